@@ -0,0 +1,244 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// CreateAutoScaleConfig creates a new autoscale config
+func (c *Client) CreateAutoScaleConfig(ctx context.Context, config *AutoScaleConfig) (*AutoScaleConfig, error) {
+	if err := validateAutoScaleConfig(config); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":                 c.APIKey,
+		"api_key_secret":          c.APISecret,
+		"target_autoscale_config": config,
+	}
+
+	var result struct {
+		AutoScaleConfig *AutoScaleConfig `json:"autoscale_config"`
+	}
+	if err := c.post(ctx, "/api/public/create_autoscale_config", payload, "autoscale_config", config.Name, &result); err != nil {
+		return nil, err
+	}
+	if result.AutoScaleConfig == nil {
+		return nil, fmt.Errorf("create_autoscale_config returned no autoscale config")
+	}
+
+	return result.AutoScaleConfig, nil
+}
+
+// GetAutoScaleConfig retrieves an autoscale config by ID
+func (c *Client) GetAutoScaleConfig(ctx context.Context, autoScaleConfigID string) (*AutoScaleConfig, error) {
+	configs, err := c.GetAutoScaleConfigs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting autoscale configs: %w", err)
+	}
+
+	for _, config := range configs {
+		if config.AutoScaleConfigID == autoScaleConfigID {
+			return &config, nil
+		}
+	}
+
+	return nil, &NotFoundError{ResourceType: "autoscale_config", ID: autoScaleConfigID}
+}
+
+// UpdateAutoScaleConfig updates an existing autoscale config
+func (c *Client) UpdateAutoScaleConfig(ctx context.Context, config *AutoScaleConfig) (*AutoScaleConfig, error) {
+	if err := validateAutoScaleConfig(config); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":                 c.APIKey,
+		"api_key_secret":          c.APISecret,
+		"target_autoscale_config": config,
+	}
+
+	var result struct {
+		AutoScaleConfig *AutoScaleConfig `json:"autoscale_config"`
+	}
+	if err := c.post(ctx, "/api/public/update_autoscale_config", payload, "autoscale_config", config.AutoScaleConfigID, &result); err != nil {
+		return nil, err
+	}
+	if result.AutoScaleConfig == nil {
+		return config, nil
+	}
+
+	return result.AutoScaleConfig, nil
+}
+
+// DeleteAutoScaleConfig deletes an autoscale config by ID
+func (c *Client) DeleteAutoScaleConfig(ctx context.Context, autoScaleConfigID string) error {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_autoscale_config": map[string]string{
+			"autoscale_config_id": autoScaleConfigID,
+		},
+	}
+
+	return c.post(ctx, "/api/public/delete_autoscale_config", payload, "autoscale_config", autoScaleConfigID, nil)
+}
+
+// GetAutoScaleConfigs retrieves all autoscale configs
+func (c *Client) GetAutoScaleConfigs(ctx context.Context) ([]AutoScaleConfig, error) {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+	}
+
+	var result struct {
+		AutoScaleConfigs []AutoScaleConfig `json:"autoscale_configs"`
+	}
+	if err := c.post(ctx, "/api/public/get_autoscale_configs", payload, "autoscale_config", "", &result); err != nil {
+		return nil, err
+	}
+
+	return result.AutoScaleConfigs, nil
+}
+
+func validateAutoScaleConfig(config *AutoScaleConfig) error {
+	if config.Name == "" {
+		return fmt.Errorf("autoscale config name is required")
+	}
+	if config.ZoneID == "" {
+		return fmt.Errorf("autoscale config must be bound to a zone")
+	}
+	if config.StandbyCores < 0 || config.StandbyMemoryMB < 0 || config.StandbyGPUs < 0 {
+		return fmt.Errorf("autoscale config standby capacity cannot be negative")
+	}
+	if config.DownscaleBackoff < 0 {
+		return fmt.Errorf("autoscale config downscale_backoff cannot be negative, got %d", config.DownscaleBackoff)
+	}
+	if config.AgentCoresOverride < 0 {
+		return fmt.Errorf("autoscale config agent_cores_override cannot be negative, got %g", config.AgentCoresOverride)
+	}
+	return nil
+}
+
+// CreateVMProviderConfig creates a new VM provider config
+func (c *Client) CreateVMProviderConfig(ctx context.Context, config *VMProviderConfig) (*VMProviderConfig, error) {
+	if err := validateVMProviderConfig(config); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":                   c.APIKey,
+		"api_key_secret":            c.APISecret,
+		"target_vm_provider_config": config,
+	}
+
+	var result struct {
+		VMProviderConfig *VMProviderConfig `json:"vm_provider_config"`
+	}
+	if err := c.post(ctx, "/api/public/create_vm_provider_config", payload, "vm_provider_config", config.Name, &result); err != nil {
+		return nil, err
+	}
+	if result.VMProviderConfig == nil {
+		return nil, fmt.Errorf("create_vm_provider_config returned no VM provider config")
+	}
+
+	return result.VMProviderConfig, nil
+}
+
+// GetVMProviderConfig retrieves a VM provider config by ID
+func (c *Client) GetVMProviderConfig(ctx context.Context, vmProviderConfigID string) (*VMProviderConfig, error) {
+	configs, err := c.GetVMProviderConfigs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting VM provider configs: %w", err)
+	}
+
+	for _, config := range configs {
+		if config.VMProviderConfigID == vmProviderConfigID {
+			return &config, nil
+		}
+	}
+
+	return nil, &NotFoundError{ResourceType: "vm_provider_config", ID: vmProviderConfigID}
+}
+
+// UpdateVMProviderConfig updates an existing VM provider config
+func (c *Client) UpdateVMProviderConfig(ctx context.Context, config *VMProviderConfig) (*VMProviderConfig, error) {
+	if err := validateVMProviderConfig(config); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":                   c.APIKey,
+		"api_key_secret":            c.APISecret,
+		"target_vm_provider_config": config,
+	}
+
+	var result struct {
+		VMProviderConfig *VMProviderConfig `json:"vm_provider_config"`
+	}
+	if err := c.post(ctx, "/api/public/update_vm_provider_config", payload, "vm_provider_config", config.VMProviderConfigID, &result); err != nil {
+		return nil, err
+	}
+	if result.VMProviderConfig == nil {
+		return config, nil
+	}
+
+	return result.VMProviderConfig, nil
+}
+
+// DeleteVMProviderConfig deletes a VM provider config by ID
+func (c *Client) DeleteVMProviderConfig(ctx context.Context, vmProviderConfigID string) error {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_vm_provider_config": map[string]string{
+			"vm_provider_config_id": vmProviderConfigID,
+		},
+	}
+
+	return c.post(ctx, "/api/public/delete_vm_provider_config", payload, "vm_provider_config", vmProviderConfigID, nil)
+}
+
+// GetVMProviderConfigs retrieves all VM provider configs
+func (c *Client) GetVMProviderConfigs(ctx context.Context) ([]VMProviderConfig, error) {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+	}
+
+	var result struct {
+		VMProviderConfigs []VMProviderConfig `json:"vm_provider_configs"`
+	}
+	if err := c.post(ctx, "/api/public/get_vm_provider_configs", payload, "vm_provider_config", "", &result); err != nil {
+		return nil, err
+	}
+
+	return result.VMProviderConfigs, nil
+}
+
+func validateVMProviderConfig(config *VMProviderConfig) error {
+	if config.Name == "" {
+		return fmt.Errorf("VM provider config name is required")
+	}
+	if !IsValidVMProvider(config.Provider) {
+		return fmt.Errorf("VM provider must be one of %v, got %q", VMProviders, config.Provider)
+	}
+	prefix := config.Provider + "_"
+	for key := range config.Settings {
+		if !strings.HasPrefix(key, prefix) {
+			return fmt.Errorf("%s VM provider config setting %q must start with %q", config.Provider, key, prefix)
+		}
+	}
+	return nil
+}
+
+// IsValidVMProvider reports whether p is one of VMProviders.
+func IsValidVMProvider(p string) bool {
+	for _, valid := range VMProviders {
+		if p == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAutoScaleConfig(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        AutoScaleConfig
+		errorContains string
+	}{
+		{name: "Valid", config: AutoScaleConfig{Name: "eu", ZoneID: "z1", StandbyCores: 4, DownscaleBackoff: 900}},
+		{name: "Missing zone", config: AutoScaleConfig{Name: "eu"}, errorContains: "must be bound to a zone"},
+		{name: "Negative standby", config: AutoScaleConfig{Name: "eu", ZoneID: "z1", StandbyGPUs: -1}, errorContains: "cannot be negative"},
+		{name: "Negative override", config: AutoScaleConfig{Name: "eu", ZoneID: "z1", AgentCoresOverride: -2}, errorContains: "agent_cores_override"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateAutoScaleConfig(&tc.config)
+			if tc.errorContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.errorContains)
+			}
+		})
+	}
+}
+
+func TestVMProviderConfig_JSON(t *testing.T) {
+	config := VMProviderConfig{
+		VMProviderConfigID: "vp1",
+		Name:               "eu-aws",
+		Provider:           VMProviderAWS,
+		Settings: map[string]interface{}{
+			"aws_region":                 "eu-west-2",
+			"aws_ec2_security_group_ids": []string{"sg-1"},
+		},
+	}
+
+	data, err := json.Marshal(config)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"vm_provider_config_id": "vp1",
+		"vm_provider_config_name": "eu-aws",
+		"vm_provider_name": "aws",
+		"aws_region": "eu-west-2",
+		"aws_ec2_security_group_ids": ["sg-1"]
+	}`, string(data))
+
+	var decoded VMProviderConfig
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "vp1", decoded.VMProviderConfigID)
+	assert.Equal(t, "eu-aws", decoded.Name)
+	assert.Equal(t, VMProviderAWS, decoded.Provider)
+	assert.Equal(t, map[string]interface{}{
+		"aws_region":                 "eu-west-2",
+		"aws_ec2_security_group_ids": []interface{}{"sg-1"},
+	}, decoded.Settings)
+}
+
+func TestValidateVMProviderConfig(t *testing.T) {
+	err := validateVMProviderConfig(&VMProviderConfig{Name: "x", Provider: "vsphere"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "must be one of")
+	}
+
+	err = validateVMProviderConfig(&VMProviderConfig{Name: "x", Provider: VMProviderGCP, Settings: map[string]interface{}{"aws_region": "eu-west-2"}})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `must start with "gcp_"`)
+	}
+
+	assert.NoError(t, validateVMProviderConfig(&VMProviderConfig{Name: "x", Provider: VMProviderDigitalOcean, Settings: map[string]interface{}{"digital_ocean_region": "lon1"}}))
+}
+
+func TestClient_AutoScaleAndVMProviderConfigs(t *testing.T) {
+	var createdAutoScale, createdVMProvider map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		switch r.URL.Path {
+		case "/api/public/create_vm_provider_config":
+			createdVMProvider = req["target_vm_provider_config"].(map[string]interface{})
+			w.Write([]byte(`{"vm_provider_config": {"vm_provider_config_id": "vp1", "vm_provider_config_name": "eu-aws", "vm_provider_name": "aws", "aws_region": "eu-west-2"}}`))
+		case "/api/public/get_vm_provider_configs":
+			w.Write([]byte(`{"vm_provider_configs": [{"vm_provider_config_id": "vp1", "vm_provider_config_name": "eu-aws", "vm_provider_name": "aws", "aws_region": "eu-west-2", "aws_ec2_ebs_volume_size_gb": 80}]}`))
+		case "/api/public/create_autoscale_config":
+			createdAutoScale = req["target_autoscale_config"].(map[string]interface{})
+			w.Write([]byte(`{"autoscale_config": {"autoscale_config_id": "as1", "autoscale_config_name": "eu", "zone_id": "z1"}}`))
+		case "/api/public/get_autoscale_configs":
+			w.Write([]byte(`{"autoscale_configs": [{"autoscale_config_id": "as1", "autoscale_config_name": "eu", "zone_id": "z1", "standby_cores": 8, "downscale_backoff": 600}]}`))
+		case "/api/public/delete_autoscale_config":
+			assert.Equal(t, map[string]interface{}{"autoscale_config_id": "as1"}, req["target_autoscale_config"])
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+	ctx := context.Background()
+
+	vmProvider, err := client.CreateVMProviderConfig(ctx, &VMProviderConfig{
+		Name:     "eu-aws",
+		Provider: VMProviderAWS,
+		Settings: map[string]interface{}{"aws_region": "eu-west-2", "aws_secret_access_key": "secret"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "vp1", vmProvider.VMProviderConfigID)
+	assert.Equal(t, "aws", createdVMProvider["vm_provider_name"])
+	assert.Equal(t, "secret", createdVMProvider["aws_secret_access_key"])
+
+	gotVMProvider, err := client.GetVMProviderConfig(ctx, "vp1")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(80), gotVMProvider.Settings["aws_ec2_ebs_volume_size_gb"])
+
+	autoScale, err := client.CreateAutoScaleConfig(ctx, &AutoScaleConfig{Name: "eu", ZoneID: "z1", Enabled: true, VMProviderConfigID: "vp1", StandbyCores: 8, DownscaleBackoff: 600})
+	assert.NoError(t, err)
+	assert.Equal(t, "as1", autoScale.AutoScaleConfigID)
+	assert.Equal(t, "vp1", createdAutoScale["vm_provider_config_id"])
+	assert.Equal(t, float64(0), createdAutoScale["agent_cores_override"])
+
+	gotAutoScale, err := client.GetAutoScaleConfig(ctx, "as1")
+	assert.NoError(t, err)
+	assert.Equal(t, 600, gotAutoScale.DownscaleBackoff)
+
+	_, err = client.GetAutoScaleConfig(ctx, "missing")
+	assert.True(t, IsNotFound(err))
+
+	assert.NoError(t, client.DeleteAutoScaleConfig(ctx, "as1"))
+}
+
+func TestClient_UpdateAutoScaleConfig_ClearsAgentCoresOverride(t *testing.T) {
+	var updated map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+		updated = req["target_autoscale_config"].(map[string]interface{})
+		w.Write([]byte(`{"autoscale_config": {"autoscale_config_id": "as1", "autoscale_config_name": "eu", "zone_id": "z1"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+
+	_, err := client.UpdateAutoScaleConfig(context.Background(), &AutoScaleConfig{AutoScaleConfigID: "as1", Name: "eu", ZoneID: "z1"})
+	assert.NoError(t, err)
+	if assert.Contains(t, updated, "agent_cores_override") {
+		assert.Equal(t, float64(0), updated["agent_cores_override"])
+	}
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"encoding/json"
+)
+
+// AutoScaleConfig describes how Kasm keeps spare agent capacity in a zone by
+// launching and destroying VMs through a VM provider config.
+type AutoScaleConfig struct {
+	AutoScaleConfigID  string `json:"autoscale_config_id,omitempty"`
+	Name               string `json:"autoscale_config_name"`
+	ZoneID             string `json:"zone_id"`
+	Enabled            bool   `json:"enabled"`
+	VMProviderConfigID string `json:"vm_provider_config_id,omitempty"`
+	// Standby capacity kept free across the zone's agents.
+	StandbyCores    float64 `json:"standby_cores"`
+	StandbyMemoryMB int     `json:"standby_memory_mb"`
+	StandbyGPUs     int     `json:"standby_gpus"`
+	// DownscaleBackoff is how long, in seconds, an idle agent is kept before
+	// it is destroyed.
+	DownscaleBackoff int `json:"downscale_backoff"`
+	// AgentCoresOverride replaces the number of cores agents report, to over-
+	// or under-provision sessions. 0 uses the reported value; it is always
+	// sent so that an update can clear a previous override.
+	AgentCoresOverride float64 `json:"agent_cores_override"`
+}
+
+// Cloud VM providers supported by Kasm autoscaling.
+const (
+	VMProviderAWS          = "aws"
+	VMProviderAzure        = "azure"
+	VMProviderGCP          = "gcp"
+	VMProviderDigitalOcean = "digital_ocean"
+	VMProviderOCI          = "oci"
+)
+
+// VMProviders lists the valid values of VMProviderConfig.Provider.
+var VMProviders = []string{
+	VMProviderAWS,
+	VMProviderAzure,
+	VMProviderGCP,
+	VMProviderDigitalOcean,
+	VMProviderOCI,
+}
+
+// VMProviderConfig holds the cloud account and VM template autoscaling uses
+// to launch agents. The cloud-specific settings are sent alongside the common
+// fields, with keys prefixed by the provider name, such as aws_region.
+type VMProviderConfig struct {
+	VMProviderConfigID string
+	Name               string
+	Provider           string
+	Settings           map[string]interface{}
+}
+
+// vmProviderConfigFields are the common fields of a VM provider config.
+type vmProviderConfigFields struct {
+	VMProviderConfigID string `json:"vm_provider_config_id,omitempty"`
+	Name               string `json:"vm_provider_config_name"`
+	Provider           string `json:"vm_provider_name"`
+}
+
+// MarshalJSON flattens Settings into the config object.
+func (c VMProviderConfig) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]interface{}, len(c.Settings)+3)
+	for key, value := range c.Settings {
+		fields[key] = value
+	}
+	if c.VMProviderConfigID != "" {
+		fields["vm_provider_config_id"] = c.VMProviderConfigID
+	}
+	fields["vm_provider_config_name"] = c.Name
+	fields["vm_provider_name"] = c.Provider
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON collects every field that is not a common field into
+// Settings.
+func (c *VMProviderConfig) UnmarshalJSON(data []byte) error {
+	var common vmProviderConfigFields
+	if err := json.Unmarshal(data, &common); err != nil {
+		return err
+	}
+	var settings map[string]interface{}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return err
+	}
+	delete(settings, "vm_provider_config_id")
+	delete(settings, "vm_provider_config_name")
+	delete(settings, "vm_provider_name")
+
+	c.VMProviderConfigID = common.VMProviderConfigID
+	c.Name = common.Name
+	c.Provider = common.Provider
+	c.Settings = settings
+	return nil
+}
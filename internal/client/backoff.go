@@ -3,6 +3,7 @@
 package client
 
 import (
+	"context"
 	"math/rand"
 	"time"
 )
@@ -49,3 +50,17 @@ func (b *ExponentialBackoff) NextBackOff() time.Duration {
 func (b *ExponentialBackoff) Reset() {
 	b.currentInterval = 0
 }
+
+// SleepContext pauses for d or until ctx is done, whichever comes first.
+// It returns ctx.Err() when the wait was cut short by cancellation.
+func SleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,220 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultCacheTTL is how long list responses are reused when no WithCacheTTL
+// option is given.
+const DefaultCacheTTL = 5 * time.Minute
+
+// Cached collections. A collection groups the cache entries that a write
+// endpoint invalidates together.
+const (
+	collectionImages      = "images"
+	collectionGroups      = "groups"
+	collectionUsers       = "users"
+	collectionGroupImages = "group_images"
+	collectionRegistries  = "registries"
+
+	collectionEgressProviders   = "egress_providers"
+	collectionEgressGateways    = "egress_gateways"
+	collectionEgressCredentials = "egress_credentials"
+	collectionEgressMappings    = "egress_provider_mappings"
+
+	collectionSSOMappings = "sso_mappings"
+)
+
+// invalidatingEndpoints lists, for each endpoint that changes server state,
+// the cached collections whose contents it may change.
+var invalidatingEndpoints = map[string][]string{
+	"/api/public/create_image":        {collectionImages, collectionGroupImages},
+	"/api/public/update_image":        {collectionImages, collectionGroupImages},
+	"/api/public/delete_image":        {collectionImages, collectionGroupImages, collectionEgressMappings},
+	"/api/public/create_group":        {collectionGroups},
+	"/api/public/update_group":        {collectionGroups, collectionUsers},
+	"/api/public/delete_group":        {collectionGroups, collectionUsers, collectionGroupImages, collectionEgressMappings, collectionSSOMappings},
+	"/api/public/add_settings_group":  {collectionGroups},
+	"/api/public/set_settings_group":  {collectionGroups},
+	"/api/public/add_user_group":      {collectionUsers, collectionGroups},
+	"/api/public/remove_user_group":   {collectionUsers, collectionGroups},
+	"/api/public/create_user":         {collectionUsers},
+	"/api/public/update_user":         {collectionUsers},
+	"/api/public/delete_user":         {collectionUsers, collectionGroups, collectionEgressMappings},
+	"/api/public/add_images_group":    {collectionGroupImages},
+	"/api/public/remove_images_group": {collectionGroupImages},
+	"/api/public/create_registry":     {collectionRegistries},
+	"/api/public/delete_registry":     {collectionRegistries},
+
+	"/api/public/update_settings_group": {collectionGroups},
+	"/api/public/remove_settings_group": {collectionGroups},
+
+	"/api/public/create_egress_provider": {collectionEgressProviders},
+	"/api/public/update_egress_provider": {collectionEgressProviders},
+	"/api/public/delete_egress_provider": {collectionEgressProviders, collectionEgressGateways, collectionEgressCredentials, collectionEgressMappings},
+	"/api/public/create_egress_gateway":  {collectionEgressGateways},
+	"/api/public/update_egress_gateway":  {collectionEgressGateways},
+	"/api/public/delete_egress_gateway":  {collectionEgressGateways},
+
+	"/api/public/create_egress_credential": {collectionEgressCredentials},
+	"/api/public/update_egress_credential": {collectionEgressCredentials},
+	"/api/public/delete_egress_credential": {collectionEgressCredentials},
+
+	"/api/public/create_egress_provider_mapping": {collectionEgressMappings},
+	"/api/public/update_egress_provider_mapping": {collectionEgressMappings},
+	"/api/public/delete_egress_provider_mapping": {collectionEgressMappings},
+
+	"/api/public/add_sso_mapping_group":    {collectionSSOMappings},
+	"/api/public/update_sso_mapping_group": {collectionSSOMappings},
+	"/api/public/delete_sso_mapping_group": {collectionSSOMappings},
+	"/api/public/delete_ldap_config":       {collectionSSOMappings},
+	"/api/public/delete_saml_config":       {collectionSSOMappings},
+	"/api/public/delete_oidc_config":       {collectionSSOMappings},
+}
+
+// WithCacheTTL sets how long list responses are reused. A ttl of 0 disables
+// caching; concurrent identical requests are still coalesced.
+func WithCacheTTL(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.cache.ttl = ttl
+	}
+}
+
+type noCacheKey struct{}
+
+// NoCache returns a context whose list requests bypass the cache, for callers
+// that poll until a change becomes visible. The fresh result still replaces
+// the cached one.
+func NoCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+type cacheKey struct {
+	collection string
+	id         string
+}
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// listCache holds list responses for a single client, and so for a single
+// provider instance. Concurrent requests for the same entry share one API
+// call.
+type listCache struct {
+	ttl   time.Duration
+	group singleflight.Group
+
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+	// generations counts the invalidations of each collection. A response is
+	// only stored when no write touched its collection while it was fetched.
+	generations map[string]uint64
+}
+
+func newListCache(ttl time.Duration) *listCache {
+	return &listCache{
+		ttl:         ttl,
+		entries:     make(map[cacheKey]cacheEntry),
+		generations: make(map[string]uint64),
+	}
+}
+
+func (lc *listCache) lookup(key cacheKey) (interface{}, uint64, bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	generation := lc.generations[key.collection]
+	entry, ok := lc.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, generation, false
+	}
+	return entry.value, generation, true
+}
+
+func (lc *listCache) store(key cacheKey, generation uint64, value interface{}) {
+	if lc.ttl <= 0 {
+		return
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if lc.generations[key.collection] != generation {
+		return
+	}
+	lc.entries[key] = cacheEntry{value: value, expires: time.Now().Add(lc.ttl)}
+}
+
+// invalidate drops every entry of the given collections.
+func (lc *listCache) invalidate(collections ...string) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	for _, collection := range collections {
+		lc.generations[collection]++
+		for key := range lc.entries {
+			if key.collection == collection {
+				delete(lc.entries, key)
+			}
+		}
+	}
+}
+
+// invalidateEndpoint drops the collections that endpoint may have changed.
+func (lc *listCache) invalidateEndpoint(endpoint string) {
+	if collections, ok := invalidatingEndpoints[endpoint]; ok {
+		lc.invalidate(collections...)
+	}
+}
+
+// cachedList returns the cached list for key, or calls fetch once for all
+// concurrent callers and caches the result. Each caller receives its own copy
+// of the slice.
+func cachedList[T any](ctx context.Context, c *Client, key cacheKey, fetch func(context.Context) ([]T, error)) ([]T, error) {
+	lc := c.cache
+	bypass := ctx.Value(noCacheKey{}) != nil
+	value, generation, ok := lc.lookup(key)
+	if ok && !bypass {
+		return append([]T(nil), value.([]T)...), nil
+	}
+
+	if bypass {
+		list, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		lc.store(key, generation, list)
+		return append([]T(nil), list...), nil
+	}
+
+	// The generation is part of the request key so that callers arriving
+	// after a write never join a request that started before it. The shared
+	// request must not fail for every caller when the caller that started it
+	// is cancelled, so it runs without that caller's cancellation.
+	flightKey := fmt.Sprintf("%s/%s/%d", key.collection, key.id, generation)
+	ch := lc.group.DoChan(flightKey, func() (interface{}, error) {
+		list, err := fetch(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+		lc.store(key, generation, list)
+		return list, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-ch:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return append([]T(nil), result.Val.([]T)...), nil
+	}
+}
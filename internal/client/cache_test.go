@@ -0,0 +1,141 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newCacheTestServer counts requests per endpoint and answers list endpoints
+// with a single item. Requests to get_groups block until release is closed
+// when release is non-nil.
+func newCacheTestServer(t *testing.T, release chan struct{}) (*httptest.Server, map[string]*int32) {
+	t.Helper()
+
+	counts := map[string]*int32{
+		"/api/public/get_groups":       new(int32),
+		"/api/public/get_users":        new(int32),
+		"/api/public/get_images_group": new(int32),
+		"/api/public/create_user":      new(int32),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if count, ok := counts[r.URL.Path]; ok {
+			atomic.AddInt32(count, 1)
+		}
+		switch r.URL.Path {
+		case "/api/public/get_groups":
+			if release != nil {
+				<-release
+			}
+			w.Write([]byte(`{"groups": [{"group_id": "g1", "name": "Group 1"}]}`))
+		case "/api/public/get_users":
+			w.Write([]byte(`{"users": [{"user_id": "u1", "username": "user1"}]}`))
+		case "/api/public/get_images_group":
+			w.Write([]byte(`{"images": [{"image_id": "i1"}]}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, counts
+}
+
+func TestListCache_ReusesResponses(t *testing.T) {
+	server, counts := newCacheTestServer(t, nil)
+	client := NewClient(server.URL, "test-key", "test-secret", false)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		users, err := client.GetUsers(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, users, 1)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(counts["/api/public/get_users"]))
+
+	t.Run("Callers get independent copies", func(t *testing.T) {
+		users, _ := client.GetUsers(ctx)
+		users[0].Username = "modified"
+		users, _ = client.GetUsers(ctx)
+		assert.Equal(t, "user1", users[0].Username)
+	})
+
+	t.Run("Group images are cached per group", func(t *testing.T) {
+		_, _ = client.GetGroupImages(ctx, "g1")
+		_, _ = client.GetGroupImages(ctx, "g1")
+		_, _ = client.GetGroupImages(ctx, "g2")
+		assert.Equal(t, int32(2), atomic.LoadInt32(counts["/api/public/get_images_group"]))
+	})
+
+	t.Run("NoCache fetches fresh data", func(t *testing.T) {
+		before := atomic.LoadInt32(counts["/api/public/get_users"])
+		_, err := client.GetUsers(NoCache(ctx))
+		assert.NoError(t, err)
+		assert.Equal(t, before+1, atomic.LoadInt32(counts["/api/public/get_users"]))
+	})
+}
+
+func TestListCache_WritesInvalidate(t *testing.T) {
+	server, counts := newCacheTestServer(t, nil)
+	client := NewClient(server.URL, "test-key", "test-secret", false)
+	ctx := context.Background()
+
+	_, _ = client.GetUsers(ctx)
+	_, _ = client.GetGroupImages(ctx, "g1")
+
+	_, err := client.CreateUser(ctx, &User{Username: "new-user"})
+	assert.NoError(t, err)
+
+	_, _ = client.GetUsers(ctx)
+	_, _ = client.GetGroupImages(ctx, "g1")
+	assert.Equal(t, int32(2), atomic.LoadInt32(counts["/api/public/get_users"]), "create_user must invalidate users")
+	assert.Equal(t, int32(1), atomic.LoadInt32(counts["/api/public/get_images_group"]), "create_user must not invalidate group images")
+}
+
+func TestListCache_CoalescesConcurrentRequests(t *testing.T) {
+	release := make(chan struct{})
+	server, counts := newCacheTestServer(t, release)
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithCacheTTL(0))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			groups, err := client.GetGroups(context.Background())
+			assert.NoError(t, err)
+			assert.Len(t, groups, 1)
+		}()
+	}
+
+	// Give every caller time to join the in-flight request.
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(counts["/api/public/get_groups"]))
+
+	// With caching disabled the next call goes to the server again.
+	_, _ = client.GetGroups(context.Background())
+	assert.Equal(t, int32(2), atomic.LoadInt32(counts["/api/public/get_groups"]))
+}
+
+func TestListCache_StaleResponseNotStored(t *testing.T) {
+	lc := newListCache(time.Minute)
+	key := cacheKey{collection: collectionUsers}
+
+	_, generation, _ := lc.lookup(key)
+	lc.invalidateEndpoint("/api/public/update_user")
+	lc.store(key, generation, []User{{UserID: "stale"}})
+
+	_, _, ok := lc.lookup(key)
+	assert.False(t, ok, "a response fetched before a write must not be cached")
+}
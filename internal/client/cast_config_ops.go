@@ -1,41 +1,25 @@
 package client
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"time"
 )
 
 // GetCastingConfigs with retry logic
-func (c *Client) GetCastingConfigs() ([]CastingConfig, error) {
+func (c *Client) GetCastingConfigs(ctx context.Context) ([]CastingConfig, error) {
 	var configs []CastingConfig
-	err := c.retryOperation(func() error {
+	err := c.retryOperation(ctx, func() error {
 		payload := map[string]interface{}{
 			"api_key":        c.APIKey,
 			"api_key_secret": c.APISecret,
 		}
 
-		resp, err := c.doRequestLegacy("POST", "/api/public/get_cast_configs", payload)
-		if err != nil {
-			return fmt.Errorf("error making request: %v", err)
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode >= 400 {
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return fmt.Errorf("failed to read error response: %v", err)
-			}
-			return fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(body))
-		}
-
 		var result struct {
 			CastConfigs []CastingConfig `json:"cast_configs"`
 		}
-		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-			return fmt.Errorf("error decoding response: %v", err)
+		if err := c.post(ctx, "/api/public/get_cast_configs", payload, "cast_config", "", &result); err != nil {
+			return err
 		}
 
 		configs = result.CastConfigs
@@ -50,34 +34,23 @@ func (c *Client) GetCastingConfigs() ([]CastingConfig, error) {
 }
 
 // GetCastingConfig retrieves a specific casting configuration
-func (c *Client) GetCastingConfig(castConfigID string) (*CastingConfig, error) {
+func (c *Client) GetCastingConfig(ctx context.Context, castConfigID string) (*CastingConfig, error) {
 	var config *CastingConfig
-	err := c.retryOperation(func() error {
+	err := c.retryOperation(ctx, func() error {
 		payload := map[string]interface{}{
 			"api_key":        c.APIKey,
 			"api_key_secret": c.APISecret,
 			"cast_config_id": castConfigID,
 		}
 
-		resp, err := c.doRequestLegacy("POST", "/api/public/get_cast_config", payload)
-		if err != nil {
-			return fmt.Errorf("error making request: %v", err)
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode >= 400 {
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return fmt.Errorf("failed to read error response: %v", err)
-			}
-			return fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(body))
-		}
-
 		var result struct {
 			CastConfig *CastingConfig `json:"cast_config"`
 		}
-		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-			return fmt.Errorf("error decoding response: %v", err)
+		if err := c.post(ctx, "/api/public/get_cast_config", payload, "cast_config", castConfigID, &result); err != nil {
+			return err
+		}
+		if result.CastConfig == nil {
+			return &NotFoundError{ResourceType: "cast_config", ID: castConfigID}
 		}
 
 		config = result.CastConfig
@@ -92,38 +65,24 @@ func (c *Client) GetCastingConfig(castConfigID string) (*CastingConfig, error) {
 }
 
 // CreateCastingConfig creates a new casting configuration
-func (c *Client) CreateCastingConfig(request *CreateCastingConfigRequest) (*CastingConfig, error) {
+func (c *Client) CreateCastingConfig(ctx context.Context, request *CreateCastingConfigRequest) (*CastingConfig, error) {
 	if err := c.validateCastingConfig(&request.TargetCastConfig); err != nil {
 		return nil, err
 	}
 
 	var config *CastingConfig
-	err := c.retryOperation(func() error {
+	err := c.retryOperation(ctx, func() error {
 		payload := map[string]interface{}{
 			"api_key":            c.APIKey,
 			"api_key_secret":     c.APISecret,
 			"target_cast_config": request.TargetCastConfig,
 		}
 
-		resp, err := c.doRequestLegacy("POST", "/api/public/create_cast_config", payload)
-		if err != nil {
-			return fmt.Errorf("error making request: %v", err)
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode >= 400 {
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return fmt.Errorf("failed to read error response: %v", err)
-			}
-			return fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(body))
-		}
-
 		var result struct {
 			CastConfig *CastingConfig `json:"cast_config"`
 		}
-		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-			return fmt.Errorf("error decoding response: %v", err)
+		if err := c.post(ctx, "/api/public/create_cast_config", payload, "cast_config", request.TargetCastConfig.CastingConfigName, &result); err != nil {
+			return err
 		}
 
 		config = result.CastConfig
@@ -138,34 +97,24 @@ func (c *Client) CreateCastingConfig(request *CreateCastingConfigRequest) (*Cast
 }
 
 // UpdateCastingConfig updates an existing casting configuration
-func (c *Client) UpdateCastingConfig(request *UpdateCastingConfigRequest) (*CastingConfig, error) {
+func (c *Client) UpdateCastingConfig(ctx context.Context, request *UpdateCastingConfigRequest) (*CastingConfig, error) {
 	if err := c.validateCastingConfig(&request.TargetCastConfig); err != nil {
 		return nil, err
 	}
 
 	var config *CastingConfig
-	err := c.retryOperation(func() error {
+	err := c.retryOperation(ctx, func() error {
 		payload := map[string]interface{}{
 			"api_key":            c.APIKey,
 			"api_key_secret":     c.APISecret,
 			"target_cast_config": request.TargetCastConfig,
 		}
 
-		resp, err := c.doRequestLegacy("POST", "/api/public/update_cast_config", payload)
-		if err != nil {
-			return fmt.Errorf("error making request: %v", err)
-		}
-		defer resp.Body.Close()
-
-		if err := c.handleAPIError(resp, "update cast config"); err != nil {
-			return err
-		}
-
 		var result struct {
 			CastConfig *CastingConfig `json:"cast_config"`
 		}
-		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-			return fmt.Errorf("error decoding response: %v", err)
+		if err := c.post(ctx, "/api/public/update_cast_config", payload, "cast_config", request.TargetCastConfig.CastConfigID, &result); err != nil {
+			return err
 		}
 
 		config = result.CastConfig
@@ -180,8 +129,8 @@ func (c *Client) UpdateCastingConfig(request *UpdateCastingConfigRequest) (*Cast
 }
 
 // DeleteCastingConfig deletes a casting configuration
-func (c *Client) DeleteCastingConfig(request *DeleteCastingConfigRequest) error {
-	return c.retryOperation(func() error {
+func (c *Client) DeleteCastingConfig(ctx context.Context, request *DeleteCastingConfigRequest) error {
+	return c.retryOperation(ctx, func() error {
 		payload := map[string]interface{}{
 			"api_key":             c.APIKey,
 			"api_key_secret":      c.APISecret,
@@ -189,20 +138,7 @@ func (c *Client) DeleteCastingConfig(request *DeleteCastingConfigRequest) error
 			"casting_config_name": request.CastingConfigName,
 		}
 
-		resp, err := c.doRequestLegacy("POST", "/api/public/delete_cast_config", payload)
-		if err != nil {
-			return fmt.Errorf("error making request: %v", err)
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode >= 400 {
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return fmt.Errorf("failed to read error response: %v", err)
-			}
-			return fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(body))
-		}
-		return nil
+		return c.post(ctx, "/api/public/delete_cast_config", payload, "cast_config", request.CastConfigID, nil)
 	})
 }
 
@@ -241,10 +177,10 @@ func (c *Client) ValidateCastingConfig(config *CastingConfig) error {
 }
 
 // GetCastingConfigByName retrieves a casting configuration by its name
-func (c *Client) GetCastingConfigByName(name string) (*CastingConfig, error) {
-	configs, err := c.GetCastingConfigs()
+func (c *Client) GetCastingConfigByName(ctx context.Context, name string) (*CastingConfig, error) {
+	configs, err := c.GetCastingConfigs(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error getting cast configs: %v", err)
+		return nil, fmt.Errorf("error getting cast configs: %w", err)
 	}
 
 	for _, config := range configs {
@@ -253,14 +189,14 @@ func (c *Client) GetCastingConfigByName(name string) (*CastingConfig, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("cast config with name %s not found", name)
+	return nil, &NotFoundError{ResourceType: "cast_config", ID: name}
 }
 
 // GetCastingConfigByKey retrieves a casting configuration by its key
-func (c *Client) GetCastingConfigByKey(key string) (*CastingConfig, error) {
-	configs, err := c.GetCastingConfigs()
+func (c *Client) GetCastingConfigByKey(ctx context.Context, key string) (*CastingConfig, error) {
+	configs, err := c.GetCastingConfigs(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error getting cast configs: %v", err)
+		return nil, fmt.Errorf("error getting cast configs: %w", err)
 	}
 
 	for _, config := range configs {
@@ -269,7 +205,7 @@ func (c *Client) GetCastingConfigByKey(key string) (*CastingConfig, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("cast config with key %s not found", key)
+	return nil, &NotFoundError{ResourceType: "cast_config", ID: key}
 }
 
 // validateCastingConfig validates the configuration before API operations
@@ -306,20 +242,8 @@ func (c *Client) validateCastingConfig(config *CastingConfig) error {
 	return nil
 }
 
-// handleAPIError processes API responses and returns appropriate errors
-func (c *Client) handleAPIError(resp *http.Response, operation string) error {
-	if resp.StatusCode >= 400 {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return fmt.Errorf("failed to read error response: %v", err)
-		}
-		return fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(body))
-	}
-	return nil
-}
-
 // retryOperation retries an operation with exponential backoff
-func (c *Client) retryOperation(operation func() error) error {
+func (c *Client) retryOperation(ctx context.Context, operation func() error) error {
 	maxRetries := 3
 	backoff := 1 * time.Second
 
@@ -327,8 +251,13 @@ func (c *Client) retryOperation(operation func() error) error {
 	for i := 0; i < maxRetries; i++ {
 		if err := operation(); err != nil {
 			lastErr = err
+			if !isTransient(err) {
+				return err
+			}
 			if i < maxRetries-1 {
-				time.Sleep(backoff)
+				if err := SleepContext(ctx, backoff); err != nil {
+					return err
+				}
 				backoff *= 2
 				continue
 			}
@@ -336,12 +265,12 @@ func (c *Client) retryOperation(operation func() error) error {
 			return nil
 		}
 	}
-	return fmt.Errorf("operation failed after %d retries: %v", maxRetries, lastErr)
+	return fmt.Errorf("operation failed after %d retries: %w", maxRetries, lastErr)
 }
 
 // GetCastingConfigsByImage retrieves all casting configurations for a specific image
-func (c *Client) GetCastingConfigsByImage(imageID string) ([]CastingConfig, error) {
-	configs, err := c.GetCastingConfigs()
+func (c *Client) GetCastingConfigsByImage(ctx context.Context, imageID string) ([]CastingConfig, error) {
+	configs, err := c.GetCastingConfigs(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -356,8 +285,8 @@ func (c *Client) GetCastingConfigsByImage(imageID string) ([]CastingConfig, erro
 }
 
 // GetCastingConfigsByGroup retrieves all casting configurations for a specific group
-func (c *Client) GetCastingConfigsByGroup(groupID string) ([]CastingConfig, error) {
-	configs, err := c.GetCastingConfigs()
+func (c *Client) GetCastingConfigsByGroup(ctx context.Context, groupID string) ([]CastingConfig, error) {
+	configs, err := c.GetCastingConfigs(ctx)
 	if err != nil {
 		return nil, err
 	}
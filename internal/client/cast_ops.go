@@ -1,10 +1,8 @@
 package client
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"errors"
-	"fmt"
 	"time"
 )
 
@@ -19,6 +17,10 @@ func isRetryableError(err error) bool {
 		return false
 	}
 	// Add conditions for retryable errors
+	var rateLimitedErr *RateLimitedError
+	if errors.As(err, &rateLimitedErr) {
+		return true
+	}
 	var apiErr *APIError
 	if errors.As(err, &apiErr) {
 		switch apiErr.StatusCode {
@@ -30,149 +32,66 @@ func isRetryableError(err error) bool {
 }
 
 // CreateCastConfig creates a new casting configuration
-func (c *Client) CreateCastConfig(config *CastConfig) (*CastConfig, error) {
+func (c *Client) CreateCastConfig(ctx context.Context, config *CastConfig) (*CastConfig, error) {
 	req := createCastConfigRequest{
 		APIKey:           c.APIKey,
 		APIKeySecret:     c.APISecret,
 		TargetCastConfig: *config,
 	}
 
-	reqBody, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling request body: %v", err)
-	}
-
-	resp, err := c.HTTPClient.Post(
-		c.BaseURL+"/api/public/create_cast_config",
-		"application/json",
-		bytes.NewReader(reqBody),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		// Read error response
-		var errorResp struct {
-			ErrorMessage string `json:"error_message"`
-		}
-		if err := json.NewDecoder(resp.Body).Decode(&errorResp); err == nil && errorResp.ErrorMessage != "" {
-			return nil, fmt.Errorf("API request failed: %s", errorResp.ErrorMessage)
-		}
-		return nil, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
-	}
-
 	var result createCastConfigResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/create_cast_config", req, "cast_config", config.CastingConfigName, &result); err != nil {
+		return nil, err
 	}
 
 	return &result.CastConfig, nil
 }
 
 // GetCastConfig retrieves a casting configuration by ID
-func (c *Client) GetCastConfig(id string) (*CastConfig, error) {
+func (c *Client) GetCastConfig(ctx context.Context, id string) (*CastConfig, error) {
 	req := getCastConfigRequest{
 		APIKey:       c.APIKey,
 		APIKeySecret: c.APISecret,
 		CastConfigID: id,
 	}
 
-	reqBody, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling request body: %v", err)
-	}
-
-	resp, err := c.HTTPClient.Post(
-		c.BaseURL+"/api/public/get_cast_config",
-		"application/json",
-		bytes.NewReader(reqBody),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
-	}
-
 	var result getCastConfigResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/get_cast_config", req, "cast_config", id, &result); err != nil {
+		return nil, err
 	}
 
 	return &result.CastConfig, nil
 }
 
 // UpdateCastConfig updates an existing casting configuration
-func (c *Client) UpdateCastConfig(config *CastConfig) (*CastConfig, error) {
+func (c *Client) UpdateCastConfig(ctx context.Context, config *CastConfig) (*CastConfig, error) {
 	req := updateCastConfigRequest{
 		APIKey:           c.APIKey,
 		APIKeySecret:     c.APISecret,
 		TargetCastConfig: *config,
 	}
 
-	reqBody, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling request body: %v", err)
-	}
-
-	resp, err := c.HTTPClient.Post(
-		c.BaseURL+"/api/public/update_cast_config",
-		"application/json",
-		bytes.NewReader(reqBody),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
-	}
-
 	var result updateCastConfigResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/update_cast_config", req, "cast_config", config.ID, &result); err != nil {
+		return nil, err
 	}
 
 	return &result.CastConfig, nil
 }
 
 // DeleteCastConfig deletes a casting configuration
-func (c *Client) DeleteCastConfig(id string) error {
+func (c *Client) DeleteCastConfig(ctx context.Context, id string) error {
 	req := deleteCastConfigRequest{
 		APIKey:       c.APIKey,
 		APIKeySecret: c.APISecret,
 		CastConfigID: id,
 	}
 
-	reqBody, err := json.Marshal(req)
-	if err != nil {
-		return fmt.Errorf("error marshaling request body: %v", err)
-	}
-
-	resp, err := c.HTTPClient.Post(
-		c.BaseURL+"/api/public/delete_cast_config",
-		"application/json",
-		bytes.NewReader(reqBody),
-	)
-	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
-	}
-
-	return nil
+	return c.post(ctx, "/api/public/delete_cast_config", req, "cast_config", id, nil)
 }
 
 // ListCastConfigs retrieves all casting configurations
-func (c *Client) ListCastConfigs() ([]CastConfig, error) {
+func (c *Client) ListCastConfigs(ctx context.Context) ([]CastConfig, error) {
 	req := struct {
 		APIKey       string `json:"api_key"`
 		APIKeySecret string `json:"api_key_secret"`
@@ -181,30 +100,11 @@ func (c *Client) ListCastConfigs() ([]CastConfig, error) {
 		APIKeySecret: c.APISecret,
 	}
 
-	reqBody, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling request body: %v", err)
-	}
-
-	resp, err := c.HTTPClient.Post(
-		c.BaseURL+"/api/public/get_cast_configs",
-		"application/json",
-		bytes.NewReader(reqBody),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
-	}
-
 	var result struct {
 		CastConfigs []CastConfig `json:"cast_configs"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/get_cast_configs", req, "cast_config", "", &result); err != nil {
+		return nil, err
 	}
 
 	return result.CastConfigs, nil
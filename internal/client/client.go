@@ -1,7 +1,6 @@
 package client
 
 import (
-	"context"
 	"crypto/tls"
 	"net/http"
 	"sync"
@@ -18,8 +17,16 @@ type Client struct {
 	Version     APIVersion
 	rateLimiter *RateLimiter
 	retryConfig *RetryConfig
-	debugMode   bool
-	mu          sync.RWMutex
+	middleware  []Middleware
+	// baseTransport is the transport below the middleware chain, and
+	// headers the extra headers set by WithHeaders. Recording downloads use
+	// them directly; see downloadClient.
+	baseTransport http.RoundTripper
+	headers       map[string]string
+	serverInfo    *ServerInfo
+	cache         *listCache
+	debugMode     bool
+	mu            sync.RWMutex
 }
 
 type RetryConfig struct {
@@ -30,6 +37,20 @@ type RetryConfig struct {
 	RandomizationFactor float64
 }
 
+// Default rate limit applied when no WithRateLimit option is given.
+const (
+	DefaultRequestsPerSecond = 10
+	DefaultBurst             = 100
+)
+
+// Defaults for retries and request timeouts.
+const (
+	DefaultMaxRetries           = 3
+	DefaultRetryInitialInterval = 100 * time.Millisecond
+	DefaultRetryMaxInterval     = 10 * time.Second
+	DefaultRequestTimeout       = 30 * time.Second
+)
+
 type APIVersion string
 
 const (
@@ -40,6 +61,7 @@ const (
 // NewClient creates a new API client with options
 func NewClient(baseURL, apiKey, apiSecret string, insecure bool, options ...ClientOption) *Client {
 	tr := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
 	}
 
@@ -48,17 +70,18 @@ func NewClient(baseURL, apiKey, apiSecret string, insecure bool, options ...Clie
 		APIKey:      apiKey,
 		APISecret:   apiSecret,
 		Version:     APIVersionLatest,
-		rateLimiter: NewRateLimiter(100, time.Second),
+		rateLimiter: NewRateLimiterPerSecond(DefaultRequestsPerSecond, DefaultBurst),
+		cache:       newListCache(DefaultCacheTTL),
 		retryConfig: &RetryConfig{
-			MaxRetries:          3,
-			InitialInterval:     100 * time.Millisecond,
-			MaxInterval:         10 * time.Second,
+			MaxRetries:          DefaultMaxRetries,
+			InitialInterval:     DefaultRetryInitialInterval,
+			MaxInterval:         DefaultRetryMaxInterval,
 			Multiplier:          2.0,
 			RandomizationFactor: 0.1,
 		},
 		HTTPClient: &http.Client{
 			Transport: tr,
-			Timeout:   time.Second * 30,
+			Timeout:   DefaultRequestTimeout,
 		},
 	}
 
@@ -67,10 +90,38 @@ func NewClient(baseURL, apiKey, apiSecret string, insecure bool, options ...Clie
 		option(client)
 	}
 
+	client.baseTransport = client.HTTPClient.Transport
+	client.HTTPClient.Transport = chainMiddleware(client.HTTPClient.Transport, client.middleware)
+
 	return client
 }
 
-// Legacy support for old code
-func (c *Client) doRequestLegacy(method, path string, payload map[string]interface{}) (*http.Response, error) {
-	return c.doRequest(context.Background(), method, path, payload)
+// WithRateLimit sets the client-side rate limit to requestsPerSecond with
+// bursts of up to burst requests.
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = NewRateLimiterPerSecond(requestsPerSecond, burst)
+	}
+}
+
+// WithMaxRetries sets how many times a failed request is retried.
+func WithMaxRetries(maxRetries int) ClientOption {
+	return func(c *Client) {
+		c.retryConfig.MaxRetries = maxRetries
+	}
+}
+
+// WithRetryInterval sets the initial and maximum backoff between retries.
+func WithRetryInterval(initial, max time.Duration) ClientOption {
+	return func(c *Client) {
+		c.retryConfig.InitialInterval = initial
+		c.retryConfig.MaxInterval = max
+	}
+}
+
+// WithRequestTimeout sets the timeout for a single HTTP request attempt.
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.HTTPClient.Timeout = timeout
+	}
 }
@@ -4,10 +4,13 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func setupTestServer(t *testing.T, path string, expectedMethod string, expectedBody map[string]interface{}, response interface{}, statusCode int) *httptest.Server {
@@ -85,7 +88,7 @@ func TestLogoutUser(t *testing.T) {
 			defer server.Close()
 
 			client := NewClient(server.URL, "test-key", "test-secret", true)
-			err := client.LogoutUser(tc.userID)
+			err := client.LogoutUser(context.Background(), tc.userID)
 
 			if tc.expectError && err == nil {
 				t.Error("Expected error but got none")
@@ -147,7 +150,7 @@ func TestGetUserAttributes(t *testing.T) {
 			defer server.Close()
 
 			client := NewClient(server.URL, "test-key", "test-secret", true)
-			attrs, err := client.GetUserAttributes(tc.userID)
+			attrs, err := client.GetUserAttributes(context.Background(), tc.userID)
 
 			if tc.expectError && err == nil {
 				t.Error("Expected error but got none")
@@ -214,7 +217,7 @@ func TestUpdateUserAttributes(t *testing.T) {
 			defer server.Close()
 
 			client := NewClient(server.URL, "test-key", "test-secret", true)
-			err := client.UpdateUserAttributes(tc.userID, tc.attributes)
+			err := client.UpdateUserAttributes(context.Background(), tc.userID, tc.attributes)
 
 			if tc.expectError && err == nil {
 				t.Error("Expected error but got none")
@@ -272,14 +275,9 @@ func TestClient_Keepalive(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client := &Client{
-				HTTPClient: server.Client(),
-				BaseURL:    server.URL,
-				APIKey:     "test-api-key",
-				APISecret:  "test-api-secret",
-			}
+			client := NewClient(server.URL, "test-api-key", "test-api-secret", false)
 
-			response, err := client.Keepalive(tt.kasmID)
+			response, err := client.Keepalive(context.Background(), tt.kasmID)
 			if (err != nil) != tt.expectError {
 				t.Errorf("Client.Keepalive() error = %v, expectError %v", err, tt.expectError)
 				return
@@ -291,3 +289,29 @@ func TestClient_Keepalive(t *testing.T) {
 		})
 	}
 }
+
+func TestDoRequest_ContextCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false)
+	client.retryConfig.InitialInterval = time.Minute
+	client.retryConfig.MaxInterval = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.doRequest(ctx, "POST", "/api/public/get_users", nil)
+	if err == nil {
+		t.Fatal("Expected error from cancelled context, got nil")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected backoff wait to stop on cancellation, took %v", elapsed)
+	}
+}
@@ -0,0 +1,616 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// CreateEgressProvider creates a new egress provider
+func (c *Client) CreateEgressProvider(ctx context.Context, provider *EgressProvider) (*EgressProvider, error) {
+	if err := validateEgressProvider(provider); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":                c.APIKey,
+		"api_key_secret":         c.APISecret,
+		"target_egress_provider": provider,
+	}
+
+	var result struct {
+		EgressProvider *EgressProvider `json:"egress_provider"`
+	}
+	if err := c.post(ctx, "/api/public/create_egress_provider", payload, "egress_provider", provider.Name, &result); err != nil {
+		return nil, err
+	}
+	if result.EgressProvider == nil {
+		return nil, fmt.Errorf("create_egress_provider returned no egress provider")
+	}
+
+	return result.EgressProvider, nil
+}
+
+// GetEgressProvider retrieves an egress provider by ID
+func (c *Client) GetEgressProvider(ctx context.Context, egressProviderID string) (*EgressProvider, error) {
+	providers, err := c.GetEgressProviders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting egress providers: %w", err)
+	}
+
+	for _, provider := range providers {
+		if provider.EgressProviderID == egressProviderID {
+			return &provider, nil
+		}
+	}
+
+	return nil, &NotFoundError{ResourceType: "egress_provider", ID: egressProviderID}
+}
+
+// UpdateEgressProvider updates an existing egress provider
+func (c *Client) UpdateEgressProvider(ctx context.Context, provider *EgressProvider) (*EgressProvider, error) {
+	if err := validateEgressProvider(provider); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":                c.APIKey,
+		"api_key_secret":         c.APISecret,
+		"target_egress_provider": provider,
+	}
+
+	var result struct {
+		EgressProvider *EgressProvider `json:"egress_provider"`
+	}
+	if err := c.post(ctx, "/api/public/update_egress_provider", payload, "egress_provider", provider.EgressProviderID, &result); err != nil {
+		return nil, err
+	}
+	if result.EgressProvider == nil {
+		return provider, nil
+	}
+
+	return result.EgressProvider, nil
+}
+
+// DeleteEgressProvider deletes an egress provider by ID
+func (c *Client) DeleteEgressProvider(ctx context.Context, egressProviderID string) error {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_egress_provider": map[string]string{
+			"egress_provider_id": egressProviderID,
+		},
+	}
+
+	return c.post(ctx, "/api/public/delete_egress_provider", payload, "egress_provider", egressProviderID, nil)
+}
+
+// GetEgressProviders retrieves all egress providers. The result is cached;
+// see NoCache.
+func (c *Client) GetEgressProviders(ctx context.Context) ([]EgressProvider, error) {
+	return cachedList(ctx, c, cacheKey{collection: collectionEgressProviders}, c.getEgressProviders)
+}
+
+// getEgressProviders fetches all egress providers, bypassing the cache
+func (c *Client) getEgressProviders(ctx context.Context) ([]EgressProvider, error) {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+	}
+
+	var result struct {
+		EgressProviders []EgressProvider `json:"egress_providers"`
+	}
+	if err := c.post(ctx, "/api/public/get_egress_providers", payload, "egress_provider", "", &result); err != nil {
+		return nil, err
+	}
+
+	return result.EgressProviders, nil
+}
+
+// CreateEgressGateway creates a new gateway for an egress provider
+func (c *Client) CreateEgressGateway(ctx context.Context, gateway *EgressGateway) (*EgressGateway, error) {
+	if err := validateEgressGateway(gateway); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":               c.APIKey,
+		"api_key_secret":        c.APISecret,
+		"target_egress_gateway": gateway,
+	}
+
+	var result struct {
+		EgressGateway *EgressGateway `json:"egress_gateway"`
+	}
+	if err := c.post(ctx, "/api/public/create_egress_gateway", payload, "egress_gateway", gateway.Name, &result); err != nil {
+		return nil, err
+	}
+	if result.EgressGateway == nil {
+		return nil, fmt.Errorf("create_egress_gateway returned no egress gateway")
+	}
+
+	return result.EgressGateway, nil
+}
+
+// GetEgressGateway retrieves an egress gateway by ID
+func (c *Client) GetEgressGateway(ctx context.Context, egressGatewayID string) (*EgressGateway, error) {
+	gateways, err := c.GetEgressGateways(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting egress gateways: %w", err)
+	}
+
+	for _, gateway := range gateways {
+		if gateway.EgressGatewayID == egressGatewayID {
+			return &gateway, nil
+		}
+	}
+
+	return nil, &NotFoundError{ResourceType: "egress_gateway", ID: egressGatewayID}
+}
+
+// UpdateEgressGateway updates an existing egress gateway
+func (c *Client) UpdateEgressGateway(ctx context.Context, gateway *EgressGateway) (*EgressGateway, error) {
+	if err := validateEgressGateway(gateway); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":               c.APIKey,
+		"api_key_secret":        c.APISecret,
+		"target_egress_gateway": gateway,
+	}
+
+	var result struct {
+		EgressGateway *EgressGateway `json:"egress_gateway"`
+	}
+	if err := c.post(ctx, "/api/public/update_egress_gateway", payload, "egress_gateway", gateway.EgressGatewayID, &result); err != nil {
+		return nil, err
+	}
+	if result.EgressGateway == nil {
+		return gateway, nil
+	}
+
+	return result.EgressGateway, nil
+}
+
+// DeleteEgressGateway deletes an egress gateway by ID
+func (c *Client) DeleteEgressGateway(ctx context.Context, egressGatewayID string) error {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_egress_gateway": map[string]string{
+			"egress_gateway_id": egressGatewayID,
+		},
+	}
+
+	return c.post(ctx, "/api/public/delete_egress_gateway", payload, "egress_gateway", egressGatewayID, nil)
+}
+
+// GetEgressGateways retrieves the gateways of every egress provider. The
+// result is cached; see NoCache.
+func (c *Client) GetEgressGateways(ctx context.Context) ([]EgressGateway, error) {
+	return cachedList(ctx, c, cacheKey{collection: collectionEgressGateways}, c.getEgressGateways)
+}
+
+// getEgressGateways fetches all egress gateways, bypassing the cache
+func (c *Client) getEgressGateways(ctx context.Context) ([]EgressGateway, error) {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+	}
+
+	var result struct {
+		EgressGateways []EgressGateway `json:"egress_gateways"`
+	}
+	if err := c.post(ctx, "/api/public/get_egress_gateways", payload, "egress_gateway", "", &result); err != nil {
+		return nil, err
+	}
+
+	return result.EgressGateways, nil
+}
+
+// validateEgressProvider checks the fields the API would otherwise reject
+// with an unhelpful message.
+func validateEgressProvider(provider *EgressProvider) error {
+	if provider.Name == "" {
+		return fmt.Errorf("egress provider name is required")
+	}
+	if !IsValidEgressProviderType(provider.Type) {
+		return fmt.Errorf("egress provider type must be one of %v, got %q", EgressProviderTypes, provider.Type)
+	}
+	if provider.Type == EgressProviderTypeCustom && provider.Image == "" {
+		return fmt.Errorf("egress_image is required for custom egress providers")
+	}
+	return nil
+}
+
+// validateEgressGateway checks the fields the API would otherwise reject
+// with an unhelpful message.
+func validateEgressGateway(gateway *EgressGateway) error {
+	if gateway.Name == "" {
+		return fmt.Errorf("egress gateway name is required")
+	}
+	if gateway.EgressProviderID == "" {
+		return fmt.Errorf("egress gateway must belong to an egress provider")
+	}
+	return nil
+}
+
+// IsValidEgressProviderType reports whether t is one of EgressProviderTypes.
+func IsValidEgressProviderType(t string) bool {
+	for _, valid := range EgressProviderTypes {
+		if t == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateEgressCredential creates a new credential for an egress provider
+func (c *Client) CreateEgressCredential(ctx context.Context, credential *EgressCredential) (*EgressCredential, error) {
+	if err := validateEgressCredential(credential); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":                  c.APIKey,
+		"api_key_secret":           c.APISecret,
+		"target_egress_credential": credential,
+	}
+
+	var result struct {
+		EgressCredential *EgressCredential `json:"egress_credential"`
+	}
+	if err := c.post(ctx, "/api/public/create_egress_credential", payload, "egress_credential", credential.Name, &result); err != nil {
+		return nil, err
+	}
+	if result.EgressCredential == nil {
+		return nil, fmt.Errorf("create_egress_credential returned no egress credential")
+	}
+
+	return result.EgressCredential, nil
+}
+
+// GetEgressCredential retrieves an egress credential by ID. The server does
+// not return the secret fields.
+func (c *Client) GetEgressCredential(ctx context.Context, egressCredentialID string) (*EgressCredential, error) {
+	credentials, err := c.GetEgressCredentials(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting egress credentials: %w", err)
+	}
+
+	for _, credential := range credentials {
+		if credential.EgressCredentialID == egressCredentialID {
+			return &credential, nil
+		}
+	}
+
+	return nil, &NotFoundError{ResourceType: "egress_credential", ID: egressCredentialID}
+}
+
+// UpdateEgressCredential updates an existing egress credential
+func (c *Client) UpdateEgressCredential(ctx context.Context, credential *EgressCredential) (*EgressCredential, error) {
+	if err := validateEgressCredential(credential); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":                  c.APIKey,
+		"api_key_secret":           c.APISecret,
+		"target_egress_credential": credential,
+	}
+
+	var result struct {
+		EgressCredential *EgressCredential `json:"egress_credential"`
+	}
+	if err := c.post(ctx, "/api/public/update_egress_credential", payload, "egress_credential", credential.EgressCredentialID, &result); err != nil {
+		return nil, err
+	}
+	if result.EgressCredential == nil {
+		return credential, nil
+	}
+
+	return result.EgressCredential, nil
+}
+
+// DeleteEgressCredential deletes an egress credential by ID
+func (c *Client) DeleteEgressCredential(ctx context.Context, egressCredentialID string) error {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_egress_credential": map[string]string{
+			"egress_credential_id": egressCredentialID,
+		},
+	}
+
+	return c.post(ctx, "/api/public/delete_egress_credential", payload, "egress_credential", egressCredentialID, nil)
+}
+
+// GetEgressCredentials retrieves all egress credentials without their
+// secrets. The result is cached; see NoCache.
+func (c *Client) GetEgressCredentials(ctx context.Context) ([]EgressCredential, error) {
+	return cachedList(ctx, c, cacheKey{collection: collectionEgressCredentials}, c.getEgressCredentials)
+}
+
+// getEgressCredentials fetches all egress credentials, bypassing the cache
+func (c *Client) getEgressCredentials(ctx context.Context) ([]EgressCredential, error) {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+	}
+
+	var result struct {
+		EgressCredentials []EgressCredential `json:"egress_credentials"`
+	}
+	if err := c.post(ctx, "/api/public/get_egress_credentials", payload, "egress_credential", "", &result); err != nil {
+		return nil, err
+	}
+
+	return result.EgressCredentials, nil
+}
+
+// validateEgressCredential checks that the credential carries exactly one
+// kind of secret. Syntax of the configuration files is checked at plan time.
+func validateEgressCredential(credential *EgressCredential) error {
+	if credential.Name == "" {
+		return fmt.Errorf("egress credential name is required")
+	}
+	if credential.EgressProviderID == "" {
+		return fmt.Errorf("egress credential must belong to an egress provider")
+	}
+	if credential.WireGuardConfig == "" && credential.OpenVPNConfig == "" && credential.Username == "" {
+		return fmt.Errorf("egress credential needs a wireguard_config, an openvpn_config or a username")
+	}
+	if credential.WireGuardConfig != "" && (credential.OpenVPNConfig != "" || credential.Username != "" || credential.Password != "") {
+		return fmt.Errorf("wireguard_config cannot be combined with other credentials")
+	}
+	if (credential.Username == "") != (credential.Password == "") {
+		return fmt.Errorf("username and password must be set together")
+	}
+	return nil
+}
+
+// CreateEgressMapping maps an egress provider to a user, group or image
+func (c *Client) CreateEgressMapping(ctx context.Context, mapping *EgressMapping) (*EgressMapping, error) {
+	if err := validateEgressMapping(mapping); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":                        c.APIKey,
+		"api_key_secret":                 c.APISecret,
+		"target_egress_provider_mapping": mapping,
+	}
+
+	var result struct {
+		EgressMapping *EgressMapping `json:"egress_provider_mapping"`
+	}
+	if err := c.post(ctx, "/api/public/create_egress_provider_mapping", payload, "egress_provider_mapping", mapping.EgressProviderID, &result); err != nil {
+		return nil, err
+	}
+	if result.EgressMapping == nil {
+		return nil, fmt.Errorf("create_egress_provider_mapping returned no egress provider mapping")
+	}
+
+	return result.EgressMapping, nil
+}
+
+// GetEgressMapping retrieves an egress provider mapping by ID
+func (c *Client) GetEgressMapping(ctx context.Context, egressMappingID string) (*EgressMapping, error) {
+	mappings, err := c.GetEgressMappings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting egress provider mappings: %w", err)
+	}
+
+	for _, mapping := range mappings {
+		if mapping.EgressMappingID == egressMappingID {
+			return &mapping, nil
+		}
+	}
+
+	return nil, &NotFoundError{ResourceType: "egress_provider_mapping", ID: egressMappingID}
+}
+
+// UpdateEgressMapping updates the flags of an existing egress provider mapping
+func (c *Client) UpdateEgressMapping(ctx context.Context, mapping *EgressMapping) (*EgressMapping, error) {
+	if err := validateEgressMapping(mapping); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":                        c.APIKey,
+		"api_key_secret":                 c.APISecret,
+		"target_egress_provider_mapping": mapping,
+	}
+
+	var result struct {
+		EgressMapping *EgressMapping `json:"egress_provider_mapping"`
+	}
+	if err := c.post(ctx, "/api/public/update_egress_provider_mapping", payload, "egress_provider_mapping", mapping.EgressMappingID, &result); err != nil {
+		return nil, err
+	}
+	if result.EgressMapping == nil {
+		return mapping, nil
+	}
+
+	return result.EgressMapping, nil
+}
+
+// DeleteEgressMapping deletes an egress provider mapping by ID
+func (c *Client) DeleteEgressMapping(ctx context.Context, egressMappingID string) error {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_egress_provider_mapping": map[string]string{
+			"egress_provider_mapping_id": egressMappingID,
+		},
+	}
+
+	return c.post(ctx, "/api/public/delete_egress_provider_mapping", payload, "egress_provider_mapping", egressMappingID, nil)
+}
+
+// GetEgressMappings retrieves all egress provider mappings. The result is
+// cached; see NoCache.
+func (c *Client) GetEgressMappings(ctx context.Context) ([]EgressMapping, error) {
+	return cachedList(ctx, c, cacheKey{collection: collectionEgressMappings}, c.getEgressMappings)
+}
+
+// getEgressMappings fetches all egress provider mappings, bypassing the cache
+func (c *Client) getEgressMappings(ctx context.Context) ([]EgressMapping, error) {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+	}
+
+	var result struct {
+		EgressMappings []EgressMapping `json:"egress_provider_mappings"`
+	}
+	if err := c.post(ctx, "/api/public/get_egress_provider_mappings", payload, "egress_provider_mapping", "", &result); err != nil {
+		return nil, err
+	}
+
+	return result.EgressMappings, nil
+}
+
+func validateEgressMapping(mapping *EgressMapping) error {
+	if mapping.EgressProviderID == "" {
+		return fmt.Errorf("egress provider mapping must reference an egress provider")
+	}
+	targets := 0
+	for _, id := range []string{mapping.UserID, mapping.GroupID, mapping.ImageID} {
+		if id != "" {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return fmt.Errorf("egress provider mapping needs exactly one of user_id, group_id and image_id, got %d", targets)
+	}
+	if mapping.Default && !mapping.Allowed {
+		return fmt.Errorf("an egress provider mapping cannot be the default without being allowed")
+	}
+	return nil
+}
+
+// GetEffectiveEgress works out the egress providers userID can choose from,
+// optionally when launching imageID, and which of them is preselected.
+func (c *Client) GetEffectiveEgress(ctx context.Context, userID, imageID string) ([]EffectiveEgress, error) {
+	user, err := c.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := c.GetGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting groups: %w", err)
+	}
+	mappings, err := c.GetEgressMappings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting egress provider mappings: %w", err)
+	}
+	providers, err := c.GetEgressProviders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting egress providers: %w", err)
+	}
+
+	return resolveEffectiveEgress(user, groups, imageID, mappings, providers), nil
+}
+
+// egressRank orders mappings by specificity: user mappings beat image
+// mappings, which beat group mappings, and group mappings are ordered by
+// group priority, where the lowest number wins as elsewhere in Kasm.
+type egressRank struct {
+	level    int
+	priority int
+}
+
+func (r egressRank) less(o egressRank) bool {
+	if r.level != o.level {
+		return r.level < o.level
+	}
+	return r.priority < o.priority
+}
+
+// resolveEffectiveEgress applies the mappings that target user, imageID or
+// one of the user's groups. For each provider the most specific mapping
+// decides whether it is allowed; disabled providers are never offered. The
+// default is the allowed option whose default mapping is most specific, with
+// ties broken by provider name. Options are returned sorted by name.
+func resolveEffectiveEgress(user *User, groups []Group, imageID string, mappings []EgressMapping, providers []EgressProvider) []EffectiveEgress {
+	priorities := make(map[string]int, len(groups))
+	for _, group := range groups {
+		priorities[group.GroupID] = group.Priority
+	}
+	memberOf := make(map[string]bool, len(user.Groups))
+	for _, group := range user.Groups {
+		memberOf[group.GroupID] = true
+		if _, ok := priorities[group.GroupID]; !ok {
+			priorities[group.GroupID] = group.Priority
+		}
+	}
+
+	type decision struct {
+		mapping EgressMapping
+		rank    egressRank
+	}
+	decisions := make(map[string]decision)
+	for _, mapping := range mappings {
+		var rank egressRank
+		switch {
+		case mapping.UserID != "" && mapping.UserID == user.UserID:
+			rank = egressRank{level: 0}
+		case mapping.ImageID != "" && mapping.ImageID == imageID:
+			rank = egressRank{level: 1}
+		case mapping.GroupID != "" && memberOf[mapping.GroupID]:
+			rank = egressRank{level: 2, priority: priorities[mapping.GroupID]}
+		default:
+			continue
+		}
+		current, ok := decisions[mapping.EgressProviderID]
+		// Among equally specific mappings, one that allows access wins.
+		if !ok || rank.less(current.rank) || (rank == current.rank && mapping.Allowed && !current.mapping.Allowed) {
+			decisions[mapping.EgressProviderID] = decision{mapping: mapping, rank: rank}
+		}
+	}
+
+	options := make([]EffectiveEgress, 0, len(decisions))
+	defaultIndex := -1
+	var defaultRank egressRank
+	for _, provider := range providers {
+		d, ok := decisions[provider.EgressProviderID]
+		if !ok || !d.mapping.Allowed || !provider.Enabled {
+			continue
+		}
+		option := EffectiveEgress{
+			EgressProviderID: provider.EgressProviderID,
+			Name:             provider.Name,
+			Type:             provider.Type,
+		}
+		switch {
+		case d.mapping.UserID != "":
+			option.Source, option.SourceID = EgressMappingSourceUser, d.mapping.UserID
+		case d.mapping.ImageID != "":
+			option.Source, option.SourceID = EgressMappingSourceImage, d.mapping.ImageID
+		default:
+			option.Source, option.SourceID = EgressMappingSourceGroup, d.mapping.GroupID
+		}
+		options = append(options, option)
+	}
+	sort.Slice(options, func(i, j int) bool {
+		if options[i].Name != options[j].Name {
+			return options[i].Name < options[j].Name
+		}
+		return options[i].EgressProviderID < options[j].EgressProviderID
+	})
+
+	for i, option := range options {
+		d := decisions[option.EgressProviderID]
+		if d.mapping.Default && (defaultIndex < 0 || d.rank.less(defaultRank)) {
+			defaultIndex, defaultRank = i, d.rank
+		}
+	}
+	if defaultIndex >= 0 {
+		options[defaultIndex].Default = true
+	}
+
+	return options
+}
@@ -0,0 +1,327 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateEgressProvider(t *testing.T) {
+	testCases := []struct {
+		name          string
+		provider      EgressProvider
+		errorContains string
+	}{
+		{
+			name:     "WireGuard",
+			provider: EgressProvider{Name: "wg", Type: EgressProviderTypeWireGuard},
+		},
+		{
+			name:     "Custom with image",
+			provider: EgressProvider{Name: "custom", Type: EgressProviderTypeCustom, Image: "example/egress:1.0"},
+		},
+		{
+			name:          "Unknown type",
+			provider:      EgressProvider{Name: "ipsec", Type: "ipsec"},
+			errorContains: "must be one of",
+		},
+		{
+			name:          "Custom without image",
+			provider:      EgressProvider{Name: "custom", Type: EgressProviderTypeCustom},
+			errorContains: "egress_image is required",
+		},
+		{
+			name:          "Missing name",
+			provider:      EgressProvider{Type: EgressProviderTypeOpenVPN},
+			errorContains: "name is required",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateEgressProvider(&tc.provider)
+			if tc.errorContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.errorContains)
+			}
+		})
+	}
+}
+
+func TestClient_EgressProviderCRUD(t *testing.T) {
+	var created map[string]interface{}
+	var listCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		switch r.URL.Path {
+		case "/api/public/create_egress_provider":
+			created = req["target_egress_provider"].(map[string]interface{})
+			w.Write([]byte(`{"egress_provider": {"egress_provider_id": "ep1", "name": "wg", "egress_provider_type": "wireguard", "enabled": true}}`))
+		case "/api/public/get_egress_providers":
+			atomic.AddInt32(&listCalls, 1)
+			w.Write([]byte(`{"egress_providers": [{"egress_provider_id": "ep1", "name": "wg", "egress_provider_type": "wireguard", "enabled": true}]}`))
+		case "/api/public/delete_egress_provider":
+			assert.Equal(t, map[string]interface{}{"egress_provider_id": "ep1"}, req["target_egress_provider"])
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+	ctx := context.Background()
+
+	provider, err := client.CreateEgressProvider(ctx, &EgressProvider{Name: "wg", Type: EgressProviderTypeWireGuard, Enabled: true, Config: "[Interface]"})
+	assert.NoError(t, err)
+	assert.Equal(t, "ep1", provider.EgressProviderID)
+	assert.Equal(t, "wireguard", created["egress_provider_type"])
+	assert.Equal(t, "[Interface]", created["egress_config"])
+	assert.NotContains(t, created, "egress_provider_id")
+
+	// Lookups by ID share the cached list.
+	for i := 0; i < 2; i++ {
+		got, err := client.GetEgressProvider(ctx, "ep1")
+		assert.NoError(t, err)
+		assert.Equal(t, "wg", got.Name)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&listCalls))
+
+	_, err = client.GetEgressProvider(ctx, "missing")
+	assert.True(t, IsNotFound(err))
+
+	assert.NoError(t, client.DeleteEgressProvider(ctx, "ep1"))
+	_, err = client.GetEgressProviders(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&listCalls), "delete should invalidate the cached list")
+}
+
+func TestClient_EgressGatewayCRUD(t *testing.T) {
+	var updated map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		switch r.URL.Path {
+		case "/api/public/create_egress_gateway":
+			w.Write([]byte(`{"egress_gateway": {"egress_gateway_id": "eg1", "egress_provider_id": "ep1", "name": "London"}}`))
+		case "/api/public/update_egress_gateway":
+			updated = req["target_egress_gateway"].(map[string]interface{})
+			w.Write([]byte(`{}`))
+		case "/api/public/get_egress_gateways":
+			w.Write([]byte(`{"egress_gateways": [{"egress_gateway_id": "eg1", "egress_provider_id": "ep1", "name": "London", "country": "GB"}]}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+	ctx := context.Background()
+
+	_, err := client.CreateEgressGateway(ctx, &EgressGateway{Name: "London"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "must belong to an egress provider")
+	}
+
+	gateway, err := client.CreateEgressGateway(ctx, &EgressGateway{EgressProviderID: "ep1", Name: "London"})
+	assert.NoError(t, err)
+	assert.Equal(t, "eg1", gateway.EgressGatewayID)
+
+	gateway.Country = "GB"
+	_, err = client.UpdateEgressGateway(ctx, gateway)
+	assert.NoError(t, err)
+	assert.Equal(t, "eg1", updated["egress_gateway_id"])
+	assert.Equal(t, "GB", updated["country"])
+
+	got, err := client.GetEgressGateway(ctx, "eg1")
+	assert.NoError(t, err)
+	assert.Equal(t, "GB", got.Country)
+}
+
+func TestClient_EgressCredentialCRUD(t *testing.T) {
+	var created map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		switch r.URL.Path {
+		case "/api/public/create_egress_credential":
+			created = req["target_egress_credential"].(map[string]interface{})
+			w.Write([]byte(`{"egress_credential": {"egress_credential_id": "ec1", "egress_provider_id": "ep1", "name": "office", "username": "alice"}}`))
+		case "/api/public/get_egress_credentials":
+			w.Write([]byte(`{"egress_credentials": [{"egress_credential_id": "ec1", "egress_provider_id": "ep1", "name": "office", "username": "alice"}]}`))
+		case "/api/public/delete_egress_credential":
+			assert.Equal(t, map[string]interface{}{"egress_credential_id": "ec1"}, req["target_egress_credential"])
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+	ctx := context.Background()
+
+	_, err := client.CreateEgressCredential(ctx, &EgressCredential{EgressProviderID: "ep1", Name: "office", Username: "alice"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "username and password must be set together")
+	}
+
+	_, err = client.CreateEgressCredential(ctx, &EgressCredential{EgressProviderID: "ep1", Name: "office", WireGuardConfig: "[Interface]", Username: "alice", Password: "secret"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "cannot be combined")
+	}
+
+	credential, err := client.CreateEgressCredential(ctx, &EgressCredential{EgressProviderID: "ep1", Name: "office", Username: "alice", Password: "secret"})
+	assert.NoError(t, err)
+	assert.Equal(t, "ec1", credential.EgressCredentialID)
+	assert.Equal(t, "secret", created["password"])
+	assert.NotContains(t, created, "wireguard_config")
+
+	got, err := client.GetEgressCredential(ctx, "ec1")
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", got.Username)
+	assert.Empty(t, got.Password)
+
+	assert.NoError(t, client.DeleteEgressCredential(ctx, "ec1"))
+}
+
+func TestValidateEgressMapping(t *testing.T) {
+	testCases := []struct {
+		name          string
+		mapping       EgressMapping
+		errorContains string
+	}{
+		{
+			name:    "Group",
+			mapping: EgressMapping{EgressProviderID: "ep1", GroupID: "g1", Allowed: true, Default: true},
+		},
+		{
+			name:          "No target",
+			mapping:       EgressMapping{EgressProviderID: "ep1", Allowed: true},
+			errorContains: "exactly one of user_id, group_id and image_id",
+		},
+		{
+			name:          "Two targets",
+			mapping:       EgressMapping{EgressProviderID: "ep1", UserID: "u1", ImageID: "i1", Allowed: true},
+			errorContains: "got 2",
+		},
+		{
+			name:          "Default but not allowed",
+			mapping:       EgressMapping{EgressProviderID: "ep1", UserID: "u1", Default: true},
+			errorContains: "cannot be the default",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateEgressMapping(&tc.mapping)
+			if tc.errorContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.errorContains)
+			}
+		})
+	}
+}
+
+func TestResolveEffectiveEgress(t *testing.T) {
+	user := &User{UserID: "u1", Groups: []Group{{GroupID: "staff"}, {GroupID: "london"}}}
+	groups := []Group{
+		{GroupID: "staff", Priority: 100},
+		{GroupID: "london", Priority: 10},
+		{GroupID: "paris", Priority: 1},
+	}
+	providers := []EgressProvider{
+		{EgressProviderID: "uk", Name: "UK", Type: EgressProviderTypeWireGuard, Enabled: true},
+		{EgressProviderID: "us", Name: "US", Type: EgressProviderTypeWireGuard, Enabled: true},
+		{EgressProviderID: "fr", Name: "France", Type: EgressProviderTypeOpenVPN, Enabled: true},
+		{EgressProviderID: "de", Name: "Germany", Type: EgressProviderTypeOpenVPN, Enabled: false},
+		{EgressProviderID: "jp", Name: "Japan", Type: EgressProviderTypeWireGuard, Enabled: true},
+	}
+	mappings := []EgressMapping{
+		{EgressProviderID: "us", GroupID: "staff", Allowed: true, Default: true},
+		{EgressProviderID: "uk", GroupID: "london", Allowed: true, Default: true},
+		{EgressProviderID: "fr", GroupID: "staff", Allowed: true},
+		// The user has opted out of France.
+		{EgressProviderID: "fr", UserID: "u1", Allowed: false},
+		// Disabled providers are never offered.
+		{EgressProviderID: "de", GroupID: "staff", Allowed: true},
+		// Not a member of paris.
+		{EgressProviderID: "jp", GroupID: "paris", Allowed: true, Default: true},
+		{EgressProviderID: "jp", ImageID: "chrome", Allowed: true},
+	}
+
+	options := resolveEffectiveEgress(user, groups, "", mappings, providers)
+	assert.Equal(t, []EffectiveEgress{
+		{EgressProviderID: "uk", Name: "UK", Type: "wireguard", Default: true, Source: EgressMappingSourceGroup, SourceID: "london"},
+		{EgressProviderID: "us", Name: "US", Type: "wireguard", Source: EgressMappingSourceGroup, SourceID: "staff"},
+	}, options, "the london group has the higher priority, so its default wins")
+
+	options = resolveEffectiveEgress(user, groups, "chrome", mappings, providers)
+	if assert.Len(t, options, 3) {
+		assert.Equal(t, "jp", options[0].EgressProviderID)
+		assert.Equal(t, EgressMappingSourceImage, options[0].Source)
+		assert.False(t, options[0].Default)
+		assert.True(t, options[1].Default)
+	}
+
+	options = resolveEffectiveEgress(&User{UserID: "u2"}, groups, "", mappings, providers)
+	assert.Empty(t, options)
+}
+
+func TestClient_EgressMappingCRUD(t *testing.T) {
+	var created map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		switch r.URL.Path {
+		case "/api/public/create_egress_provider_mapping":
+			created = req["target_egress_provider_mapping"].(map[string]interface{})
+			w.Write([]byte(`{"egress_provider_mapping": {"egress_provider_mapping_id": "m1", "egress_provider_id": "ep1", "group_id": "g1", "is_default": true, "allowed": true}}`))
+		case "/api/public/get_egress_provider_mappings":
+			w.Write([]byte(`{"egress_provider_mappings": [{"egress_provider_mapping_id": "m1", "egress_provider_id": "ep1", "group_id": "g1", "is_default": true, "allowed": true}]}`))
+		case "/api/public/delete_egress_provider_mapping":
+			assert.Equal(t, map[string]interface{}{"egress_provider_mapping_id": "m1"}, req["target_egress_provider_mapping"])
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+	ctx := context.Background()
+
+	mapping, err := client.CreateEgressMapping(ctx, &EgressMapping{EgressProviderID: "ep1", GroupID: "g1", Default: true, Allowed: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "m1", mapping.EgressMappingID)
+	assert.Equal(t, "g1", created["group_id"])
+	assert.NotContains(t, created, "user_id")
+
+	got, err := client.GetEgressMapping(ctx, "m1")
+	assert.NoError(t, err)
+	assert.True(t, got.Default)
+
+	_, err = client.GetEgressMapping(ctx, "missing")
+	assert.True(t, IsNotFound(err))
+
+	assert.NoError(t, client.DeleteEgressMapping(ctx, "m1"))
+}
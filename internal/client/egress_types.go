@@ -0,0 +1,93 @@
+package client
+
+// Egress provider types accepted by Kasm.
+const (
+	EgressProviderTypeWireGuard = "wireguard"
+	EgressProviderTypeOpenVPN   = "openvpn"
+	EgressProviderTypeCustom    = "custom"
+)
+
+// EgressProviderTypes lists the valid values of EgressProvider.Type.
+var EgressProviderTypes = []string{
+	EgressProviderTypeWireGuard,
+	EgressProviderTypeOpenVPN,
+	EgressProviderTypeCustom,
+}
+
+// EgressProvider is a VPN service that session traffic can be routed
+// through. Its gateways are the individual endpoints of that service.
+type EgressProvider struct {
+	EgressProviderID string `json:"egress_provider_id,omitempty"`
+	Name             string `json:"name"`
+	Type             string `json:"egress_provider_type"`
+	Description      string `json:"description,omitempty"`
+	Enabled          bool   `json:"enabled"`
+	// Image is the container image that runs the egress connection. It is
+	// required for custom providers and overrides the built-in image
+	// otherwise.
+	Image string `json:"egress_image,omitempty"`
+	// Config is configuration shared by every gateway of the provider.
+	Config string `json:"egress_config,omitempty"`
+}
+
+// EgressGateway is a single endpoint of an egress provider, such as one
+// WireGuard peer or OpenVPN server.
+type EgressGateway struct {
+	EgressGatewayID  string `json:"egress_gateway_id,omitempty"`
+	EgressProviderID string `json:"egress_provider_id"`
+	Name             string `json:"name"`
+	Country          string `json:"country,omitempty"`
+	City             string `json:"city,omitempty"`
+	Enabled          bool   `json:"enabled"`
+	// Config is the connection configuration, such as a WireGuard .conf or
+	// OpenVPN .ovpn file.
+	Config string `json:"egress_config,omitempty"`
+}
+
+// EgressCredential holds the secrets sessions use to connect through an
+// egress provider: a WireGuard configuration, an OpenVPN profile, or a
+// username and password, the latter optionally alongside an OpenVPN profile.
+type EgressCredential struct {
+	EgressCredentialID string `json:"egress_credential_id,omitempty"`
+	EgressProviderID   string `json:"egress_provider_id"`
+	Name               string `json:"name"`
+	WireGuardConfig    string `json:"wireguard_config,omitempty"`
+	OpenVPNConfig      string `json:"openvpn_config,omitempty"`
+	Username           string `json:"username,omitempty"`
+	Password           string `json:"password,omitempty"`
+}
+
+// EgressMapping grants a user, a group or a workspace image access to an
+// egress provider. Exactly one of UserID, GroupID and ImageID is set.
+type EgressMapping struct {
+	EgressMappingID  string `json:"egress_provider_mapping_id,omitempty"`
+	EgressProviderID string `json:"egress_provider_id"`
+	UserID           string `json:"user_id,omitempty"`
+	GroupID          string `json:"group_id,omitempty"`
+	ImageID          string `json:"image_id,omitempty"`
+	// Default preselects the provider when a session is launched.
+	Default bool `json:"is_default"`
+	// Allowed is false for mappings that withdraw access granted by a less
+	// specific mapping.
+	Allowed bool `json:"allowed"`
+}
+
+// Sources of an effective egress option, from most to least specific.
+const (
+	EgressMappingSourceUser  = "user"
+	EgressMappingSourceImage = "image"
+	EgressMappingSourceGroup = "group"
+)
+
+// EffectiveEgress is an egress provider a user can choose when launching a
+// session, with the mapping that decided it.
+type EffectiveEgress struct {
+	EgressProviderID string
+	Name             string
+	Type             string
+	Default          bool
+	// Source is the kind of mapping that decided the option, one of the
+	// EgressMappingSource constants, and SourceID the ID of its target.
+	Source   string
+	SourceID string
+}
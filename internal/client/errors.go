@@ -1,26 +1,41 @@
 package client
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
 
 // NotFoundError represents a resource not found error
 type NotFoundError struct {
 	ResourceType string
 	ID           string
+	Message      string
 }
 
 func (e *NotFoundError) Error() string {
+	if e.Message != "" && e.ID == "" {
+		return fmt.Sprintf("%s not found: %s", e.ResourceType, e.Message)
+	}
 	return fmt.Sprintf("%s not found: %s", e.ResourceType, e.ID)
 }
 
+// IsNotFound reports whether err is a NotFoundError for any resource type.
+func IsNotFound(err error) bool {
+	var nfe *NotFoundError
+	return errors.As(err, &nfe)
+}
+
+// IsNotFoundOf reports whether err is a NotFoundError for resourceType.
+func IsNotFoundOf(err error, resourceType string) bool {
+	var nfe *NotFoundError
+	return errors.As(err, &nfe) && nfe.ResourceType == resourceType
+}
+
 // IsGroupNotFoundError checks if the error is due to a group not being found
 func IsGroupNotFoundError(err error) bool {
-	if err == nil {
-		return false
-	}
-	if nfe, ok := err.(*NotFoundError); ok {
-		return nfe.ResourceType == "group"
-	}
-	return false
+	return IsNotFoundOf(err, "group")
 }
 
 // APIError represents an error returned by the API
@@ -48,3 +63,88 @@ func (e *UnauthorizedError) Error() string {
 	}
 	return "Unauthorized"
 }
+
+// ForbiddenError represents a request the API key is not permitted to make.
+// NotLicensed is set when the feature requires a Kasm license the server
+// does not have.
+type ForbiddenError struct {
+	Message     string
+	NotLicensed bool
+}
+
+func (e *ForbiddenError) Error() string {
+	if e.NotLicensed {
+		return fmt.Sprintf("Not licensed: %s", e.Message)
+	}
+	if e.Message != "" {
+		return fmt.Sprintf("Forbidden: %s", e.Message)
+	}
+	return "Forbidden"
+}
+
+// IsNotLicensed reports whether err is a ForbiddenError caused by a missing
+// Kasm license.
+func IsNotLicensed(err error) bool {
+	var fe *ForbiddenError
+	return errors.As(err, &fe) && fe.NotLicensed
+}
+
+// ConflictError represents a request that clashes with existing state, such
+// as creating an object whose name is already taken.
+type ConflictError struct {
+	ResourceType string
+	Message      string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s conflict: %s", e.ResourceType, e.Message)
+}
+
+// RateLimitedError represents a request rejected by server-side rate
+// limiting after all retries were used.
+type RateLimitedError struct {
+	RetryAfter time.Duration
+	Message    string
+}
+
+func (e *RateLimitedError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("Rate limited, retry after %v: %s", e.RetryAfter, e.Message)
+	}
+	return fmt.Sprintf("Rate limited: %s", e.Message)
+}
+
+// ValidationError represents a request the API rejected as invalid. Message
+// carries the server's explanation.
+type ValidationError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("API rejected request: %s", e.Message)
+}
+
+// isTransient reports whether err may succeed if the operation is repeated.
+// Server errors, rate limiting and transport failures are transient; an API
+// answer that classified the request (not found, rejected, unauthorized) is
+// not, and neither is a cancelled context.
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var (
+		nfe *NotFoundError
+		ue  *UnauthorizedError
+		fe  *ForbiddenError
+		ce  *ConflictError
+		ve  *ValidationError
+	)
+	switch {
+	case errors.As(err, &nfe), errors.As(err, &ue), errors.As(err, &fe),
+		errors.As(err, &ce), errors.As(err, &ve):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+	"log"
+)
+
+// ExecCommandKasm runs config.Cmd inside the running session kasmID owned by
+// userID and returns its exit code and output. The session's image must
+// allow exec and the API key needs the exec permission.
+func (c *Client) ExecCommandKasm(ctx context.Context, kasmID string, userID string, config ExecConfig) (*ExecCommandResponse, error) {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_user": map[string]interface{}{
+			"user_id": userID,
+		},
+		"target_kasm": map[string]interface{}{
+			"kasm_id":   kasmID,
+			"kasm_exec": config,
+		},
+	}
+
+	log.Printf("[DEBUG] Executing command in kasm %s", kasmID)
+
+	var result ExecCommandResponse
+	if err := c.post(ctx, "/api/public/exec_command", payload, "kasm", kasmID, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
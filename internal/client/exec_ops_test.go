@@ -0,0 +1,67 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_ExecCommandKasm(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/public/exec_command", r.URL.Path)
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"kasm": {"exit_code": 2, "stdout": "out", "stderr": "err"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false)
+	resp, err := client.ExecCommandKasm(context.Background(), "kasm-1", "user-1", ExecConfig{
+		Cmd:         "ls",
+		Environment: map[string]string{"FOO": "bar"},
+		Workdir:     "/tmp",
+		Privileged:  true,
+		User:        "root",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	assert.Equal(t, 2, resp.Kasm.ExitCode)
+	assert.Equal(t, "out", resp.Kasm.Stdout)
+	assert.Equal(t, "err", resp.Kasm.Stderr)
+
+	assert.Equal(t, map[string]interface{}{"user_id": "user-1"}, received["target_user"])
+	targetKasm := received["target_kasm"].(map[string]interface{})
+	assert.Equal(t, "kasm-1", targetKasm["kasm_id"])
+	assert.Equal(t, map[string]interface{}{
+		"cmd":         "ls",
+		"environment": map[string]interface{}{"FOO": "bar"},
+		"workdir":     "/tmp",
+		"privileged":  true,
+		"user":        "root",
+	}, targetKasm["kasm_exec"])
+}
+
+func TestClient_ExecCommandKasm_NotRetried(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false)
+	_, err := client.ExecCommandKasm(context.Background(), "kasm-1", "user-1", ExecConfig{Cmd: "touch /tmp/once"})
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests), "a command must not run twice")
+}
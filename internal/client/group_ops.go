@@ -1,16 +1,13 @@
 package client
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 )
 
 // CreateGroup creates a new group
-func (c *Client) CreateGroup(group *Group) (*Group, error) {
-	body, err := json.Marshal(map[string]interface{}{
+func (c *Client) CreateGroup(ctx context.Context, group *Group) (*Group, error) {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_group": map[string]interface{}{
@@ -19,34 +16,20 @@ func (c *Client) CreateGroup(group *Group) (*Group, error) {
 			"description": group.Description,
 			"permissions": group.Permissions,
 		},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling request body: %v", err)
-	}
-
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/create_group", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	bodyBytes, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
 	}
 
 	var result struct {
 		Group Group `json:"group"`
 	}
-	if err := json.Unmarshal(bodyBytes, &result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/create_group", payload, "group", group.Name, &result); err != nil {
+		return nil, err
 	}
 
 	// If this group has sharing permissions, add the allow_kasm_sharing setting
 	if group.Permissions != nil {
 		for _, perm := range group.Permissions {
 			if perm == "allow_kasm_sharing" {
-				settingBody, err := json.Marshal(map[string]interface{}{
+				settingPayload := map[string]interface{}{
 					"api_key":        c.APIKey,
 					"api_key_secret": c.APISecret,
 					"target_group": map[string]interface{}{
@@ -56,16 +39,11 @@ func (c *Client) CreateGroup(group *Group) (*Group, error) {
 						"group_setting_id": "13ef8423a5bb445cacbb6bd9f44a2454",
 						"value":            "True",
 					},
-				})
-				if err != nil {
-					return nil, fmt.Errorf("error marshaling setting request: %v", err)
 				}
 
-				settingResp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/add_settings_group", "application/json", bytes.NewBuffer(settingBody))
-				if err != nil {
-					return nil, fmt.Errorf("error setting allow_kasm_sharing: %v", err)
+				if err := c.post(ctx, "/api/public/add_settings_group", settingPayload, "group", result.Group.GroupID, nil); err != nil {
+					return nil, fmt.Errorf("error setting allow_kasm_sharing: %w", err)
 				}
-				settingResp.Body.Close()
 				break
 			}
 		}
@@ -75,10 +53,10 @@ func (c *Client) CreateGroup(group *Group) (*Group, error) {
 }
 
 // GetGroup retrieves a group by ID
-func (c *Client) GetGroup(groupID string) (*Group, error) {
-	groups, err := c.GetGroups()
+func (c *Client) GetGroup(ctx context.Context, groupID string) (*Group, error) {
+	groups, err := c.GetGroups(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error getting groups: %v", err)
+		return nil, fmt.Errorf("error getting groups: %w", err)
 	}
 
 	for _, group := range groups {
@@ -94,30 +72,17 @@ func (c *Client) GetGroup(groupID string) (*Group, error) {
 }
 
 // UpdateGroup updates an existing group
-func (c *Client) UpdateGroup(group *Group) (*Group, error) {
-	body, err := json.Marshal(map[string]interface{}{
+func (c *Client) UpdateGroup(ctx context.Context, group *Group) (*Group, error) {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_group":   group,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/update_group", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
 	}
 
 	var result struct {
 		Group Group `json:"group"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := c.post(ctx, "/api/public/update_group", payload, "group", group.GroupID, &result); err != nil {
 		return nil, err
 	}
 
@@ -125,92 +90,61 @@ func (c *Client) UpdateGroup(group *Group) (*Group, error) {
 }
 
 // DeleteGroup deletes a group by ID
-func (c *Client) DeleteGroup(groupID string) error {
-	body, err := json.Marshal(map[string]interface{}{
+func (c *Client) DeleteGroup(ctx context.Context, groupID string) error {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_group": map[string]string{
 			"group_id": groupID,
 		},
-	})
-	if err != nil {
-		return err
-	}
-
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/delete_group", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
-	}
+	return c.post(ctx, "/api/public/delete_group", payload, "group", groupID, nil)
+}
 
-	return nil
+// GetGroups retrieves all groups. The result is cached; see NoCache.
+func (c *Client) GetGroups(ctx context.Context) ([]Group, error) {
+	return cachedList(ctx, c, cacheKey{collection: collectionGroups}, c.getGroups)
 }
 
-// GetGroups retrieves all groups
-func (c *Client) GetGroups() ([]Group, error) {
-	body, err := json.Marshal(map[string]interface{}{
+// getGroups fetches all groups, bypassing the cache
+func (c *Client) getGroups(ctx context.Context) ([]Group, error) {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling request body: %v", err)
-	}
-
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/get_groups", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	bodyBytes, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
 	}
 
 	var result struct {
 		Groups []Group `json:"groups"`
 	}
-	if err := json.Unmarshal(bodyBytes, &result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/get_groups", payload, "group", "", &result); err != nil {
+		return nil, err
 	}
 
 	return result.Groups, nil
 }
 
 // GetUsersGroup gets the users in a group
-func (c *Client) GetUsersGroup(userID string) ([]GroupUser, error) {
-	body, err := json.Marshal(map[string]interface{}{
+func (c *Client) GetUsersGroup(ctx context.Context, userID string) ([]GroupUser, error) {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_user": map[string]string{
 			"user_id": userID,
 		},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling request body: %v", err)
 	}
 
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/get_users_group", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
 	var result GetUsersGroupResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/get_users_group", payload, "user", userID, &result); err != nil {
+		return nil, err
 	}
 
 	return result.Users, nil
 }
 
 // AddUserToGroup adds a user to a group
-func (c *Client) AddUserToGroup(userID string, groupID string) error {
-	body, err := json.Marshal(map[string]interface{}{
+func (c *Client) AddUserToGroup(ctx context.Context, userID string, groupID string) error {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_group": map[string]interface{}{
@@ -219,28 +153,14 @@ func (c *Client) AddUserToGroup(userID string, groupID string) error {
 		"target_user": map[string]interface{}{
 			"user_id": userID,
 		},
-	})
-	if err != nil {
-		return fmt.Errorf("error marshaling request body: %v", err)
 	}
 
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/add_user_group", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	return nil
+	return c.post(ctx, "/api/public/add_user_group", payload, "group", groupID, nil)
 }
 
 // RemoveUserFromGroup removes a user from a group
-func (c *Client) RemoveUserFromGroup(userID string, groupID string) error {
-	body, err := json.Marshal(map[string]interface{}{
+func (c *Client) RemoveUserFromGroup(ctx context.Context, userID string, groupID string) error {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_group": map[string]interface{}{
@@ -249,57 +169,42 @@ func (c *Client) RemoveUserFromGroup(userID string, groupID string) error {
 		"target_user": map[string]interface{}{
 			"user_id": userID,
 		},
-	})
-	if err != nil {
-		return fmt.Errorf("error marshaling request body: %v", err)
 	}
 
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/remove_user_group", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
-	}
+	return c.post(ctx, "/api/public/remove_user_group", payload, "group", groupID, nil)
+}
 
-	return nil
+// GetGroupImages retrieves all images for a group. The result is cached;
+// see NoCache.
+func (c *Client) GetGroupImages(ctx context.Context, groupID string) ([]GroupImage, error) {
+	return cachedList(ctx, c, cacheKey{collection: collectionGroupImages, id: groupID}, func(ctx context.Context) ([]GroupImage, error) {
+		return c.getGroupImages(ctx, groupID)
+	})
 }
 
-// GetGroupImages retrieves all images for a group
-func (c *Client) GetGroupImages(groupID string) ([]GroupImage, error) {
-	body, err := json.Marshal(map[string]interface{}{
+// getGroupImages fetches the images of a group, bypassing the cache
+func (c *Client) getGroupImages(ctx context.Context, groupID string) ([]GroupImage, error) {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_group": map[string]interface{}{
 			"group_id": groupID,
 		},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling request body: %v", err)
-	}
-
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/get_images_group", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
 	}
-	defer resp.Body.Close()
 
 	var result struct {
 		Images []GroupImage `json:"images"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/get_images_group", payload, "group", groupID, &result); err != nil {
+		return nil, err
 	}
 
 	return result.Images, nil
 }
 
 // AddGroupImage adds an image to a group
-func (c *Client) AddGroupImage(groupID string, imageID string) error {
-	body, err := json.Marshal(map[string]interface{}{
+func (c *Client) AddGroupImage(ctx context.Context, groupID string, imageID string) error {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_group": map[string]interface{}{
@@ -308,28 +213,14 @@ func (c *Client) AddGroupImage(groupID string, imageID string) error {
 		"target_image": map[string]interface{}{
 			"image_id": imageID,
 		},
-	})
-	if err != nil {
-		return fmt.Errorf("error marshaling request body: %v", err)
-	}
-
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/add_images_group", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	return nil
+	return c.post(ctx, "/api/public/add_images_group", payload, "group", groupID, nil)
 }
 
 // RemoveGroupImage removes an image from a group
-func (c *Client) RemoveGroupImage(groupID string, imageID string) error {
-	body, err := json.Marshal(map[string]interface{}{
+func (c *Client) RemoveGroupImage(ctx context.Context, groupID string, imageID string) error {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_group": map[string]interface{}{
@@ -338,21 +229,136 @@ func (c *Client) RemoveGroupImage(groupID string, imageID string) error {
 		"target_image": map[string]interface{}{
 			"image_id": imageID,
 		},
-	})
-	if err != nil {
-		return fmt.Errorf("error marshaling request body: %v", err)
 	}
 
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/remove_images_group", "application/json", bytes.NewBuffer(body))
+	return c.post(ctx, "/api/public/remove_images_group", payload, "group", groupID, nil)
+}
+
+// AddGroupSSOMapping maps an LDAP, SAML or OIDC provider to a group
+func (c *Client) AddGroupSSOMapping(ctx context.Context, mapping *GroupSSOMapping) (*GroupSSOMapping, error) {
+	if err := validateGroupSSOMapping(mapping); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":            c.APIKey,
+		"api_key_secret":     c.APISecret,
+		"target_sso_mapping": mapping,
+	}
+
+	var result struct {
+		SSOMapping *GroupSSOMapping `json:"sso_mapping"`
+	}
+	if err := c.post(ctx, "/api/public/add_sso_mapping_group", payload, "group", mapping.GroupID, &result); err != nil {
+		return nil, err
+	}
+	if result.SSOMapping == nil {
+		return nil, fmt.Errorf("add_sso_mapping_group returned no SSO mapping")
+	}
+
+	return result.SSOMapping, nil
+}
+
+// GetGroupSSOMapping retrieves an SSO mapping of a group by ID
+func (c *Client) GetGroupSSOMapping(ctx context.Context, groupID, mappingID string) (*GroupSSOMapping, error) {
+	mappings, err := c.GetGroupSSOMappings(ctx, groupID)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return nil, fmt.Errorf("error getting SSO mappings: %w", err)
+	}
+
+	for _, mapping := range mappings {
+		if mapping.SSOGroupMappingID == mappingID {
+			return &mapping, nil
+		}
+	}
+
+	return nil, &NotFoundError{ResourceType: "sso_mapping", ID: mappingID}
+}
+
+// UpdateGroupSSOMapping updates an existing SSO mapping of a group
+func (c *Client) UpdateGroupSSOMapping(ctx context.Context, mapping *GroupSSOMapping) (*GroupSSOMapping, error) {
+	if err := validateGroupSSOMapping(mapping); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":            c.APIKey,
+		"api_key_secret":     c.APISecret,
+		"target_sso_mapping": mapping,
+	}
+
+	var result struct {
+		SSOMapping *GroupSSOMapping `json:"sso_mapping"`
 	}
-	defer resp.Body.Close()
+	if err := c.post(ctx, "/api/public/update_sso_mapping_group", payload, "sso_mapping", mapping.SSOGroupMappingID, &result); err != nil {
+		return nil, err
+	}
+	if result.SSOMapping == nil {
+		return mapping, nil
+	}
+
+	return result.SSOMapping, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
+// DeleteGroupSSOMapping deletes an SSO mapping of a group by ID
+func (c *Client) DeleteGroupSSOMapping(ctx context.Context, mappingID string) error {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_sso_mapping": map[string]string{
+			"sso_group_mapping_id": mappingID,
+		},
 	}
 
+	return c.post(ctx, "/api/public/delete_sso_mapping_group", payload, "sso_mapping", mappingID, nil)
+}
+
+// GetGroupSSOMappings retrieves the SSO mappings of a group. The result is
+// cached; see NoCache.
+func (c *Client) GetGroupSSOMappings(ctx context.Context, groupID string) ([]GroupSSOMapping, error) {
+	return cachedList(ctx, c, cacheKey{collection: collectionSSOMappings, id: groupID}, func(ctx context.Context) ([]GroupSSOMapping, error) {
+		return c.getGroupSSOMappings(ctx, groupID)
+	})
+}
+
+// getGroupSSOMappings fetches the SSO mappings of a group, bypassing the cache
+func (c *Client) getGroupSSOMappings(ctx context.Context, groupID string) ([]GroupSSOMapping, error) {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_group": map[string]interface{}{
+			"group_id": groupID,
+		},
+	}
+
+	var result struct {
+		SSOMappings []GroupSSOMapping `json:"sso_mappings"`
+	}
+	if err := c.post(ctx, "/api/public/get_sso_mappings_group", payload, "group", groupID, &result); err != nil {
+		return nil, err
+	}
+
+	return result.SSOMappings, nil
+}
+
+func validateGroupSSOMapping(mapping *GroupSSOMapping) error {
+	if mapping.GroupID == "" {
+		return fmt.Errorf("SSO mapping must reference a group")
+	}
+	providers := 0
+	for _, id := range []string{mapping.LDAPID, mapping.SAMLID, mapping.OIDCID} {
+		if id != "" {
+			providers++
+		}
+	}
+	if providers != 1 {
+		return fmt.Errorf("SSO mapping needs exactly one of ldap_id, saml_id and oidc_id, got %d", providers)
+	}
+	if mapping.ApplyToAllUsers && mapping.GroupAttribute != "" {
+		return fmt.Errorf("SSO mapping cannot set a group attribute when applying to all users")
+	}
+	if !mapping.ApplyToAllUsers && mapping.GroupAttribute == "" {
+		return fmt.Errorf("SSO mapping needs a group attribute unless it applies to all users")
+	}
 	return nil
 }
@@ -0,0 +1,120 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateGroupSSOMapping(t *testing.T) {
+	testCases := []struct {
+		name          string
+		mapping       GroupSSOMapping
+		errorContains string
+	}{
+		{name: "LDAP group", mapping: GroupSSOMapping{GroupID: "g1", LDAPID: "l1", GroupAttribute: "CN=Developers,DC=example,DC=com"}},
+		{name: "OIDC all users", mapping: GroupSSOMapping{GroupID: "g1", OIDCID: "o1", ApplyToAllUsers: true}},
+		{name: "No group", mapping: GroupSSOMapping{SAMLID: "s1", GroupAttribute: "developers"}, errorContains: "must reference a group"},
+		{name: "No provider", mapping: GroupSSOMapping{GroupID: "g1", GroupAttribute: "developers"}, errorContains: "got 0"},
+		{name: "Two providers", mapping: GroupSSOMapping{GroupID: "g1", LDAPID: "l1", SAMLID: "s1", GroupAttribute: "developers"}, errorContains: "got 2"},
+		{name: "No attribute", mapping: GroupSSOMapping{GroupID: "g1", SAMLID: "s1"}, errorContains: "needs a group attribute"},
+		{name: "Attribute and all users", mapping: GroupSSOMapping{GroupID: "g1", SAMLID: "s1", GroupAttribute: "developers", ApplyToAllUsers: true}, errorContains: "cannot set a group attribute"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateGroupSSOMapping(&tc.mapping)
+			if tc.errorContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.errorContains)
+			}
+		})
+	}
+}
+
+func TestGroupSSOMapping_SSOType(t *testing.T) {
+	testCases := []struct {
+		mapping GroupSSOMapping
+		ssoType string
+		ssoID   string
+	}{
+		{mapping: GroupSSOMapping{LDAPID: "l1"}, ssoType: SSOTypeLDAP, ssoID: "l1"},
+		{mapping: GroupSSOMapping{SAMLID: "s1"}, ssoType: SSOTypeSAML, ssoID: "s1"},
+		{mapping: GroupSSOMapping{OIDCID: "o1"}, ssoType: SSOTypeOIDC, ssoID: "o1"},
+		{mapping: GroupSSOMapping{}, ssoType: "", ssoID: ""},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.ssoType, tc.mapping.SSOType())
+		assert.Equal(t, tc.ssoID, tc.mapping.SSOID())
+	}
+}
+
+func TestClient_GroupSSOMappingCRUD(t *testing.T) {
+	var created map[string]interface{}
+	var lists atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		switch r.URL.Path {
+		case "/api/public/add_sso_mapping_group":
+			created = req["target_sso_mapping"].(map[string]interface{})
+			w.Write([]byte(`{"sso_mapping": {"sso_group_mapping_id": "m1", "group_id": "g1", "saml_id": "s1", "sso_group_attributes": "developers"}}`))
+		case "/api/public/update_sso_mapping_group":
+			assert.Equal(t, "m1", req["target_sso_mapping"].(map[string]interface{})["sso_group_mapping_id"])
+			w.Write([]byte(`{}`))
+		case "/api/public/get_sso_mappings_group":
+			lists.Add(1)
+			assert.Equal(t, map[string]interface{}{"group_id": "g1"}, req["target_group"])
+			w.Write([]byte(`{"sso_mappings": [{"sso_group_mapping_id": "m1", "group_id": "g1", "saml_id": "s1", "sso_group_attributes": "developers"}]}`))
+		case "/api/public/delete_sso_mapping_group":
+			assert.Equal(t, map[string]interface{}{"sso_group_mapping_id": "m1"}, req["target_sso_mapping"])
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+	ctx := context.Background()
+
+	mapping, err := client.AddGroupSSOMapping(ctx, &GroupSSOMapping{GroupID: "g1", SAMLID: "s1", GroupAttribute: "developers"})
+	assert.NoError(t, err)
+	assert.Equal(t, "m1", mapping.SSOGroupMappingID)
+	assert.Equal(t, "developers", created["sso_group_attributes"])
+	assert.Equal(t, false, created["apply_to_all_users"])
+	assert.NotContains(t, created, "ldap_id")
+
+	got, err := client.GetGroupSSOMapping(ctx, "g1", "m1")
+	assert.NoError(t, err)
+	assert.Equal(t, SSOTypeSAML, got.SSOType())
+
+	// A second lookup is served from the cache.
+	_, err = client.GetGroupSSOMapping(ctx, "g1", "missing")
+	assert.True(t, IsNotFound(err))
+	assert.Equal(t, int32(1), lists.Load())
+
+	got.GroupAttribute = "engineering"
+	_, err = client.UpdateGroupSSOMapping(ctx, got)
+	assert.NoError(t, err)
+
+	// The update invalidates the cached mappings.
+	_, err = client.GetGroupSSOMappings(ctx, "g1")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), lists.Load())
+
+	assert.NoError(t, client.DeleteGroupSSOMapping(ctx, "m1"))
+}
@@ -34,3 +34,53 @@ type GroupImage struct {
 	ImageFriendlyName string `json:"image_friendly_name"`
 	ImageSrc          string `json:"image_src"`
 }
+
+// SSO provider types a group can be mapped from.
+const (
+	SSOTypeLDAP = "ldap"
+	SSOTypeSAML = "saml"
+	SSOTypeOIDC = "oidc"
+)
+
+// GroupSSOMapping adds users who log in through an LDAP, SAML or OIDC
+// provider to a Kasm group. Exactly one of LDAPID, SAMLID and OIDCID is set.
+type GroupSSOMapping struct {
+	SSOGroupMappingID string `json:"sso_group_mapping_id,omitempty"`
+	GroupID           string `json:"group_id"`
+	LDAPID            string `json:"ldap_id,omitempty"`
+	SAMLID            string `json:"saml_id,omitempty"`
+	OIDCID            string `json:"oidc_id,omitempty"`
+	// GroupAttribute is the group DN for LDAP providers, or the value of the
+	// groups attribute or claim for SAML and OIDC providers.
+	GroupAttribute string `json:"sso_group_attributes,omitempty"`
+	// ApplyToAllUsers adds every user of the provider to the group,
+	// regardless of their groups.
+	ApplyToAllUsers bool `json:"apply_to_all_users"`
+}
+
+// SSOType returns the type of provider the mapping is for, one of the
+// SSOType constants, or "" when no provider is set.
+func (m *GroupSSOMapping) SSOType() string {
+	switch {
+	case m.LDAPID != "":
+		return SSOTypeLDAP
+	case m.SAMLID != "":
+		return SSOTypeSAML
+	case m.OIDCID != "":
+		return SSOTypeOIDC
+	}
+	return ""
+}
+
+// SSOID returns the ID of the provider the mapping is for.
+func (m *GroupSSOMapping) SSOID() string {
+	switch m.SSOType() {
+	case SSOTypeLDAP:
+		return m.LDAPID
+	case SSOTypeSAML:
+		return m.SAMLID
+	case SSOTypeOIDC:
+		return m.OIDCID
+	}
+	return ""
+}
@@ -8,59 +8,76 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
 func (c *Client) doRequest(ctx context.Context, method, endpoint string, payload interface{}) (*http.Response, error) {
-	// Check rate limit
-	if err := c.rateLimiter.Take(); err != nil {
-		return nil, fmt.Errorf("rate limit: %w", err)
-	}
+	// Invalidate once the write has finished, whatever its outcome, so that
+	// lists fetched while it was in flight are not cached.
+	defer c.cache.invalidateEndpoint(endpoint)
 
-	var body io.Reader
+	var jsonData []byte
 	if payload != nil {
-		jsonData, err := json.Marshal(payload)
+		var err error
+		jsonData, err = json.Marshal(payload)
 		if err != nil {
 			return nil, fmt.Errorf("marshaling request payload: %w", err)
 		}
-		body = bytes.NewBuffer(jsonData)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+endpoint, body)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
-	}
-
-	// Add headers
-	req.Header.Set("Content-Type", "application/json")
-	if c.APIKey != "" {
-		req.Header.Set("X-Api-Key", c.APIKey)
-		req.Header.Set("X-Api-Secret", c.APISecret)
 	}
 
+	policy := retryPolicyFor(endpoint)
 	backoff := NewExponentialBackoff(c.retryConfig)
 	var resp *http.Response
+	var wait time.Duration
 
 	// Retry loop
 	for attempt := 0; attempt <= c.retryConfig.MaxRetries; attempt++ {
 		if attempt > 0 {
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(backoff.NextBackOff()):
+			if err := SleepContext(ctx, wait); err != nil {
+				return nil, err
 			}
 		}
 
+		// Every attempt takes a token, so retries are rate limited too
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("rate limit: %w", err)
+		}
+
+		// Build a fresh request for every attempt so the body is replayed
+		// in full rather than re-sending an already drained reader.
+		req, err := c.newRequest(ctx, method, endpoint, jsonData)
+		if err != nil {
+			return nil, err
+		}
+
 		resp, err = c.HTTPClient.Do(req)
 		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if !policy.retryOnError() {
+				return nil, fmt.Errorf("request failed: %w", err)
+			}
 			if attempt == c.retryConfig.MaxRetries {
 				return nil, fmt.Errorf("request failed after %d attempts: %w",
-					c.retryConfig.MaxRetries, err)
+					attempt+1, err)
 			}
+			wait = backoff.NextBackOff()
 			continue
 		}
 
-		if shouldRetry(resp.StatusCode) && attempt < c.retryConfig.MaxRetries {
+		if policy.shouldRetry(resp.StatusCode) && attempt < c.retryConfig.MaxRetries {
+			// Prefer the server's Retry-After hint over our own backoff,
+			// but never wait longer than the configured maximum interval
+			wait = backoff.NextBackOff()
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				wait = retryAfter
+				if wait > c.retryConfig.MaxInterval {
+					wait = c.retryConfig.MaxInterval
+				}
+			}
 			resp.Body.Close()
 			continue
 		}
@@ -71,6 +88,54 @@ func (c *Client) doRequest(ctx context.Context, method, endpoint string, payload
 	return resp, nil
 }
 
+// newRequest builds a single request attempt for endpoint with jsonData as its body.
+func (c *Client) newRequest(ctx context.Context, method, endpoint string, jsonData []byte) (*http.Request, error) {
+	var body io.Reader
+	if jsonData != nil {
+		body = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	// Add headers
+	req.Header.Set("Content-Type", "application/json")
+	if c.APIKey != "" {
+		req.Header.Set("X-Api-Key", c.APIKey)
+		req.Header.Set("X-Api-Secret", c.APISecret)
+	}
+
+	return req, nil
+}
+
+// parseRetryAfter parses a Retry-After header value, given either as a number
+// of seconds or as an HTTP date, into a wait duration relative to now.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := date.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
 func shouldRetry(statusCode int) bool {
 	return statusCode == 429 || // Rate limit exceeded
 		statusCode == 408 || // Request timeout
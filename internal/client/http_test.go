@@ -0,0 +1,262 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryTestClient(serverURL string) *Client {
+	client := NewClient(serverURL, "test-key", "test-secret", false)
+	client.retryConfig.InitialInterval = time.Millisecond
+	client.retryConfig.MaxInterval = time.Millisecond
+	return client
+}
+
+func TestDoRequest_ReplaysBodyOnRetry(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&attempts, 1)
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Attempt %d: failed to decode request body: %v", n, err)
+		}
+		if body["api_key"] != "test-key" {
+			t.Errorf("Attempt %d: expected api_key in body, got %v", n, body)
+		}
+
+		if n < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(server.URL)
+	resp, err := client.doRequest(context.Background(), "POST", "/api/public/get_users", map[string]interface{}{
+		"api_key":        client.APIKey,
+		"api_key_secret": client.APISecret,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("Expected 3 attempts, got %d", got)
+	}
+}
+
+func TestDoRequest_RetryPolicy(t *testing.T) {
+	testCases := []struct {
+		name             string
+		endpoint         string
+		statusCode       int
+		expectedAttempts int32
+	}{
+		{
+			name:             "Safe endpoint retries server errors",
+			endpoint:         "/api/public/get_users",
+			statusCode:       http.StatusBadGateway,
+			expectedAttempts: 4,
+		},
+		{
+			name:             "Create user is not retried on server error",
+			endpoint:         "/api/public/create_user",
+			statusCode:       http.StatusBadGateway,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "Request kasm is not retried on server error",
+			endpoint:         "/api/public/request_kasm",
+			statusCode:       http.StatusServiceUnavailable,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "Create user is retried when rate limited",
+			endpoint:         "/api/public/create_user",
+			statusCode:       http.StatusTooManyRequests,
+			expectedAttempts: 4,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&attempts, 1)
+				w.WriteHeader(tc.statusCode)
+			}))
+			defer server.Close()
+
+			client := newRetryTestClient(server.URL)
+			resp, err := client.doRequest(context.Background(), "POST", tc.endpoint, map[string]interface{}{})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			resp.Body.Close()
+
+			if got := atomic.LoadInt32(&attempts); got != tc.expectedAttempts {
+				t.Errorf("Expected %d attempts, got %d", tc.expectedAttempts, got)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "Empty", value: "", ok: false},
+		{name: "Seconds", value: "7", expected: 7 * time.Second, ok: true},
+		{name: "Negative seconds", value: "-1", ok: false},
+		{name: "HTTP date", value: now.Add(90 * time.Second).Format(http.TimeFormat), expected: 90 * time.Second, ok: true},
+		{name: "HTTP date in the past", value: now.Add(-time.Minute).Format(http.TimeFormat), expected: 0, ok: true},
+		{name: "Garbage", value: "soon", ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tc.value, now)
+			if ok != tc.ok {
+				t.Fatalf("Expected ok=%v, got %v", tc.ok, ok)
+			}
+			if got != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestDoRequest_HonoursRetryAfter(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(server.URL)
+	client.retryConfig.MaxInterval = 5 * time.Second
+
+	start := time.Now()
+	resp, err := client.doRequest(context.Background(), "POST", "/api/public/get_users", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Expected retry to wait for Retry-After, took %v", elapsed)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("Expected 2 attempts, got %d", got)
+	}
+}
+
+func TestClientOptions_RetryTuning(t *testing.T) {
+	client := NewClient("https://example.com", "test-key", "test-secret", false,
+		WithMaxRetries(7),
+		WithRetryInterval(time.Second, time.Minute),
+		WithRequestTimeout(5*time.Minute),
+	)
+
+	if client.retryConfig.MaxRetries != 7 {
+		t.Errorf("Expected MaxRetries 7, got %d", client.retryConfig.MaxRetries)
+	}
+	if client.retryConfig.InitialInterval != time.Second || client.retryConfig.MaxInterval != time.Minute {
+		t.Errorf("Expected intervals 1s/1m, got %v/%v", client.retryConfig.InitialInterval, client.retryConfig.MaxInterval)
+	}
+	if client.HTTPClient.Timeout != 5*time.Minute {
+		t.Errorf("Expected timeout 5m, got %v", client.HTTPClient.Timeout)
+	}
+}
+
+func TestDoRequest_RetriesTakeRateLimitTokens(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(server.URL)
+	client.rateLimiter = NewRateLimiterPerSecond(2, 1)
+
+	start := time.Now()
+	resp, err := client.doRequest(context.Background(), "POST", "/api/public/get_users", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("Expected the retry to wait for a rate limit token, took %v", elapsed)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("Expected 2 attempts, got %d", got)
+	}
+}
+
+func TestDoRequest_RetryAfterCappedAtMaxInterval(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "86400")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(server.URL)
+	client.retryConfig.MaxInterval = 50 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := client.doRequest(ctx, "POST", "/api/public/get_users", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("Expected 2 attempts, got %d", got)
+	}
+}
+
+func TestDoRequest_ReportsAttemptCount(t *testing.T) {
+	client := newRetryTestClient("http://127.0.0.1:1")
+	client.retryConfig.MaxRetries = 2
+
+	_, err := client.doRequest(context.Background(), "POST", "/api/public/get_users", map[string]interface{}{})
+	if err == nil || !strings.Contains(err.Error(), "after 3 attempts") {
+		t.Errorf("Expected an error reporting 3 attempts, got %v", err)
+	}
+}
@@ -1,8 +1,7 @@
 package client
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
 	"log"
@@ -10,7 +9,7 @@ import (
 )
 
 // CreateImage creates a new workspace image
-func (c *Client) CreateImage(image *Image) (*Image, error) {
+func (c *Client) CreateImage(ctx context.Context, image *Image) (*Image, error) {
 	// Convert Image to CreateImageRequest
 	req := &CreateImageRequest{
 		ImageSrc:           image.ImageSrc,
@@ -24,33 +23,26 @@ func (c *Client) CreateImage(image *Image) (*Image, error) {
 		ImageType:          image.ImageType,
 		Enabled:            image.Enabled,
 	}
-	return c.AddWorkspaceImage(req)
+	return c.AddWorkspaceImage(ctx, req)
 }
 
-func (c *Client) GetImage(imageID string) (*Image, error) {
+func (c *Client) GetImage(ctx context.Context, imageID string) (*Image, error) {
 	var image *Image
-	err := c.retryOperation(func() error {
+	err := c.retryOperation(ctx, func() error {
 		payload := map[string]interface{}{
 			"api_key":        c.APIKey,
 			"api_key_secret": c.APISecret,
 			"image_id":       imageID,
 		}
 
-		resp, err := c.doRequestLegacy("POST", "/api/public/get_image", payload)
-		if err != nil {
-			return fmt.Errorf("error making request: %v", err)
-		}
-		defer resp.Body.Close()
-
-		if err := c.handleAPIError(resp, "get image"); err != nil {
-			return err
-		}
-
 		var result struct {
 			Image *Image `json:"image"`
 		}
-		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-			return fmt.Errorf("error decoding response: %v", err)
+		if err := c.post(ctx, "/api/public/get_image", payload, "image", imageID, &result); err != nil {
+			return err
+		}
+		if result.Image == nil {
+			return &NotFoundError{ResourceType: "image", ID: imageID}
 		}
 
 		image = result.Image
@@ -65,83 +57,44 @@ func (c *Client) GetImage(imageID string) (*Image, error) {
 }
 
 // UpdateImage updates an existing workspace image
-func (c *Client) UpdateImage(image *Image) (*Image, error) {
-	body, err := json.Marshal(map[string]interface{}{
+func (c *Client) UpdateImage(ctx context.Context, image *Image) (*Image, error) {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_image":   image,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling request: %v", err)
-	}
-
-	resp, err := c.HTTPClient.Post(
-		c.BaseURL+"/api/public/update_image",
-		"application/json",
-		bytes.NewBuffer(body),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %v", err)
-	}
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
 	}
 
 	var result struct {
-		Image        *Image `json:"image"`
-		ErrorMessage string `json:"error_message,omitempty"`
-	}
-	if err := json.Unmarshal(bodyBytes, &result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+		Image *Image `json:"image"`
 	}
-
-	if result.ErrorMessage != "" {
-		return nil, fmt.Errorf("API returned error: %s", result.ErrorMessage)
+	if err := c.post(ctx, "/api/public/update_image", payload, "image", image.ImageID, &result); err != nil {
+		return nil, err
 	}
 
 	return result.Image, nil
 }
 
 // DeleteImage deletes a workspace image
-func (c *Client) DeleteImage(imageID string) error {
-	body, err := json.Marshal(map[string]interface{}{
+func (c *Client) DeleteImage(ctx context.Context, imageID string) error {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_image": map[string]string{
 			"image_id": imageID,
 		},
-	})
-	if err != nil {
-		return fmt.Errorf("error marshaling request: %v", err)
-	}
-
-	resp, err := c.HTTPClient.Post(
-		c.BaseURL+"/api/public/delete_image",
-		"application/json",
-		bytes.NewBuffer(body),
-	)
-	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
-	}
+	return c.post(ctx, "/api/public/delete_image", payload, "image", imageID, nil)
+}
 
-	return nil
+// GetImages retrieves all available images. The result is cached; see
+// NoCache.
+func (c *Client) GetImages(ctx context.Context) ([]Image, error) {
+	return cachedList(ctx, c, cacheKey{collection: collectionImages}, c.getImages)
 }
 
-// GetImages retrieves all available images
-func (c *Client) GetImages() ([]Image, error) {
+// getImages fetches all images, bypassing the cache
+func (c *Client) getImages(ctx context.Context) ([]Image, error) {
 	log.Printf("[DEBUG] Getting images from Kasm API")
 
 	req := struct {
@@ -152,39 +105,26 @@ func (c *Client) GetImages() ([]Image, error) {
 		APIKeySecret: c.APISecret,
 	}
 
-	reqBody, err := json.Marshal(req)
+	resp, err := c.doRequest(ctx, "POST", "/api/public/get_images", req)
 	if err != nil {
-		return nil, fmt.Errorf("error marshaling request: %v", err)
-	}
-
-	log.Printf("[DEBUG] GetImages request body: %s", string(reqBody))
-
-	resp, err := c.HTTPClient.Post(
-		c.BaseURL+"/api/public/get_images",
-		"application/json",
-		bytes.NewReader(reqBody),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Read response body for debugging
-	bodyBytes, _ := io.ReadAll(resp.Body)
-	bodyString := string(bodyBytes)
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
 
 	log.Printf("[DEBUG] GetImages response status: %d", resp.StatusCode)
-	log.Printf("[DEBUG] GetImages response body: %s", bodyString)
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, bodyString)
-	}
+	log.Printf("[DEBUG] GetImages response body: %s", c.redact(string(bodyBytes)))
 
 	var result struct {
 		Images []Image `json:"images"`
 	}
-	if err := json.Unmarshal(bodyBytes, &result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v, body: %s", err, bodyString)
+	if err := decodeBody(resp.StatusCode, resp.Header, bodyBytes, "image", "", &result); err != nil {
+		return nil, err
 	}
 
 	log.Printf("[DEBUG] Found %d images", len(result.Images))
@@ -196,79 +136,46 @@ func (c *Client) GetImages() ([]Image, error) {
 }
 
 // GetSessionRecordings retrieves recordings for a specific session
-func (c *Client) GetSessionRecordings(kasmID string, preauthDownloadLink bool) ([]SessionRecording, error) {
+func (c *Client) GetSessionRecordings(ctx context.Context, kasmID string, opts SessionRecordingsOptions) ([]SessionRecording, error) {
 	req := struct {
-		APIKey              string `json:"api_key"`
-		APIKeySecret        string `json:"api_key_secret"`
-		TargetKasmID        string `json:"target_kasm_id"`
-		PreauthDownloadLink bool   `json:"preauth_download_link"`
+		APIKey                    string `json:"api_key"`
+		APIKeySecret              string `json:"api_key_secret"`
+		TargetKasmID              string `json:"target_kasm_id"`
+		PreauthDownloadLink       bool   `json:"preauth_download_link"`
+		PreauthDownloadLinkExpiry int64  `json:"preauth_download_link_expiry,omitempty"`
 	}{
-		APIKey:              c.APIKey,
-		APIKeySecret:        c.APISecret,
-		TargetKasmID:        kasmID,
-		PreauthDownloadLink: preauthDownloadLink,
-	}
-
-	reqBody, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling request: %v", err)
-	}
-
-	resp, err := c.HTTPClient.Post(
-		c.BaseURL+"/api/public/get_session_recordings",
-		"application/json",
-		bytes.NewReader(reqBody),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
+		APIKey:                    c.APIKey,
+		APIKeySecret:              c.APISecret,
+		TargetKasmID:              kasmID,
+		PreauthDownloadLink:       opts.PreauthDownloadLink,
+		PreauthDownloadLinkExpiry: opts.preauthDownloadLinkExpirySeconds(),
 	}
 
 	var result struct {
 		SessionRecordings []SessionRecording `json:"session_recordings"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/get_session_recordings", req, "kasm", kasmID, &result); err != nil {
+		return nil, err
 	}
 
-	return result.SessionRecordings, nil
+	return opts.filter(result.SessionRecordings), nil
 }
 
-// GetSessionsRecordings retrieves recordings for multiple sessions
-func (c *Client) GetSessionsRecordings(kasmIDs []string, preauthDownloadLink bool) (map[string][]SessionRecording, error) {
+// GetSessionsRecordings retrieves recordings for multiple sessions, keyed by
+// Kasm ID
+func (c *Client) GetSessionsRecordings(ctx context.Context, kasmIDs []string, opts SessionRecordingsOptions) (map[string][]SessionRecording, error) {
 	req := struct {
-		APIKey              string   `json:"api_key"`
-		APIKeySecret        string   `json:"api_key_secret"`
-		TargetKasmIDs       []string `json:"target_kasm_ids"`
-		PreauthDownloadLink bool     `json:"preauth_download_link"`
+		APIKey                    string   `json:"api_key"`
+		APIKeySecret              string   `json:"api_key_secret"`
+		TargetKasmIDs             []string `json:"target_kasm_ids"`
+		PreauthDownloadLink       bool     `json:"preauth_download_link"`
+		PreauthDownloadLinkExpiry int64    `json:"preauth_download_link_expiry,omitempty"`
 	}{
-		APIKey:              c.APIKey,
-		APIKeySecret:        c.APISecret,
-		TargetKasmIDs:       kasmIDs,
-		PreauthDownloadLink: preauthDownloadLink,
-	}
-
-	reqBody, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling request: %v", err)
-	}
-
-	resp, err := c.HTTPClient.Post(
-		c.BaseURL+"/api/public/get_sessions_recordings",
-		"application/json",
-		bytes.NewReader(reqBody),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
+		APIKey:                    c.APIKey,
+		APIKeySecret:              c.APISecret,
+		TargetKasmIDs:             kasmIDs,
+		PreauthDownloadLink:       opts.PreauthDownloadLink,
+		PreauthDownloadLinkExpiry: opts.preauthDownloadLinkExpirySeconds(),
 	}
 
 	var result struct {
@@ -276,80 +183,57 @@ func (c *Client) GetSessionsRecordings(kasmIDs []string, preauthDownloadLink boo
 			SessionRecordings []SessionRecording `json:"session_recordings"`
 		} `json:"kasm_sessions"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/get_sessions_recordings", req, "kasm", "", &result); err != nil {
+		return nil, err
 	}
 
 	// Convert to simpler map structure
 	recordings := make(map[string][]SessionRecording)
 	for kasmID, session := range result.KasmSessions {
-		recordings[kasmID] = session.SessionRecordings
+		recordings[kasmID] = opts.filter(session.SessionRecordings)
 	}
 
 	return recordings, nil
 }
 
 // AddWorkspaceImage adds a new workspace image to Kasm
-func (c *Client) AddWorkspaceImage(image *CreateImageRequest) (*Image, error) {
+func (c *Client) AddWorkspaceImage(ctx context.Context, image *CreateImageRequest) (*Image, error) {
 	reqBody := createImageAPIRequest{
 		APIKey:       c.APIKey,
 		APIKeySecret: c.APISecret,
 		TargetImage:  *image,
 	}
 
-	body, err := json.Marshal(reqBody)
+	resp, err := c.doRequest(ctx, "POST", "/api/public/create_image", reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("error marshaling request: %v", err)
-	}
-
-	// Add debug logging for request
-	if os.Getenv("KASM_DEBUG") != "" {
-		log.Printf("[DEBUG] AddWorkspaceImage request body: %s", string(body))
-	}
-
-	resp, err := c.HTTPClient.Post(
-		c.BaseURL+"/api/public/create_image",
-		"application/json",
-		bytes.NewBuffer(body),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Read the full response body
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %v", err)
+		return nil, fmt.Errorf("error reading response body: %w", err)
 	}
 
 	// Add debug logging for response
 	if os.Getenv("KASM_DEBUG") != "" {
-		log.Printf("[DEBUG] AddWorkspaceImage response: %s", string(bodyBytes))
-	}
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
+		log.Printf("[DEBUG] AddWorkspaceImage response: %s", c.redact(string(bodyBytes)))
 	}
 
 	var result struct {
-		Image        *Image `json:"image"`
-		ErrorMessage string `json:"error_message,omitempty"`
-	}
-	if err := json.Unmarshal(bodyBytes, &result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v, body: %s", err, string(bodyBytes))
+		Image *Image `json:"image"`
 	}
-
-	if result.ErrorMessage != "" {
-		return nil, fmt.Errorf("API returned error: %s", result.ErrorMessage)
+	if err := decodeBody(resp.StatusCode, resp.Header, bodyBytes, "image", image.Name, &result); err != nil {
+		return nil, err
 	}
 
 	if result.Image == nil {
-		return nil, fmt.Errorf("API returned success but image is nil. Response body: %s", string(bodyBytes))
+		return nil, fmt.Errorf("API returned success but image is nil. Response body: %s", c.redact(string(bodyBytes)))
 	}
 
 	if result.Image.ImageID == "" {
-		return nil, fmt.Errorf("API returned image but ImageID is empty. Response body: %s", string(bodyBytes))
+		return nil, fmt.Errorf("API returned image but ImageID is empty. Response body: %s", c.redact(string(bodyBytes)))
 	}
 
 	return result.Image, nil
@@ -1,5 +1,10 @@
 package client
 
+import (
+	"math"
+	"time"
+)
+
 // Image represents a Kasm image as returned by the documented API
 type Image struct {
 	ImageID             string                 `json:"image_id,omitempty"`
@@ -49,6 +54,79 @@ type SessionRecording struct {
 	SessionRecordingDownloadURL string                 `json:"session_recording_download_url,omitempty"`
 }
 
+// recordingTimeLayouts are the timestamp formats Kasm uses in recording
+// metadata.
+var recordingTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999",
+	"2006-01-02T15:04:05.999999",
+}
+
+// StartTime returns when the recording started, taken from the start_time or
+// created metadata field. ok is false when neither holds a timestamp.
+func (r SessionRecording) StartTime() (t time.Time, ok bool) {
+	for _, key := range []string{"start_time", "created"} {
+		switch v := r.SessionRecordingMetadata[key].(type) {
+		case string:
+			for _, layout := range recordingTimeLayouts {
+				if t, err := time.Parse(layout, v); err == nil {
+					return t.UTC(), true
+				}
+			}
+		case float64:
+			// Unix time in seconds
+			sec, frac := math.Modf(v)
+			return time.Unix(int64(sec), int64(frac*1e9)).UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// SessionRecordingsOptions controls which session recordings are returned.
+type SessionRecordingsOptions struct {
+	// PreauthDownloadLink requests pre-authorized download links.
+	PreauthDownloadLink bool
+	// PreauthDownloadLinkExpiry is how long the pre-authorized links stay
+	// valid. Zero uses the server default.
+	PreauthDownloadLinkExpiry time.Duration
+	// StartDate and EndDate, when set, keep only recordings that started
+	// within that range, inclusive. Recordings without a start time are
+	// dropped when either bound is set. The API has no date filter, so this
+	// is applied by the client.
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+func (o SessionRecordingsOptions) preauthDownloadLinkExpirySeconds() int64 {
+	if !o.PreauthDownloadLink || o.PreauthDownloadLinkExpiry <= 0 {
+		return 0
+	}
+	return int64(math.Ceil(o.PreauthDownloadLinkExpiry.Seconds()))
+}
+
+// filter returns the recordings that fall within the date range.
+func (o SessionRecordingsOptions) filter(recordings []SessionRecording) []SessionRecording {
+	if o.StartDate.IsZero() && o.EndDate.IsZero() {
+		return recordings
+	}
+
+	filtered := make([]SessionRecording, 0, len(recordings))
+	for _, rec := range recordings {
+		start, ok := rec.StartTime()
+		if !ok {
+			continue
+		}
+		if !o.StartDate.IsZero() && start.Before(o.StartDate) {
+			continue
+		}
+		if !o.EndDate.IsZero() && start.After(o.EndDate) {
+			continue
+		}
+		filtered = append(filtered, rec)
+	}
+	return filtered
+}
+
 // CreateImageRequest represents the request to create a workspace image
 type CreateImageRequest struct {
 	ImageSrc           string  `json:"image_src"`
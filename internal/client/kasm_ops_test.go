@@ -0,0 +1,105 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateKasm_ImageNotAuthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/public/get_user":
+			w.Write([]byte(`{"user": {"user_id": "u1", "groups": [{"group_id": "g1", "name": "All Users"}]}}`))
+		case "/api/public/get_images_group":
+			w.Write([]byte(`{"images": [{"image_id": "other"}]}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+
+	_, err := client.CreateKasm(context.Background(), "u1", "img1", "token", "", false, false, false, false, false)
+
+	var fe *ForbiddenError
+	assert.True(t, errors.As(err, &fe), "expected ForbiddenError, got %v", err)
+}
+
+func TestClient_CreateKasm_ForbiddenNotRetried(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/public/get_user":
+			w.Write([]byte(`{"user": {"user_id": "u1", "groups": [{"group_id": "g1", "name": "All Users"}]}}`))
+		case "/api/public/get_images_group":
+			w.Write([]byte(`{"images": [{"image_id": "img1"}]}`))
+		case "/api/public/request_kasm":
+			requests.Add(1)
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error_message": "Access denied"}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(3))
+
+	_, err := client.CreateKasm(context.Background(), "u1", "img1", "token", "", false, false, false, false, false)
+
+	var fe *ForbiddenError
+	assert.True(t, errors.As(err, &fe), "expected ForbiddenError, got %v", err)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestClient_CreateKasm_EnableSharing(t *testing.T) {
+	testCases := []struct {
+		name    string
+		version string
+		want    interface{}
+	}{
+		{name: "Supported", version: "1.16.0", want: true},
+		{name: "Older server", version: "1.14.0", want: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var body map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/public/get_user":
+					w.Write([]byte(`{"user": {"user_id": "u1", "groups": [{"group_id": "g1", "name": "All Users"}]}}`))
+				case "/api/public/get_images_group":
+					w.Write([]byte(`{"images": [{"image_id": "img1"}]}`))
+				case "/api/public/request_kasm":
+					json.NewDecoder(r.Body).Decode(&body)
+					w.Write([]byte(`{"kasm_id": "k1"}`))
+				default:
+					t.Errorf("unexpected request to %s", r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			version, err := ParseServerVersion(tc.version)
+			require.NoError(t, err)
+			client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0), WithServerVersion(version))
+
+			_, err = client.CreateKasm(context.Background(), "u1", "img1", "token", "", false, true, false, false, false)
+			require.NoError(t, err)
+			assert.Equal(t, false, body["share"])
+			assert.Equal(t, tc.want, body["enable_sharing"])
+		})
+	}
+}
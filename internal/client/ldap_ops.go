@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// CreateLDAPConfig creates a new LDAP configuration
+func (c *Client) CreateLDAPConfig(ctx context.Context, config *LDAPConfig) (*LDAPConfig, error) {
+	if err := validateLDAPConfig(config); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":            c.APIKey,
+		"api_key_secret":     c.APISecret,
+		"target_ldap_config": config,
+	}
+
+	var result struct {
+		LDAPConfig *LDAPConfig `json:"ldap_config"`
+	}
+	if err := c.post(ctx, "/api/public/create_ldap_config", payload, "ldap_config", config.Name, &result); err != nil {
+		return nil, err
+	}
+	if result.LDAPConfig == nil {
+		return nil, fmt.Errorf("create_ldap_config returned no LDAP configuration")
+	}
+
+	return result.LDAPConfig, nil
+}
+
+// GetLDAPConfig retrieves an LDAP configuration by ID
+func (c *Client) GetLDAPConfig(ctx context.Context, ldapID string) (*LDAPConfig, error) {
+	configs, err := c.GetLDAPConfigs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting LDAP configurations: %w", err)
+	}
+
+	for _, config := range configs {
+		if config.LDAPID == ldapID {
+			return &config, nil
+		}
+	}
+
+	return nil, &NotFoundError{ResourceType: "ldap_config", ID: ldapID}
+}
+
+// UpdateLDAPConfig updates an existing LDAP configuration. An empty service
+// account password leaves the stored password unchanged.
+func (c *Client) UpdateLDAPConfig(ctx context.Context, config *LDAPConfig) (*LDAPConfig, error) {
+	if err := validateLDAPConfig(config); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":            c.APIKey,
+		"api_key_secret":     c.APISecret,
+		"target_ldap_config": config,
+	}
+
+	var result struct {
+		LDAPConfig *LDAPConfig `json:"ldap_config"`
+	}
+	if err := c.post(ctx, "/api/public/update_ldap_config", payload, "ldap_config", config.LDAPID, &result); err != nil {
+		return nil, err
+	}
+	if result.LDAPConfig == nil {
+		return config, nil
+	}
+
+	return result.LDAPConfig, nil
+}
+
+// DeleteLDAPConfig deletes an LDAP configuration by ID
+func (c *Client) DeleteLDAPConfig(ctx context.Context, ldapID string) error {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_ldap_config": map[string]string{
+			"ldap_id": ldapID,
+		},
+	}
+
+	return c.post(ctx, "/api/public/delete_ldap_config", payload, "ldap_config", ldapID, nil)
+}
+
+// GetLDAPConfigs retrieves all LDAP configurations
+func (c *Client) GetLDAPConfigs(ctx context.Context) ([]LDAPConfig, error) {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+	}
+
+	var result struct {
+		LDAPConfigs []LDAPConfig `json:"ldap_configs"`
+	}
+	if err := c.post(ctx, "/api/public/get_ldap_configs", payload, "ldap_config", "", &result); err != nil {
+		return nil, err
+	}
+
+	return result.LDAPConfigs, nil
+}
+
+// TestLDAPConfig asks Kasm to connect to the directory of an LDAP
+// configuration and bind with its service account. A failed test is
+// reported as an error.
+func (c *Client) TestLDAPConfig(ctx context.Context, ldapID string) (*LDAPTestResult, error) {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_ldap_config": map[string]string{
+			"ldap_id": ldapID,
+		},
+	}
+
+	var result LDAPTestResult
+	if err := c.post(ctx, "/api/public/test_ldap_config", payload, "ldap_config", ldapID, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success {
+		if result.Message == "" {
+			result.Message = "the directory did not accept the connection"
+		}
+		return &result, fmt.Errorf("LDAP connection test failed: %s", result.Message)
+	}
+
+	return &result, nil
+}
+
+func validateLDAPConfig(config *LDAPConfig) error {
+	if config.Name == "" {
+		return fmt.Errorf("LDAP configuration name is required")
+	}
+	u, err := url.Parse(config.URL)
+	if err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") || u.Host == "" {
+		return fmt.Errorf("LDAP url must be an ldap:// or ldaps:// address, got %q", config.URL)
+	}
+	if config.SearchBase == "" {
+		return fmt.Errorf("LDAP search_base is required")
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateLDAPConfig(t *testing.T) {
+	valid := func() LDAPConfig {
+		return LDAPConfig{Name: "corp", URL: "ldaps://dc1.example.com:636", SearchBase: "dc=example,dc=com"}
+	}
+
+	testCases := []struct {
+		name          string
+		modify        func(*LDAPConfig)
+		errorContains string
+	}{
+		{name: "Valid", modify: func(*LDAPConfig) {}},
+		{name: "Plain LDAP", modify: func(c *LDAPConfig) { c.URL = "ldap://dc1.example.com" }},
+		{name: "Missing name", modify: func(c *LDAPConfig) { c.Name = "" }, errorContains: "name is required"},
+		{name: "HTTP URL", modify: func(c *LDAPConfig) { c.URL = "https://dc1.example.com" }, errorContains: "ldap:// or ldaps://"},
+		{name: "Missing host", modify: func(c *LDAPConfig) { c.URL = "ldaps://" }, errorContains: "ldap:// or ldaps://"},
+		{name: "Missing search base", modify: func(c *LDAPConfig) { c.SearchBase = "" }, errorContains: "search_base is required"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := valid()
+			tc.modify(&config)
+			err := validateLDAPConfig(&config)
+			if tc.errorContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.errorContains)
+			}
+		})
+	}
+}
+
+func TestClient_LDAPConfigCRUD(t *testing.T) {
+	var created map[string]interface{}
+	testPasses := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		switch r.URL.Path {
+		case "/api/public/create_ldap_config":
+			created = req["target_ldap_config"].(map[string]interface{})
+			w.Write([]byte(`{"ldap_config": {"ldap_id": "l1", "name": "corp", "url": "ldaps://dc1.example.com", "search_base": "dc=example,dc=com", "search_filter": "(&(objectClass=person)(sAMAccountName={0}))"}}`))
+		case "/api/public/update_ldap_config":
+			assert.Equal(t, "l1", req["target_ldap_config"].(map[string]interface{})["ldap_id"])
+			w.Write([]byte(`{}`))
+		case "/api/public/get_ldap_configs":
+			w.Write([]byte(`{"ldap_configs": [{"ldap_id": "l1", "name": "corp", "enabled": true}]}`))
+		case "/api/public/delete_ldap_config":
+			assert.Equal(t, map[string]interface{}{"ldap_id": "l1"}, req["target_ldap_config"])
+			w.Write([]byte(`{}`))
+		case "/api/public/test_ldap_config":
+			if testPasses {
+				w.Write([]byte(`{"success": true, "message": "bind succeeded"}`))
+			} else {
+				w.Write([]byte(`{"success": false, "message": "invalid credentials"}`))
+			}
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+	ctx := context.Background()
+
+	config, err := client.CreateLDAPConfig(ctx, &LDAPConfig{
+		Name:                   "corp",
+		URL:                    "ldaps://dc1.example.com",
+		SearchBase:             "dc=example,dc=com",
+		ServiceAccountDN:       "cn=kasm,dc=example,dc=com",
+		ServiceAccountPassword: "hunter2",
+		AutoCreateAppUser:      true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "l1", config.LDAPID)
+	assert.Equal(t, "(&(objectClass=person)(sAMAccountName={0}))", config.SearchFilter)
+	assert.NotContains(t, created, "ldap_id")
+	assert.NotContains(t, created, "search_filter", "an unset filter leaves the server default")
+	assert.Equal(t, "hunter2", created["service_account_password"])
+
+	_, err = client.UpdateLDAPConfig(ctx, config)
+	assert.NoError(t, err)
+
+	got, err := client.GetLDAPConfig(ctx, "l1")
+	assert.NoError(t, err)
+	assert.True(t, got.Enabled)
+
+	_, err = client.GetLDAPConfig(ctx, "missing")
+	assert.True(t, IsNotFound(err))
+
+	result, err := client.TestLDAPConfig(ctx, "l1")
+	assert.NoError(t, err)
+	assert.Equal(t, "bind succeeded", result.Message)
+
+	testPasses = false
+	_, err = client.TestLDAPConfig(ctx, "l1")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid credentials")
+	}
+
+	assert.NoError(t, client.DeleteLDAPConfig(ctx, "l1"))
+}
@@ -0,0 +1,33 @@
+package client
+
+// LDAPConfig is an LDAP or Active Directory directory Kasm authenticates
+// users against.
+type LDAPConfig struct {
+	LDAPID  string `json:"ldap_id,omitempty"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	// URL is the ldap:// or ldaps:// address of the directory.
+	URL        string `json:"url"`
+	SearchBase string `json:"search_base"`
+	// SearchFilter finds the entry of a user; {0} is replaced by the
+	// username.
+	SearchFilter string `json:"search_filter,omitempty"`
+	// GroupMembershipFilter finds the groups of a user; {0} is replaced by
+	// the DN of the user.
+	GroupMembershipFilter  string `json:"group_membership_filter,omitempty"`
+	ServiceAccountDN       string `json:"service_account_dn,omitempty"`
+	ServiceAccountPassword string `json:"service_account_password,omitempty"`
+	// UsernameDomainMatch restricts the directory to usernames ending in
+	// this domain, such as @example.com.
+	UsernameDomainMatch string `json:"username_domain_match,omitempty"`
+	AutoCreateAppUser   bool   `json:"auto_create_app_user"`
+	// EmailAttribute is the directory attribute copied to the email of the
+	// Kasm user, such as mail.
+	EmailAttribute string `json:"email_attribute,omitempty"`
+}
+
+// LDAPTestResult is the outcome of test_ldap_config.
+type LDAPTestResult struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
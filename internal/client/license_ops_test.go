@@ -4,6 +4,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -73,7 +74,7 @@ func TestGetLicenses(t *testing.T) {
 				Message: "Invalid API key",
 			},
 			expectError:   true,
-			expectedError: "API request was not successful: Invalid API key",
+			expectedError: "Unauthorized: Invalid API key",
 			expectedCount: 0,
 		},
 		{
@@ -81,7 +82,7 @@ func TestGetLicenses(t *testing.T) {
 			responseStatus: http.StatusInternalServerError,
 			responseBody:   "Internal Server Error",
 			expectError:    true,
-			expectedError:  "API request failed with status code: 500",
+			expectedError:  "API error (status 500)",
 			expectedCount:  0,
 		},
 	}
@@ -108,14 +109,9 @@ func TestGetLicenses(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client := &Client{
-				HTTPClient: server.Client(),
-				BaseURL:    server.URL,
-				APIKey:     "test-key",
-				APISecret:  "test-secret",
-			}
+			client := NewClient(server.URL, "test-key", "test-secret", false)
 
-			licenses, err := client.GetLicenses()
+			licenses, err := client.GetLicenses(context.Background())
 
 			if tc.expectError {
 				assert.Error(t, err)
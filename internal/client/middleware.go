@@ -0,0 +1,112 @@
+package client
+
+import (
+	"net/http"
+	"time"
+)
+
+// Middleware wraps the transport used for every HTTP attempt. Middleware runs
+// below the rate limiter and the retry loop, so it sees each retry as a
+// separate round trip.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithMiddleware appends middleware to the client's transport chain. The
+// first middleware given is the outermost and sees the request first.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// WithHeaders adds headers to every request, for example a token required by
+// a proxy or web application firewall in front of Kasm.
+func WithHeaders(headers map[string]string) ClientOption {
+	return func(c *Client) {
+		if c.headers == nil {
+			c.headers = make(map[string]string, len(headers))
+		}
+		for name, value := range headers {
+			c.headers[name] = value
+		}
+		c.middleware = append(c.middleware, HeaderMiddleware(headers))
+	}
+}
+
+// chainMiddleware wraps transport so that middleware[0] is the outermost.
+func chainMiddleware(transport http.RoundTripper, middleware []Middleware) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(middleware) - 1; i >= 0; i-- {
+		transport = middleware[i](transport)
+	}
+	return transport
+}
+
+// HeaderMiddleware sets headers on every request. Headers already present on
+// the request are overwritten.
+func HeaderMiddleware(headers map[string]string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			// A RoundTripper must not modify the caller's request.
+			req = req.Clone(req.Context())
+			for name, value := range headers {
+				req.Header.Set(name, value)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// LoggingMiddleware reports the method, path, status and duration of every
+// attempt through logf. Bodies are not logged and header values are redacted.
+func LoggingMiddleware(logf func(format string, args ...interface{})) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				logf("[DEBUG] %s %s failed after %v: %s", req.Method, req.URL.Path, time.Since(start), Redact(err.Error()))
+				return resp, err
+			}
+			logf("[DEBUG] %s %s returned %d in %v (headers: %v)", req.Method, req.URL.Path, resp.StatusCode, time.Since(start), RedactHeader(resp.Header))
+			return resp, err
+		})
+	}
+}
+
+// MetricsMiddleware calls observe after every attempt with the request, the
+// response or transport error, and the time taken.
+func MetricsMiddleware(observe func(req *http.Request, resp *http.Response, err error, duration time.Duration)) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			observe(req, resp, err, time.Since(start))
+			return resp, err
+		})
+	}
+}
+
+// FaultInjectionMiddleware lets inject short-circuit a request. When inject
+// returns a response or an error, it is returned without contacting the
+// server; when it returns (nil, nil), the request proceeds normally.
+func FaultInjectionMiddleware(inject func(req *http.Request) (*http.Response, error)) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := inject(req)
+			if resp != nil || err != nil {
+				return resp, err
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
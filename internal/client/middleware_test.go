@@ -0,0 +1,139 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware_Order(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" before")
+				resp, err := next.RoundTrip(req)
+				calls = append(calls, name+" after")
+				return resp, err
+			})
+		}
+	}
+
+	client := NewClient(server.URL, "test-key", "test-secret", false,
+		WithMiddleware(record("outer")),
+		WithMiddleware(record("inner")),
+	)
+
+	if err := client.post(context.Background(), "/api/public/get_users", map[string]interface{}{}, "user", "", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	assert.Equal(t, []string{"outer before", "inner before", "inner after", "outer after"}, calls)
+}
+
+func TestHeaderMiddleware(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false,
+		WithHeaders(map[string]string{"X-WAF-Token": "bypass", "X-Team": "platform"}),
+	)
+
+	if err := client.post(context.Background(), "/api/public/get_users", map[string]interface{}{}, "user", "", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	assert.Equal(t, "bypass", got.Get("X-WAF-Token"))
+	assert.Equal(t, "platform", got.Get("X-Team"))
+	assert.Equal(t, "application/json", got.Get("Content-Type"))
+}
+
+func TestFaultInjectionMiddleware_IsRetried(t *testing.T) {
+	var served int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&served, 1)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var injected int32
+	fault := FaultInjectionMiddleware(func(req *http.Request) (*http.Response, error) {
+		if atomic.AddInt32(&injected, 1) <= 2 {
+			return &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader("unavailable")),
+				Request:    req,
+			}, nil
+		}
+		return nil, nil
+	})
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMiddleware(fault))
+	client.retryConfig.InitialInterval = time.Millisecond
+	client.retryConfig.MaxInterval = time.Millisecond
+
+	if err := client.post(context.Background(), "/api/public/get_users", map[string]interface{}{}, "user", "", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&injected))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&served))
+}
+
+func TestFaultInjectionMiddleware_TransportError(t *testing.T) {
+	injectedErr := errors.New("injected failure")
+	client := NewClient("http://kasm.invalid", "test-key", "test-secret", false,
+		WithMaxRetries(0),
+		WithMiddleware(FaultInjectionMiddleware(func(req *http.Request) (*http.Response, error) {
+			return nil, injectedErr
+		})),
+	)
+
+	err := client.post(context.Background(), "/api/public/get_users", map[string]interface{}{}, "user", "", nil)
+	assert.ErrorIs(t, err, injectedErr)
+}
+
+func TestLoggingMiddleware_RedactsHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=secret-cookie")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var lines []string
+	logf := func(format string, args ...interface{}) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	}
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMiddleware(LoggingMiddleware(logf)))
+	if err := client.post(context.Background(), "/api/public/get_users", map[string]interface{}{}, "user", "", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if assert.Len(t, lines, 1) {
+		assert.Contains(t, lines[0], "POST /api/public/get_users returned 200")
+		assert.NotContains(t, lines[0], "secret-cookie")
+	}
+}
@@ -1,11 +1,14 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
 )
 
+// RateLimiter is a token bucket holding up to capacity tokens, refilled at
+// one token per refillRate.
 type RateLimiter struct {
 	tokens     int
 	capacity   int
@@ -23,18 +26,19 @@ func NewRateLimiter(capacity int, refillRate time.Duration) *RateLimiter {
 	}
 }
 
+// NewRateLimiterPerSecond creates a RateLimiter that allows requestsPerSecond
+// on average with bursts of up to burst requests.
+func NewRateLimiterPerSecond(requestsPerSecond float64, burst int) *RateLimiter {
+	return NewRateLimiter(burst, time.Duration(float64(time.Second)/requestsPerSecond))
+}
+
+// Take consumes a token without blocking. It returns an error when the
+// bucket is empty.
 func (r *RateLimiter) Take() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	now := time.Now()
-	elapsedTime := now.Sub(r.lastRefill)
-	newTokens := int(elapsedTime / r.refillRate)
-
-	if newTokens > 0 {
-		r.tokens = min(r.capacity, r.tokens+newTokens)
-		r.lastRefill = now
-	}
+	r.refill(time.Now())
 
 	if r.tokens <= 0 {
 		return fmt.Errorf("rate limit exceeded, try again in %v", r.refillRate)
@@ -44,6 +48,52 @@ func (r *RateLimiter) Take() error {
 	return nil
 }
 
+// Wait blocks until a token is available or ctx is done. Callers queue for
+// refilled tokens instead of failing when the bucket is empty.
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay := r.reserve()
+		if delay <= 0 {
+			return nil
+		}
+		if err := SleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve consumes a token and returns zero if one is free. Otherwise it
+// returns how long until the next token is added.
+func (r *RateLimiter) reserve() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.refill(now)
+
+	if r.tokens > 0 {
+		r.tokens--
+		return 0
+	}
+
+	return r.refillRate - now.Sub(r.lastRefill)
+}
+
+// refill adds the tokens earned since lastRefill. Partial intervals carry
+// over so a steady stream of callers is not starved.
+func (r *RateLimiter) refill(now time.Time) {
+	if r.tokens >= r.capacity {
+		r.lastRefill = now
+		return
+	}
+
+	newTokens := int(now.Sub(r.lastRefill) / r.refillRate)
+	if newTokens > 0 {
+		r.tokens = min(r.capacity, r.tokens+newTokens)
+		r.lastRefill = r.lastRefill.Add(time.Duration(newTokens) * r.refillRate)
+	}
+}
+
 // min returns the smaller of x or y
 func min(x, y int) int {
 	if x < y {
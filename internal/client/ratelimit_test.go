@@ -0,0 +1,55 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Take(t *testing.T) {
+	limiter := NewRateLimiter(2, time.Hour)
+
+	for i := 0; i < 2; i++ {
+		if err := limiter.Take(); err != nil {
+			t.Fatalf("Take %d: unexpected error: %v", i, err)
+		}
+	}
+	if err := limiter.Take(); err == nil {
+		t.Error("Expected error when bucket is empty, got nil")
+	}
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	limiter := NewRateLimiterPerSecond(50, 1)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait %d: unexpected error: %v", i, err)
+		}
+	}
+
+	// One token is available immediately; the other two refill at 20ms each.
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("Expected Wait to block for refills, took %v", elapsed)
+	}
+}
+
+func TestRateLimiter_WaitCancelled(t *testing.T) {
+	limiter := NewRateLimiter(1, time.Hour)
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := limiter.Wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
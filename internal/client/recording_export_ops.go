@@ -0,0 +1,320 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// partSuffix marks a download that has not finished yet. Only complete files
+// carry their final name.
+const partSuffix = ".part"
+
+var recordingExtPattern = regexp.MustCompile(`^\.[A-Za-z0-9]{1,8}$`)
+
+// ExportSessionRecordings downloads every recording of the sessions kasmIDs to
+// dir/<kasm_id>/<recording_id><ext>, where ext is taken from the download
+// link. At most opts.Concurrency files are downloaded at once. Interrupted
+// downloads resume from their partial file on the next call. The result is
+// ordered by Kasm ID and recording ID.
+func (c *Client) ExportSessionRecordings(ctx context.Context, kasmIDs []string, dir string, opts RecordingExportOptions) ([]ExportedRecording, error) {
+	sessions, err := c.GetSessionsRecordings(ctx, kasmIDs, SessionRecordingsOptions{PreauthDownloadLink: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var recordings []ExportedRecording
+	var links []string
+	for kasmID, sessionRecordings := range sessions {
+		for _, rec := range sessionRecordings {
+			if !isSafePathElement(kasmID) || !isSafePathElement(rec.RecordingID) {
+				return nil, fmt.Errorf("recording %q of session %q cannot be used as a file name", rec.RecordingID, kasmID)
+			}
+			if rec.SessionRecordingDownloadURL == "" {
+				return nil, fmt.Errorf("recording %s of session %s has no download link", rec.RecordingID, kasmID)
+			}
+			recordings = append(recordings, ExportedRecording{
+				KasmID:      kasmID,
+				RecordingID: rec.RecordingID,
+				RecordingFile: RecordingFile{
+					Path: filepath.Join(dir, kasmID, rec.RecordingID+recordingExt(rec.SessionRecordingDownloadURL)),
+				},
+			})
+			links = append(links, rec.SessionRecordingDownloadURL)
+		}
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultRecordingExportConcurrency
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+	for i := range recordings {
+		rec := &recordings[i]
+		link := links[i]
+		g.Go(func() error {
+			if known, ok := opts.KnownSHA256[rec.RecordingID]; ok {
+				if file, err := HashRecordingFile(rec.Path); err == nil && file.SHA256 == known {
+					log.Printf("[DEBUG] Recording %s is already exported to %s", rec.RecordingID, rec.Path)
+					rec.RecordingFile = *file
+					return nil
+				}
+			}
+
+			file, err := c.DownloadSessionRecording(gctx, link, rec.Path)
+			if err != nil {
+				return fmt.Errorf("error downloading recording %s of session %s: %w", rec.RecordingID, rec.KasmID, err)
+			}
+			rec.RecordingFile = *file
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(recordings, func(i, j int) bool {
+		if recordings[i].KasmID != recordings[j].KasmID {
+			return recordings[i].KasmID < recordings[j].KasmID
+		}
+		return recordings[i].RecordingID < recordings[j].RecordingID
+	})
+	return recordings, nil
+}
+
+// DownloadSessionRecording streams the recording at downloadURL, a
+// pre-authorized link that may be relative to the Kasm server, to dest.
+// Data is written to dest.part first and renamed once complete. An existing
+// partial file is resumed with a range request, and transient failures are
+// retried from where they stopped.
+func (c *Client) DownloadSessionRecording(ctx context.Context, downloadURL, dest string) (*RecordingFile, error) {
+	link, err := c.resolveURL(downloadURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return nil, fmt.Errorf("error creating directory for %s: %w", dest, err)
+	}
+
+	httpClient := c.downloadClient(link)
+	part := dest + partSuffix
+	backoff := NewExponentialBackoff(c.retryConfig)
+	for attempt := 0; ; attempt++ {
+		err := c.downloadPart(ctx, httpClient, link, part)
+		if err == nil {
+			break
+		}
+		if !isTransient(err) || attempt >= c.retryConfig.MaxRetries {
+			return nil, err
+		}
+		log.Printf("[DEBUG] Download of %s failed, resuming: %v", dest, err)
+		if err := SleepContext(ctx, backoff.NextBackOff()); err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := HashRecordingFile(part)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Rename(part, dest); err != nil {
+		return nil, fmt.Errorf("error moving %s into place: %w", dest, err)
+	}
+	file.Path = dest
+	return file, nil
+}
+
+// downloadClient returns the HTTP client used to fetch link. Pre-authorized
+// links may point at object storage outside Kasm, which must not receive the
+// provider's extra headers or client certificate, so the client is built on
+// the base transport without middleware and only adds them for links on the
+// Kasm server. Recordings can be far larger than an API response, so there
+// is no timeout; the download is bounded by its context.
+func (c *Client) downloadClient(link string) *http.Client {
+	transport := c.baseTransport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if c.isKasmHost(link) {
+		if len(c.headers) > 0 {
+			transport = HeaderMiddleware(c.headers)(transport)
+		}
+	} else if tr, ok := transport.(*http.Transport); ok && tr.TLSClientConfig != nil && len(tr.TLSClientConfig.Certificates) > 0 {
+		tr = tr.Clone()
+		tr.TLSClientConfig.Certificates = nil
+		transport = tr
+	}
+
+	return &http.Client{Transport: transport}
+}
+
+// isKasmHost reports whether link has the same host as the Kasm server.
+func (c *Client) isKasmHost(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	base, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, base.Host)
+}
+
+// downloadPart appends the rest of link to the partial file part.
+func (c *Client) downloadPart(ctx context.Context, httpClient *http.Client, link, part string) error {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limit: %w", err)
+	}
+
+	var offset int64
+	if info, err := os.Stat(part); err == nil {
+		offset = info.Size()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		if start, ok := contentRangeStart(resp.Header.Get("Content-Range")); !ok || start != offset {
+			os.Remove(part)
+			return fmt.Errorf("server resumed %s at an unexpected offset, restarting", link)
+		}
+		flags |= os.O_APPEND
+	case resp.StatusCode == http.StatusOK:
+		// Kasm reports errors as JSON with status 200.
+		if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+			if err := checkResponse(resp.StatusCode, resp.Header, body, "session_recording", ""); err != nil {
+				return err
+			}
+			return fmt.Errorf("expected a recording from %s, got JSON: %s", link, Redact(string(body)))
+		}
+		// The server ignored the range, so start over.
+		flags |= os.O_TRUNC
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		os.Remove(part)
+		return fmt.Errorf("partial download of %s no longer matches the recording, restarting", link)
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		if err := checkResponse(resp.StatusCode, resp.Header, body, "session_recording", ""); err != nil {
+			return err
+		}
+		return &APIError{StatusCode: resp.StatusCode, Response: Redact(string(body))}
+	}
+
+	f, err := os.OpenFile(part, flags, 0o644)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", part, err)
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("error reading recording: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("error writing %s: %w", part, err)
+	}
+	return nil
+}
+
+// HashRecordingFile returns the size and SHA-256 checksum of the file at p.
+func HashRecordingFile(p string) (*RecordingFile, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", p, err)
+	}
+	return &RecordingFile{Path: p, SHA256: hex.EncodeToString(h.Sum(nil)), Size: size}, nil
+}
+
+// resolveURL makes a link returned by the API absolute.
+func (c *Client) resolveURL(link string) (string, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", fmt.Errorf("invalid download link: %w", err)
+	}
+	if u.IsAbs() {
+		return u.String(), nil
+	}
+	base, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL: %w", err)
+	}
+	return base.ResolveReference(u).String(), nil
+}
+
+// recordingExt returns the file extension of the download link, such as
+// ".webm", or "" when it has none.
+func recordingExt(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	ext := path.Ext(u.Path)
+	if !recordingExtPattern.MatchString(ext) {
+		return ""
+	}
+	return ext
+}
+
+// contentRangeStart returns the first byte position of a Content-Range
+// header such as "bytes 100-199/200".
+func contentRangeStart(header string) (int64, bool) {
+	rest, ok := strings.CutPrefix(header, "bytes ")
+	if !ok {
+		return 0, false
+	}
+	start, _, ok := strings.Cut(rest, "-")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(start, 10, 64)
+	return n, err == nil
+}
+
+// isSafePathElement reports whether s can be used as a single file name.
+func isSafePathElement(s string) bool {
+	return s != "" && s != "." && s != ".." && !strings.ContainsAny(s, `/\`)
+}
@@ -0,0 +1,259 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestClient_DownloadSessionRecording_Resume(t *testing.T) {
+	content := bytes.Repeat([]byte("recording-"), 1000)
+
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, "rec.webm", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "rec.webm")
+	require.NoError(t, os.WriteFile(dest+partSuffix, content[:4000], 0o644))
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+	file, err := client.DownloadSessionRecording(context.Background(), "/recordings/rec.webm", dest)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"bytes=4000-"}, ranges)
+	assert.Equal(t, checksum(content), file.SHA256)
+	assert.Equal(t, int64(len(content)), file.Size)
+	assert.Equal(t, dest, file.Path)
+
+	written, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, content, written)
+	assert.NoFileExists(t, dest+partSuffix)
+}
+
+func TestClient_DownloadSessionRecording_RetriesFromOffset(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 500)
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			// Send half the file, then drop the connection.
+			w.Header().Set("Content-Length", "5000")
+			w.Write(content[:2500])
+			panic(http.ErrAbortHandler)
+		}
+		assert.Equal(t, "bytes=2500-", r.Header.Get("Range"))
+		http.ServeContent(w, r, "rec.webm", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "session", "rec.webm")
+	client := NewClient(server.URL, "test-key", "test-secret", false,
+		WithMaxRetries(2), WithRetryInterval(time.Millisecond, time.Millisecond))
+	file, err := client.DownloadSessionRecording(context.Background(), server.URL+"/rec.webm", dest)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	assert.Equal(t, checksum(content), file.SHA256)
+}
+
+func TestClient_DownloadSessionRecording_Headers(t *testing.T) {
+	var kasmToken, storageToken, storageAuth string
+	kasm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		kasmToken = r.Header.Get("X-WAF-Token")
+		w.Write([]byte("recording"))
+	}))
+	defer kasm.Close()
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		storageToken = r.Header.Get("X-WAF-Token")
+		storageAuth = r.Header.Get("Authorization")
+		w.Write([]byte("recording"))
+	}))
+	defer storage.Close()
+
+	client := NewClient(kasm.URL, "test-key", "test-secret", false, WithMaxRetries(0),
+		WithHeaders(map[string]string{"X-WAF-Token": "bypass", "Authorization": "Bearer proxy"}))
+	dir := t.TempDir()
+
+	_, err := client.DownloadSessionRecording(context.Background(), "/recordings/rec.webm", filepath.Join(dir, "kasm.webm"))
+	require.NoError(t, err)
+	assert.Equal(t, "bypass", kasmToken)
+
+	_, err = client.DownloadSessionRecording(context.Background(), storage.URL+"/bucket/rec.webm?X-Amz-Signature=abc", filepath.Join(dir, "storage.webm"))
+	require.NoError(t, err)
+	assert.Empty(t, storageToken)
+	assert.Empty(t, storageAuth)
+}
+
+func TestClient_DownloadSessionRecording_Errors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		contentType   string
+		statusCode    int
+		body          string
+		errorContains string
+	}{
+		{
+			name:          "Expired link",
+			statusCode:    http.StatusForbidden,
+			body:          "Request has expired",
+			errorContains: "Forbidden",
+		},
+		{
+			name:          "Error message",
+			contentType:   "application/json",
+			statusCode:    http.StatusOK,
+			body:          `{"error_message": "Recording not found"}`,
+			errorContains: "not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tc.contentType != "" {
+					w.Header().Set("Content-Type", tc.contentType)
+				}
+				w.WriteHeader(tc.statusCode)
+				w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			dest := filepath.Join(t.TempDir(), "rec.webm")
+			client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+			_, err := client.DownloadSessionRecording(context.Background(), "/rec.webm", dest)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.errorContains)
+			}
+			assert.NoFileExists(t, dest)
+		})
+	}
+}
+
+func TestClient_ExportSessionRecordings(t *testing.T) {
+	files := map[string][]byte{
+		"/rec/r1.webm": []byte("first recording"),
+		"/rec/r2.webm": []byte("second recording"),
+		"/rec/r3":      []byte("third recording"),
+	}
+
+	var (
+		mu          sync.Mutex
+		downloads   []string
+		active      int32
+		maxActive   int32
+		requestedID []interface{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/public/get_sessions_recordings" {
+			var req map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&req)
+			mu.Lock()
+			requestedID = req["target_kasm_ids"].([]interface{})
+			mu.Unlock()
+			assert.Equal(t, true, req["preauth_download_link"])
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"kasm_sessions": map[string]interface{}{
+					"kasm-b": map[string]interface{}{"session_recordings": []map[string]interface{}{
+						{"recording_id": "r3", "session_recording_download_url": "/rec/r3"},
+					}},
+					"kasm-a": map[string]interface{}{"session_recordings": []map[string]interface{}{
+						{"recording_id": "r2", "session_recording_download_url": "/rec/r2.webm?sig=abc"},
+						{"recording_id": "r1", "session_recording_download_url": "/rec/r1.webm"},
+					}},
+				},
+			})
+			return
+		}
+
+		n := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			m := atomic.LoadInt32(&maxActive)
+			if n <= m || atomic.CompareAndSwapInt32(&maxActive, m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		downloads = append(downloads, r.URL.Path)
+		mu.Unlock()
+		w.Write(files[r.URL.Path])
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+
+	recordings, err := client.ExportSessionRecordings(context.Background(), []string{"kasm-a", "kasm-b"}, dir,
+		RecordingExportOptions{Concurrency: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"kasm-a", "kasm-b"}, requestedID)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&maxActive))
+	assert.Len(t, downloads, 3)
+
+	require.Len(t, recordings, 3)
+	assert.Equal(t, "kasm-a", recordings[0].KasmID)
+	assert.Equal(t, "r1", recordings[0].RecordingID)
+	assert.Equal(t, filepath.Join(dir, "kasm-a", "r1.webm"), recordings[0].Path)
+	assert.Equal(t, checksum(files["/rec/r1.webm"]), recordings[0].SHA256)
+	assert.Equal(t, filepath.Join(dir, "kasm-a", "r2.webm"), recordings[1].Path)
+	assert.Equal(t, filepath.Join(dir, "kasm-b", "r3"), recordings[2].Path)
+
+	// Files that still match their known checksum are not downloaded again;
+	// a tampered file is.
+	require.NoError(t, os.WriteFile(recordings[1].Path, []byte("tampered"), 0o644))
+	downloads = nil
+	known := map[string]string{}
+	for _, rec := range recordings {
+		known[rec.RecordingID] = rec.SHA256
+	}
+	recordings, err = client.ExportSessionRecordings(context.Background(), []string{"kasm-a", "kasm-b"}, dir,
+		RecordingExportOptions{KnownSHA256: known})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/rec/r2.webm"}, downloads)
+	assert.Equal(t, checksum(files["/rec/r2.webm"]), recordings[1].SHA256)
+}
+
+func TestClient_ExportSessionRecordings_UnsafeID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"kasm_sessions": map[string]interface{}{
+				"kasm-a": map[string]interface{}{"session_recordings": []map[string]interface{}{
+					{"recording_id": "../escape", "session_recording_download_url": "/rec/x"},
+				}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+	_, err := client.ExportSessionRecordings(context.Background(), []string{"kasm-a"}, t.TempDir(), RecordingExportOptions{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "cannot be used as a file name")
+	}
+}
@@ -0,0 +1,30 @@
+package client
+
+// DefaultRecordingExportConcurrency is how many recordings are downloaded at
+// once when RecordingExportOptions.Concurrency is not set.
+const DefaultRecordingExportConcurrency = 4
+
+// RecordingExportOptions controls ExportSessionRecordings.
+type RecordingExportOptions struct {
+	// Concurrency is the maximum number of simultaneous downloads.
+	Concurrency int
+	// KnownSHA256 maps recording IDs to the checksums of files exported
+	// earlier. A file that is already in place with its known checksum is
+	// kept instead of being downloaded again.
+	KnownSHA256 map[string]string
+}
+
+// RecordingFile describes a recording stored on local disk.
+type RecordingFile struct {
+	Path   string
+	SHA256 string
+	Size   int64
+}
+
+// ExportedRecording is a session recording written by
+// ExportSessionRecordings.
+type ExportedRecording struct {
+	KasmID      string
+	RecordingID string
+	RecordingFile
+}
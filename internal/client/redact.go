@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RedactedValue replaces sensitive values in logs and diagnostics.
+const RedactedValue = "***REDACTED***"
+
+// SensitiveKeys lists the request, response and log field names whose values
+// must never be written out in clear text.
+var SensitiveKeys = []string{
+	"api_key_secret",
+	"api_secret",
+	"password",
+	"docker_password",
+	"session_token",
+	"session_jwt",
+	"aws_secret_access_key",
+	"aws_secret_key",
+	"aws_session_token",
+	"secret_access_key",
+	"egress_config",
+	"wireguard_config",
+	"openvpn_config",
+	"gcp_credentials",
+	"digital_ocean_token",
+	"oci_private_key",
+	"connection_private_key",
+	"sp_private_key",
+}
+
+var (
+	// sensitiveJSONPattern matches "key": "value" pairs in JSON text. Keys
+	// ending in password or secret are matched as well so that fields such as
+	// new_password or client_secret are covered without listing each one.
+	sensitiveJSONPattern = regexp.MustCompile(`(?i)("(?:` + sensitiveKeyPattern() + `|[a-z_]*password|[a-z_]*secret)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// sensitiveParamPattern matches key=value pairs in free text such as
+	// formatted error messages and query strings.
+	sensitiveParamPattern = regexp.MustCompile(`(?i)\b(` + sensitiveKeyPattern() + `)=([^\s,&"]+)`)
+	// sensitiveStructPattern matches Name:value pairs produced by formatting a
+	// Go struct with %+v, where field names are the keys without underscores.
+	sensitiveStructPattern = regexp.MustCompile(`(?i)\b(` + strings.ReplaceAll(sensitiveKeyPattern(), "_", "") + `|[a-z]*password|[a-z]*secret):("(?:[^"\\]|\\.)*"|[^\s}]+)`)
+)
+
+// sensitiveHeaders lists the credential-bearing headers, including the API
+// key and secret the client sends on every request.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key", "X-Api-Secret"}
+
+// sensitiveHeaderWords mask any header whose name contains one of them, such
+// as X-Auth-Token or X-Client-Secret.
+var sensitiveHeaderWords = []string{"secret", "token", "password", "api-key"}
+
+func sensitiveKeyPattern() string {
+	quoted := make([]string, len(SensitiveKeys))
+	for i, key := range SensitiveKeys {
+		quoted[i] = regexp.QuoteMeta(key)
+	}
+	return strings.Join(quoted, "|")
+}
+
+// Redact masks the values of sensitive fields in s, which may be a JSON body
+// or free text, and replaces every literal occurrence of secrets.
+func Redact(s string, secrets ...string) string {
+	s = sensitiveJSONPattern.ReplaceAllString(s, `${1}"`+RedactedValue+`"`)
+	s = sensitiveParamPattern.ReplaceAllString(s, "${1}="+RedactedValue)
+	s = sensitiveStructPattern.ReplaceAllString(s, "${1}:"+RedactedValue)
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, RedactedValue)
+		}
+	}
+	return s
+}
+
+// RedactHeader returns a copy of header with credential-bearing headers
+// masked.
+func RedactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for name := range redacted {
+		if isSensitiveHeader(name) {
+			redacted.Set(name, RedactedValue)
+		}
+	}
+	return redacted
+}
+
+func isSensitiveHeader(name string) bool {
+	for _, sensitive := range sensitiveHeaders {
+		if strings.EqualFold(name, sensitive) {
+			return true
+		}
+	}
+	lower := strings.ToLower(name)
+	for _, word := range sensitiveHeaderWords {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaskLogs returns a context whose tflog output masks the sensitive field
+// keys and any literal occurrence of secrets.
+func MaskLogs(ctx context.Context, secrets ...string) context.Context {
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, SensitiveKeys...)
+
+	var values []string
+	for _, secret := range secrets {
+		if secret != "" {
+			values = append(values, secret)
+		}
+	}
+	if len(values) > 0 {
+		ctx = tflog.MaskAllFieldValuesStrings(ctx, values...)
+		ctx = tflog.MaskMessageStrings(ctx, values...)
+	}
+	return ctx
+}
+
+// redact masks sensitive fields in s along with the client's API secret.
+func (c *Client) redact(s string) string {
+	return Redact(s, c.APISecret)
+}
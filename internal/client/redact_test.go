@@ -0,0 +1,122 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		secrets  []string
+		hidden   []string
+		retained []string
+	}{
+		{
+			name:     "JSON request body",
+			input:    `{"api_key": "key-1", "api_key_secret": "s3cr3t", "user_id": "u1"}`,
+			hidden:   []string{"s3cr3t"},
+			retained: []string{`"api_key": "key-1"`, `"user_id": "u1"`},
+		},
+		{
+			name:     "Nested JSON fields",
+			input:    `{"target_user":{"password":"hunter2","username":"bob"},"target_image":{"docker_password":"dp"}}`,
+			hidden:   []string{"hunter2", `"dp"`},
+			retained: []string{`"username":"bob"`},
+		},
+		{
+			name:     "Session credentials",
+			input:    `{"session_token":"tok-123","session_jwt":"eyJhbGciOi.x.y","kasm_id":"k1"}`,
+			hidden:   []string{"tok-123", "eyJhbGciOi"},
+			retained: []string{`"kasm_id":"k1"`},
+		},
+		{
+			name:   "AWS secrets",
+			input:  `{"aws_secret_access_key":"AKIAsecret","aws_session_token":"st"}`,
+			hidden: []string{"AKIAsecret", `"st"`},
+		},
+		{
+			name:     "Escaped quotes in value",
+			input:    `{"password":"a\"b","name":"n"}`,
+			hidden:   []string{`a\"b`},
+			retained: []string{`"name":"n"`},
+		},
+		{
+			name:     "Key value text",
+			input:    "base_url=https://kasm, api_secret=abc123, insecure=false",
+			hidden:   []string{"abc123"},
+			retained: []string{"base_url=https://kasm", "insecure=false"},
+		},
+		{
+			name:     "Formatted struct",
+			input:    "{Username:bob Password:hunter2 APIKeySecret:xyz}",
+			hidden:   []string{"hunter2", "xyz"},
+			retained: []string{"Username:bob"},
+		},
+		{
+			name:    "Literal secret",
+			input:   "request failed for secret-value",
+			secrets: []string{"secret-value", ""},
+			hidden:  []string{"secret-value"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Redact(tc.input, tc.secrets...)
+			for _, h := range tc.hidden {
+				assert.NotContains(t, got, h)
+			}
+			for _, r := range tc.retained {
+				assert.Contains(t, got, r)
+			}
+			assert.True(t, strings.Contains(got, RedactedValue), "expected %q to contain redaction marker", got)
+		})
+	}
+}
+
+func TestRedactHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("Set-Cookie", "session=abc")
+	header.Set("Authorization", "Bearer abc")
+
+	redacted := RedactHeader(header)
+
+	assert.Equal(t, "application/json", redacted.Get("Content-Type"))
+	assert.Equal(t, RedactedValue, redacted.Get("Set-Cookie"))
+	assert.Equal(t, RedactedValue, redacted.Get("Authorization"))
+	assert.Equal(t, "session=abc", header.Get("Set-Cookie"), "original header must not be modified")
+}
+
+func TestRedactHeader_RequestCredentials(t *testing.T) {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("X-Api-Key", "key-123")
+	header.Set("X-Api-Secret", "secret-456")
+	header.Set("X-Auth-Token", "token-789")
+	header.Set("X-Client-Secret", "secret-abc")
+
+	redacted := RedactHeader(header)
+
+	assert.Equal(t, "application/json", redacted.Get("Content-Type"))
+	for _, name := range []string{"X-Api-Key", "X-Api-Secret", "X-Auth-Token", "X-Client-Secret"} {
+		assert.Equal(t, RedactedValue, redacted.Get(name), name)
+	}
+}
+
+func TestCheckResponse_RedactsBody(t *testing.T) {
+	body := []byte(`{"api_key_secret": "s3cr3t", "detail": "boom"}`)
+
+	err := checkResponse(http.StatusBadGateway, http.Header{}, body, "", "")
+
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "s3cr3t")
+}
@@ -1,43 +1,32 @@
 package client
 
 import (
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
+	"context"
 )
 
-// GetRegistries retrieves all registries
-func (c *Client) GetRegistries() ([]Registry, error) {
+// GetRegistries retrieves all registries. The result is cached; see NoCache.
+func (c *Client) GetRegistries(ctx context.Context) ([]Registry, error) {
+	return cachedList(ctx, c, cacheKey{collection: collectionRegistries}, c.getRegistries)
+}
+
+// getRegistries fetches all registries, bypassing the cache
+func (c *Client) getRegistries(ctx context.Context) ([]Registry, error) {
 	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/get_registries", payload)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %v", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(body))
-	}
 	var result struct {
 		Registries []Registry `json:"registries"`
 	}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v, body: %s", err, string(body))
+	if err := c.post(ctx, "/api/public/get_registries", payload, "registry", "", &result); err != nil {
+		return nil, err
 	}
 	return result.Registries, nil
 }
 
 // CreateRegistryImage creates a new image from a registry workspace
-func (c *Client) CreateRegistryImage(workspace RegistryWorkspace) (*Image, error) {
+func (c *Client) CreateRegistryImage(ctx context.Context, workspace RegistryWorkspace) (*Image, error) {
 	image := ImageFromRegistryWorkspace(workspace)
 
 	payload := map[string]interface{}{
@@ -46,24 +35,18 @@ func (c *Client) CreateRegistryImage(workspace RegistryWorkspace) (*Image, error
 		"target_image":   image,
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/create_image", payload)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
 	var result struct {
 		Image *Image `json:"image"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/create_image", payload, "image", image.Name, &result); err != nil {
+		return nil, err
 	}
 
 	return result.Image, nil
 }
 
 // GetRegistryImage retrieves an image from the registry
-func (c *Client) GetRegistryImage(imageID string) (*Image, error) {
+func (c *Client) GetRegistryImage(ctx context.Context, imageID string) (*Image, error) {
 	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
@@ -72,48 +55,36 @@ func (c *Client) GetRegistryImage(imageID string) (*Image, error) {
 		},
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/get_image", payload)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
 	var result struct {
 		Image *Image `json:"image"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/get_image", payload, "image", imageID, &result); err != nil {
+		return nil, err
 	}
 
 	return result.Image, nil
 }
 
 // UpdateRegistryImage updates an existing registry image
-func (c *Client) UpdateRegistryImage(image *Image) (*Image, error) {
+func (c *Client) UpdateRegistryImage(ctx context.Context, image *Image) (*Image, error) {
 	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_image":   image,
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/update_image", payload)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
 	var result struct {
 		Image *Image `json:"image"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/update_image", payload, "image", image.ImageID, &result); err != nil {
+		return nil, err
 	}
 
 	return result.Image, nil
 }
 
 // DeleteRegistryImage deletes an image from the registry
-func (c *Client) DeleteRegistryImage(imageID string) error {
+func (c *Client) DeleteRegistryImage(ctx context.Context, imageID string) error {
 	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
@@ -122,50 +93,27 @@ func (c *Client) DeleteRegistryImage(imageID string) error {
 		},
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/delete_image", payload)
-	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
-	}
-
-	return nil
+	return c.post(ctx, "/api/public/delete_image", payload, "image", imageID, nil)
 }
 
 // ListRegistryImages retrieves all registry images
-func (c *Client) ListRegistryImages() ([]RegistryImage, error) {
+func (c *Client) ListRegistryImages(ctx context.Context) ([]RegistryImage, error) {
 	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/get_images", payload)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %v", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(body))
-	}
 	var result struct {
 		Images []RegistryImage `json:"images"`
 	}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v, body: %s", err, string(body))
+	if err := c.post(ctx, "/api/public/get_images", payload, "image", "", &result); err != nil {
+		return nil, err
 	}
 	return result.Images, nil
 }
 
 // CreateRegistry creates a new registry
-func (c *Client) CreateRegistry(request *CreateRegistryRequest) error {
+func (c *Client) CreateRegistry(ctx context.Context, request *CreateRegistryRequest) error {
 	payload := map[string]interface{}{
 		"api_key":         c.APIKey,
 		"api_key_secret":  c.APISecret,
@@ -174,22 +122,11 @@ func (c *Client) CreateRegistry(request *CreateRegistryRequest) error {
 		"channel":         request.Channel,
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/create_registry", payload)
-	if err != nil {
-		return fmt.Errorf("error creating registry: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to create registry, status: %d, response: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	return nil
+	return c.post(ctx, "/api/public/create_registry", payload, "registry", request.Registry, nil)
 }
 
 // DeleteRegistry deletes a registry
-func (c *Client) DeleteRegistry(registryID string) error {
+func (c *Client) DeleteRegistry(ctx context.Context, registryID string) error {
 	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
@@ -198,18 +135,7 @@ func (c *Client) DeleteRegistry(registryID string) error {
 		},
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/delete_registry", payload)
-	if err != nil {
-		return fmt.Errorf("error deleting registry: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete registry, status: %d, response: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	return nil
+	return c.post(ctx, "/api/public/delete_registry", payload, "registry", registryID, nil)
 }
 
 // ImageFromRegistryWorkspace converts a RegistryWorkspace to an Image
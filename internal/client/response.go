@@ -0,0 +1,144 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// post sends payload to endpoint and decodes the response into out, which
+// may be nil when the caller only needs to know the call succeeded.
+// resourceType and id identify the target object in a NotFoundError.
+func (c *Client) post(ctx context.Context, endpoint string, payload interface{}, resourceType, id string, out interface{}) error {
+	resp, err := c.doRequest(ctx, "POST", endpoint, payload)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return decodeResponse(resp, resourceType, id, out)
+}
+
+// decodeResponse reads resp and returns a typed error for HTTP failures or a
+// Kasm error_message. On success the body is decoded into out when non-nil.
+func decodeResponse(resp *http.Response, resourceType, id string, out interface{}) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+
+	return decodeBody(resp.StatusCode, resp.Header, body, resourceType, id, out)
+}
+
+// decodeBody is decodeResponse for a body that has already been read.
+func decodeBody(statusCode int, header http.Header, body []byte, resourceType, id string, out interface{}) error {
+	if err := checkResponse(statusCode, header, body, resourceType, id); err != nil {
+		return err
+	}
+
+	if out == nil || len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("error decoding response: %w, body: %s", err, Redact(string(body)))
+	}
+	return nil
+}
+
+// checkResponse returns a typed error when the status code signals failure or
+// when Kasm answered a 2xx status with an error_message.
+func checkResponse(statusCode int, header http.Header, body []byte, resourceType, id string) error {
+	if statusCode >= 200 && statusCode < 300 {
+		message := errorMessage(body, "error_message")
+		if message == "" {
+			return nil
+		}
+		return classifyMessage(statusCode, message, resourceType, id)
+	}
+
+	message := errorMessage(body, "error_message", "message", "error")
+	if message == "" && !bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")) {
+		message = Redact(strings.TrimSpace(string(body)))
+	}
+
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return &UnauthorizedError{Message: message}
+	case http.StatusForbidden:
+		return &ForbiddenError{Message: message, NotLicensed: isLicenseMessage(message)}
+	case http.StatusNotFound:
+		return &NotFoundError{ResourceType: resourceType, ID: id, Message: message}
+	case http.StatusConflict:
+		return &ConflictError{ResourceType: resourceType, Message: message}
+	case http.StatusTooManyRequests:
+		retryAfter, _ := parseRetryAfter(header.Get("Retry-After"), time.Now())
+		return &RateLimitedError{RetryAfter: retryAfter, Message: message}
+	}
+
+	if statusCode >= 500 {
+		return &APIError{StatusCode: statusCode, ErrorMessage: message, Response: Redact(string(body))}
+	}
+	return classifyMessage(statusCode, message, resourceType, id)
+}
+
+// classifyMessage maps a Kasm error message onto the error taxonomy. Kasm
+// reports most failures as free text, so this is necessarily keyword based.
+func classifyMessage(statusCode int, message, resourceType, id string) error {
+	lower := strings.ToLower(message)
+
+	switch {
+	case isLicenseMessage(message):
+		return &ForbiddenError{Message: message, NotLicensed: true}
+	case strings.Contains(lower, "not found"),
+		strings.Contains(lower, "does not exist"),
+		strings.Contains(lower, "no such"),
+		strings.Contains(lower, "unable to find"),
+		strings.Contains(lower, "could not find"):
+		return &NotFoundError{ResourceType: resourceType, ID: id, Message: message}
+	case strings.Contains(lower, "already exists"),
+		strings.Contains(lower, "duplicate"),
+		strings.Contains(lower, "already in use"):
+		return &ConflictError{ResourceType: resourceType, Message: message}
+	case strings.Contains(lower, "unauthorized"),
+		strings.Contains(lower, "invalid api key"),
+		strings.Contains(lower, "authentication failed"):
+		return &UnauthorizedError{Message: message}
+	case strings.Contains(lower, "access denied"),
+		strings.Contains(lower, "forbidden"),
+		strings.Contains(lower, "not authorized"),
+		strings.Contains(lower, "insufficient permission"):
+		return &ForbiddenError{Message: message}
+	}
+
+	if message == "" {
+		message = http.StatusText(statusCode)
+	}
+	return &ValidationError{StatusCode: statusCode, Message: message}
+}
+
+func isLicenseMessage(message string) bool {
+	return strings.Contains(strings.ToLower(message), "licens")
+}
+
+// errorMessage returns the first non-empty string among keys in a JSON
+// object body. Kasm uses error_message; some proxies and older endpoints use
+// message or error.
+func errorMessage(body []byte, keys ...string) string {
+	var envelope map[string]interface{}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return ""
+	}
+	for _, key := range keys {
+		if value, ok := envelope[key].(string); ok {
+			if s := strings.TrimSpace(value); s != "" {
+				return Redact(s)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,170 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckResponse(t *testing.T) {
+	testCases := []struct {
+		name       string
+		statusCode int
+		body       string
+		check      func(t *testing.T, err error)
+	}{
+		{
+			name:       "Success",
+			statusCode: http.StatusOK,
+			body:       `{"user": {"user_id": "abc"}}`,
+			check: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name:       "Error message on 200 is not found",
+			statusCode: http.StatusOK,
+			body:       `{"error_message": "User does not exist"}`,
+			check: func(t *testing.T, err error) {
+				assert.True(t, IsNotFoundOf(err, "user"))
+				var nfe *NotFoundError
+				assert.True(t, errors.As(err, &nfe))
+				assert.Equal(t, "abc", nfe.ID)
+			},
+		},
+		{
+			name:       "Error message on 200 is a license error",
+			statusCode: http.StatusOK,
+			body:       `{"error_message": "This feature is not licensed"}`,
+			check: func(t *testing.T, err error) {
+				assert.True(t, IsNotLicensed(err))
+			},
+		},
+		{
+			name:       "Error message on 200 is a conflict",
+			statusCode: http.StatusOK,
+			body:       `{"error_message": "Username already exists"}`,
+			check: func(t *testing.T, err error) {
+				var ce *ConflictError
+				assert.True(t, errors.As(err, &ce))
+			},
+		},
+		{
+			name:       "Unrecognised error message is a validation error",
+			statusCode: http.StatusOK,
+			body:       `{"error_message": "Invalid memory value"}`,
+			check: func(t *testing.T, err error) {
+				var ve *ValidationError
+				assert.True(t, errors.As(err, &ve))
+				assert.Equal(t, "Invalid memory value", ve.Message)
+			},
+		},
+		{
+			name:       "401",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"error_message": "Bad key"}`,
+			check: func(t *testing.T, err error) {
+				var ue *UnauthorizedError
+				assert.True(t, errors.As(err, &ue))
+			},
+		},
+		{
+			name:       "403",
+			statusCode: http.StatusForbidden,
+			body:       `Forbidden`,
+			check: func(t *testing.T, err error) {
+				var fe *ForbiddenError
+				assert.True(t, errors.As(err, &fe))
+				assert.False(t, fe.NotLicensed)
+			},
+		},
+		{
+			name:       "404",
+			statusCode: http.StatusNotFound,
+			body:       ``,
+			check: func(t *testing.T, err error) {
+				assert.True(t, IsNotFoundOf(err, "user"))
+			},
+		},
+		{
+			name:       "500",
+			statusCode: http.StatusInternalServerError,
+			body:       `{"error_message": "boom"}`,
+			check: func(t *testing.T, err error) {
+				var apiErr *APIError
+				assert.True(t, errors.As(err, &apiErr))
+				assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+				assert.Equal(t, "boom", apiErr.ErrorMessage)
+			},
+		},
+		{
+			name:       "400 with plain text body",
+			statusCode: http.StatusBadRequest,
+			body:       `bad request body`,
+			check: func(t *testing.T, err error) {
+				var ve *ValidationError
+				assert.True(t, errors.As(err, &ve))
+				assert.Equal(t, "bad request body", ve.Message)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkResponse(tc.statusCode, http.Header{}, []byte(tc.body), "user", "abc")
+			tc.check(t, err)
+		})
+	}
+}
+
+func TestCheckResponse_RateLimited(t *testing.T) {
+	header := http.Header{}
+	header.Set("Retry-After", "30")
+
+	err := checkResponse(http.StatusTooManyRequests, header, []byte(`{"message": "slow down"}`), "", "")
+
+	var rle *RateLimitedError
+	if !errors.As(err, &rle) {
+		t.Fatalf("Expected RateLimitedError, got %T: %v", err, err)
+	}
+	assert.Equal(t, 30*time.Second, rle.RetryAfter)
+	assert.Equal(t, "slow down", rle.Message)
+}
+
+func TestPost_WrappedErrorsRemainTyped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"error_message": "User not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false)
+
+	_, err := client.GetUser(context.Background(), "user-1")
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	assert.True(t, IsUserNotFoundError(err), "expected user not found, got %v", err)
+
+	var nfe *NotFoundError
+	if assert.True(t, errors.As(err, &nfe)) {
+		assert.Equal(t, "user-1", nfe.ID)
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	assert.True(t, isTransient(&APIError{StatusCode: http.StatusBadGateway}))
+	assert.True(t, isTransient(&RateLimitedError{}))
+	assert.True(t, isTransient(errors.New("connection reset")))
+	assert.False(t, isTransient(&NotFoundError{ResourceType: "image"}))
+	assert.False(t, isTransient(&ValidationError{Message: "bad"}))
+	assert.False(t, isTransient(context.Canceled))
+}
@@ -0,0 +1,85 @@
+package client
+
+import "net/http"
+
+// retryPolicy classifies how doRequest may retry a request to a given endpoint.
+type retryPolicy int
+
+const (
+	// retrySafe endpoints only read state or converge on the same result when
+	// repeated, so they are retried on any transient failure.
+	retrySafe retryPolicy = iota
+	// retryUnsafe endpoints create server-side state. Repeating one after the
+	// server may have processed it can produce duplicate users or sessions, so
+	// they are only retried when the server explicitly rejected the attempt.
+	retryUnsafe
+	// retryNever endpoints are best-effort probes whose callers fall back to
+	// a default when they fail, so a failure is reported immediately.
+	retryNever
+)
+
+// unsafeEndpoints lists the non-idempotent endpoints. Anything not listed here
+// is treated as safe to retry.
+var unsafeEndpoints = map[string]bool{
+	"/api/public/activate":                       true,
+	"/api/public/add_settings_group":             true,
+	"/api/public/add_sso_mapping_group":          true,
+	"/api/public/create_autoscale_config":        true,
+	"/api/public/create_cast_config":             true,
+	"/api/public/create_egress_credential":       true,
+	"/api/public/create_egress_gateway":          true,
+	"/api/public/create_egress_provider":         true,
+	"/api/public/create_egress_provider_mapping": true,
+	"/api/public/create_group":                   true,
+	"/api/public/create_image":                   true,
+	"/api/public/create_ldap_config":             true,
+	"/api/public/create_oidc_config":             true,
+	"/api/public/create_registry":                true,
+	"/api/public/create_saml_config":             true,
+	"/api/public/create_server":                  true,
+	"/api/public/create_server_pool":             true,
+	"/api/public/create_session_token":           true,
+	"/api/public/create_staging_config":          true,
+	"/api/public/create_user":                    true,
+	"/api/public/create_vm_provider_config":      true,
+	"/api/public/create_zone":                    true,
+	"/api/public/exec_command":                   true,
+	"/api/public/join_kasm":                      true,
+	"/api/public/request_kasm":                   true,
+}
+
+// probeEndpoints lists the endpoints that are never retried.
+var probeEndpoints = map[string]bool{
+	"/api/public/get_system_info": true,
+}
+
+// retryPolicyFor returns the retry policy for endpoint.
+func retryPolicyFor(endpoint string) retryPolicy {
+	switch {
+	case unsafeEndpoints[endpoint]:
+		return retryUnsafe
+	case probeEndpoints[endpoint]:
+		return retryNever
+	}
+	return retrySafe
+}
+
+// shouldRetry reports whether a response with statusCode may be retried under
+// the given policy.
+func (p retryPolicy) shouldRetry(statusCode int) bool {
+	switch p {
+	case retryUnsafe:
+		// 429 means the server refused the request before acting on it.
+		return statusCode == http.StatusTooManyRequests
+	case retryNever:
+		return false
+	}
+	return shouldRetry(statusCode)
+}
+
+// retryOnError reports whether a transport error may be retried under the
+// given policy. A transport error gives no guarantee the server did not act
+// on the request, so only safe endpoints are retried.
+func (p retryPolicy) retryOnError() bool {
+	return p == retrySafe
+}
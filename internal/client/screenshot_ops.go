@@ -0,0 +1,52 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// GetKasmScreenshot captures the desktop of the running session kasmID. The
+// server scales the image to width pixels wide; a width of 0 uses its
+// default. Kasm answers with the image itself rather than JSON.
+func (c *Client) GetKasmScreenshot(ctx context.Context, kasmID string, userID string, width int) (*Screenshot, error) {
+	requestBody := ScreenshotRequest{
+		APIKey:    c.APIKey,
+		APISecret: c.APISecret,
+		KasmID:    kasmID,
+		UserID:    userID,
+		Width:     width,
+	}
+
+	log.Printf("[DEBUG] Getting screenshot for kasm %s", kasmID)
+
+	resp, err := c.doRequest(ctx, "POST", "/api/public/get_kasm_screenshot", requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+
+	if err := checkResponse(resp.StatusCode, resp.Header, body, "kasm", kasmID); err != nil {
+		return nil, err
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, fmt.Errorf("expected an image from get_kasm_screenshot, got %s: %s",
+			contentType, Redact(string(bytes.TrimSpace(body))))
+	}
+
+	return &Screenshot{Data: body, ContentType: contentType}, nil
+}
@@ -0,0 +1,95 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pngHeader is enough of a PNG file for content type detection.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestClient_GetKasmScreenshot(t *testing.T) {
+	testCases := []struct {
+		name          string
+		contentType   string
+		statusCode    int
+		body          []byte
+		expectedType  string
+		errorContains string
+	}{
+		{
+			name:         "JPEG image",
+			contentType:  "image/jpeg",
+			statusCode:   http.StatusOK,
+			body:         []byte("\xff\xd8\xff\xe0jpeg"),
+			expectedType: "image/jpeg",
+		},
+		{
+			name:         "Content type detected from body",
+			statusCode:   http.StatusOK,
+			body:         pngHeader,
+			expectedType: "image/png",
+		},
+		{
+			name:          "Error message",
+			contentType:   "application/json",
+			statusCode:    http.StatusOK,
+			body:          []byte(`{"error_message": "Kasm not found"}`),
+			errorContains: "not found",
+		},
+		{
+			name:          "Unexpected JSON",
+			contentType:   "application/json",
+			statusCode:    http.StatusOK,
+			body:          []byte(`{"kasm": {}}`),
+			errorContains: "expected an image",
+		},
+		{
+			name:          "Server error",
+			statusCode:    http.StatusInternalServerError,
+			body:          []byte("boom"),
+			errorContains: "API error (status 500)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var received ScreenshotRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/public/get_kasm_screenshot", r.URL.Path)
+				json.NewDecoder(r.Body).Decode(&received)
+				if tc.contentType != "" {
+					w.Header().Set("Content-Type", tc.contentType)
+				}
+				w.WriteHeader(tc.statusCode)
+				w.Write(tc.body)
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+			screenshot, err := client.GetKasmScreenshot(context.Background(), "kasm-1", "user-1", 640)
+
+			assert.Equal(t, "kasm-1", received.KasmID)
+			assert.Equal(t, "user-1", received.UserID)
+			assert.Equal(t, 640, received.Width)
+
+			if tc.errorContains != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.errorContains)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedType, screenshot.ContentType)
+			assert.Equal(t, tc.body, screenshot.Data)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package client
+
+// ScreenshotRequest represents the request body for the get_kasm_screenshot API endpoint
+type ScreenshotRequest struct {
+	APIKey    string `json:"api_key"`
+	APISecret string `json:"api_key_secret"`
+	KasmID    string `json:"kasm_id"`
+	UserID    string `json:"user_id,omitempty"`
+	Width     int    `json:"width,omitempty"`
+}
+
+// Screenshot is an image of a session's desktop
+type Screenshot struct {
+	// Data is the encoded image.
+	Data []byte
+	// ContentType is the media type reported by the server, such as image/jpeg.
+	ContentType string
+}
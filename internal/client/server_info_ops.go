@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// serverInfoProbeTimeout bounds DetectServerInfo so that an unreachable
+// server does not hold up provider configuration.
+const serverInfoProbeTimeout = 10 * time.Second
+
+// WithServerVersion pins the Kasm server version instead of probing it, for
+// servers that do not report their version.
+func WithServerVersion(version ServerVersion) ClientOption {
+	return func(c *Client) {
+		c.serverInfo = &ServerInfo{Version: version}
+	}
+}
+
+// GetServerInfo asks the server for its version and build.
+func (c *Client) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+	}
+
+	var result GetSystemInfoResponse
+	if err := c.post(ctx, "/api/public/get_system_info", payload, "system_info", "", &result); err != nil {
+		return nil, err
+	}
+
+	raw := result.systemInfo
+	if result.SystemInfo != nil {
+		raw = *result.SystemInfo
+	}
+
+	info := &ServerInfo{
+		BuildID:        raw.BuildID,
+		InstallationID: raw.InstallationID,
+	}
+	for _, s := range []string{raw.ServerVersion, raw.KasmVersion, raw.Version} {
+		if s == "" {
+			continue
+		}
+		version, err := ParseServerVersion(s)
+		if err != nil {
+			return nil, err
+		}
+		info.Version = version
+		info.Detected = true
+		break
+	}
+	return info, nil
+}
+
+// DetectServerInfo probes the server once and caches the result for
+// ServerInfo and RequireFeature. A server that does not implement
+// get_system_info is recorded with an unknown version rather than treated as
+// an error. A version pinned with WithServerVersion is never overwritten.
+func (c *Client) DetectServerInfo(ctx context.Context) (*ServerInfo, error) {
+	if info := c.ServerInfo(); info.Version.IsKnown() {
+		return info, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, serverInfoProbeTimeout)
+	defer cancel()
+
+	info, err := c.GetServerInfo(ctx)
+	if err != nil {
+		var ve *ValidationError
+		if !IsNotFound(err) && !errors.As(err, &ve) {
+			return nil, fmt.Errorf("error detecting Kasm server version: %w", err)
+		}
+		log.Printf("[DEBUG] Server does not report its version: %v", err)
+		info = &ServerInfo{}
+	}
+
+	c.mu.Lock()
+	c.serverInfo = info
+	c.mu.Unlock()
+	return info, nil
+}
+
+// ServerInfo returns the cached server information. The version is unknown
+// until DetectServerInfo succeeds or WithServerVersion is used.
+func (c *Client) ServerInfo() *ServerInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.serverInfo == nil {
+		return &ServerInfo{}
+	}
+	info := *c.serverInfo
+	return &info
+}
+
+// RequireFeature returns an UnsupportedFeatureError when the server is known
+// to be older than the first release that supports feature. When the version
+// is unknown the request is left for the server to judge.
+func (c *Client) RequireFeature(feature Feature) error {
+	version := c.ServerInfo().Version
+	if !version.IsKnown() || version.AtLeast(feature.MinVersion) {
+		return nil
+	}
+	return &UnsupportedFeatureError{Feature: feature, ServerVersion: version}
+}
+
+// SupportsFeature reports whether RequireFeature allows feature.
+func (c *Client) SupportsFeature(feature Feature) bool {
+	return c.RequireFeature(feature) == nil
+}
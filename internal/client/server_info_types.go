@@ -0,0 +1,30 @@
+package client
+
+// ServerInfo describes the Kasm deployment the client talks to.
+type ServerInfo struct {
+	// Version is the zero ServerVersion when it could not be determined.
+	Version ServerVersion
+	// BuildID is the build identifier reported by the server, if any.
+	BuildID string
+	// InstallationID identifies the Kasm installation, if reported.
+	InstallationID string
+	// Detected is false when the version was configured explicitly or the
+	// server did not report one.
+	Detected bool
+}
+
+// systemInfo is the get_system_info payload. Kasm releases differ in whether
+// the fields are nested under system_info and in what the version is called.
+type systemInfo struct {
+	Version        string `json:"version"`
+	ServerVersion  string `json:"server_version"`
+	KasmVersion    string `json:"kasm_version"`
+	BuildID        string `json:"build_id"`
+	InstallationID string `json:"installation_id"`
+}
+
+// GetSystemInfoResponse represents the response from get_system_info
+type GetSystemInfoResponse struct {
+	systemInfo
+	SystemInfo *systemInfo `json:"system_info"`
+}
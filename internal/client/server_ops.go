@@ -0,0 +1,227 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// CreateServer registers a new fixed server
+func (c *Client) CreateServer(ctx context.Context, server *Server) (*Server, error) {
+	if err := validateServer(server); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_server":  server,
+	}
+
+	var result struct {
+		Server *Server `json:"server"`
+	}
+	if err := c.post(ctx, "/api/public/create_server", payload, "server", server.Name, &result); err != nil {
+		return nil, err
+	}
+	if result.Server == nil {
+		return nil, fmt.Errorf("create_server returned no server")
+	}
+
+	return result.Server, nil
+}
+
+// GetServer retrieves a fixed server by ID
+func (c *Client) GetServer(ctx context.Context, serverID string) (*Server, error) {
+	servers, err := c.GetServers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting servers: %w", err)
+	}
+
+	for _, server := range servers {
+		if server.ServerID == serverID {
+			return &server, nil
+		}
+	}
+
+	return nil, &NotFoundError{ResourceType: "server", ID: serverID}
+}
+
+// UpdateServer updates an existing fixed server
+func (c *Client) UpdateServer(ctx context.Context, server *Server) (*Server, error) {
+	if err := validateServer(server); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_server":  server,
+	}
+
+	var result struct {
+		Server *Server `json:"server"`
+	}
+	if err := c.post(ctx, "/api/public/update_server", payload, "server", server.ServerID, &result); err != nil {
+		return nil, err
+	}
+	if result.Server == nil {
+		return server, nil
+	}
+
+	return result.Server, nil
+}
+
+// DeleteServer deletes a fixed server by ID
+func (c *Client) DeleteServer(ctx context.Context, serverID string) error {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_server": map[string]string{
+			"server_id": serverID,
+		},
+	}
+
+	return c.post(ctx, "/api/public/delete_server", payload, "server", serverID, nil)
+}
+
+// GetServers retrieves all fixed servers
+func (c *Client) GetServers(ctx context.Context) ([]Server, error) {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+	}
+
+	var result struct {
+		Servers []Server `json:"servers"`
+	}
+	if err := c.post(ctx, "/api/public/get_servers", payload, "server", "", &result); err != nil {
+		return nil, err
+	}
+
+	return result.Servers, nil
+}
+
+func validateServer(server *Server) error {
+	if server.Name == "" {
+		return fmt.Errorf("server name is required")
+	}
+	if server.Hostname == "" {
+		return fmt.Errorf("server hostname is required")
+	}
+	if !IsValidServerConnectionType(server.ConnectionType) {
+		return fmt.Errorf("server connection_type must be one of %v, got %q", ServerConnectionTypes, server.ConnectionType)
+	}
+	if server.Port < 1 || server.Port > 65535 {
+		return fmt.Errorf("server connection_port must be between 1 and 65535, got %d", server.Port)
+	}
+	if server.PrivateKey != "" && server.ConnectionType != ServerConnectionSSH {
+		return fmt.Errorf("a private key can only be used with ssh connections, not %s", server.ConnectionType)
+	}
+	if server.MaxSimultaneousSessions < 0 {
+		return fmt.Errorf("server max_simultaneous_sessions cannot be negative, got %d", server.MaxSimultaneousSessions)
+	}
+	return nil
+}
+
+// IsValidServerConnectionType reports whether t is one of
+// ServerConnectionTypes.
+func IsValidServerConnectionType(t string) bool {
+	_, ok := defaultServerPorts[t]
+	return ok
+}
+
+// CreateServerPool creates a new server pool
+func (c *Client) CreateServerPool(ctx context.Context, pool *ServerPool) (*ServerPool, error) {
+	if pool.Name == "" {
+		return nil, fmt.Errorf("server pool name is required")
+	}
+
+	payload := map[string]interface{}{
+		"api_key":            c.APIKey,
+		"api_key_secret":     c.APISecret,
+		"target_server_pool": pool,
+	}
+
+	var result struct {
+		ServerPool *ServerPool `json:"server_pool"`
+	}
+	if err := c.post(ctx, "/api/public/create_server_pool", payload, "server_pool", pool.Name, &result); err != nil {
+		return nil, err
+	}
+	if result.ServerPool == nil {
+		return nil, fmt.Errorf("create_server_pool returned no server pool")
+	}
+
+	return result.ServerPool, nil
+}
+
+// GetServerPool retrieves a server pool by ID
+func (c *Client) GetServerPool(ctx context.Context, serverPoolID string) (*ServerPool, error) {
+	pools, err := c.GetServerPools(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting server pools: %w", err)
+	}
+
+	for _, pool := range pools {
+		if pool.ServerPoolID == serverPoolID {
+			return &pool, nil
+		}
+	}
+
+	return nil, &NotFoundError{ResourceType: "server_pool", ID: serverPoolID}
+}
+
+// UpdateServerPool renames an existing server pool
+func (c *Client) UpdateServerPool(ctx context.Context, pool *ServerPool) (*ServerPool, error) {
+	if pool.Name == "" {
+		return nil, fmt.Errorf("server pool name is required")
+	}
+
+	payload := map[string]interface{}{
+		"api_key":            c.APIKey,
+		"api_key_secret":     c.APISecret,
+		"target_server_pool": pool,
+	}
+
+	var result struct {
+		ServerPool *ServerPool `json:"server_pool"`
+	}
+	if err := c.post(ctx, "/api/public/update_server_pool", payload, "server_pool", pool.ServerPoolID, &result); err != nil {
+		return nil, err
+	}
+	if result.ServerPool == nil {
+		return pool, nil
+	}
+
+	return result.ServerPool, nil
+}
+
+// DeleteServerPool deletes a server pool by ID
+func (c *Client) DeleteServerPool(ctx context.Context, serverPoolID string) error {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_server_pool": map[string]string{
+			"server_pool_id": serverPoolID,
+		},
+	}
+
+	return c.post(ctx, "/api/public/delete_server_pool", payload, "server_pool", serverPoolID, nil)
+}
+
+// GetServerPools retrieves all server pools
+func (c *Client) GetServerPools(ctx context.Context) ([]ServerPool, error) {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+	}
+
+	var result struct {
+		ServerPools []ServerPool `json:"server_pools"`
+	}
+	if err := c.post(ctx, "/api/public/get_server_pools", payload, "server_pool", "", &result); err != nil {
+		return nil, err
+	}
+
+	return result.ServerPools, nil
+}
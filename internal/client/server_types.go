@@ -0,0 +1,60 @@
+package client
+
+// Connection types of fixed servers.
+const (
+	ServerConnectionRDP     = "rdp"
+	ServerConnectionVNC     = "vnc"
+	ServerConnectionSSH     = "ssh"
+	ServerConnectionKasmVNC = "kasmvnc"
+)
+
+// ServerConnectionTypes lists the valid values of Server.ConnectionType.
+var ServerConnectionTypes = []string{
+	ServerConnectionRDP,
+	ServerConnectionVNC,
+	ServerConnectionSSH,
+	ServerConnectionKasmVNC,
+}
+
+// defaultServerPorts are the well-known ports of each connection type.
+var defaultServerPorts = map[string]int{
+	ServerConnectionRDP:     3389,
+	ServerConnectionVNC:     5900,
+	ServerConnectionSSH:     22,
+	ServerConnectionKasmVNC: 6901,
+}
+
+// DefaultServerPort returns the well-known port of a connection type, or 0
+// for an unknown type.
+func DefaultServerPort(connectionType string) int {
+	return defaultServerPorts[connectionType]
+}
+
+// Server is a fixed RDP, VNC, SSH or KasmVNC host that server workspaces
+// connect to, as opposed to a container launched on an agent.
+type Server struct {
+	ServerID       string `json:"server_id,omitempty"`
+	Name           string `json:"friendly_name"`
+	Hostname       string `json:"hostname"`
+	ConnectionType string `json:"connection_type"`
+	Port           int    `json:"connection_port"`
+	// The credentials are always sent, so that an update with an empty
+	// value clears the one stored by Kasm.
+	Username string `json:"connection_username"`
+	Password string `json:"connection_password"`
+	// PrivateKey authenticates SSH connections instead of a password.
+	PrivateKey string `json:"connection_private_key"`
+	// MaxSimultaneousSessions limits the sessions on the server; 0 means
+	// no limit.
+	MaxSimultaneousSessions int    `json:"max_simultaneous_sessions"`
+	ZoneID                  string `json:"zone_id,omitempty"`
+	ServerPoolID            string `json:"server_pool_id,omitempty"`
+	Enabled                 bool   `json:"enabled"`
+}
+
+// ServerPool groups fixed servers so that a workspace can be launched on any
+// of them.
+type ServerPool struct {
+	ServerPoolID string `json:"server_pool_id,omitempty"`
+	Name         string `json:"server_pool_name"`
+}
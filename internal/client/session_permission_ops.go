@@ -1,31 +1,29 @@
 package client
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 )
 
 // SetSessionPermissions sets permissions for multiple users in a session
-func (c *Client) SetSessionPermissions(request *SetSessionPermissionsRequest) ([]SessionPermission, error) {
+func (c *Client) SetSessionPermissions(ctx context.Context, request *SetSessionPermissionsRequest) ([]SessionPermission, error) {
 	payload := map[string]interface{}{
 		"api_key":                    c.APIKey,
 		"api_key_secret":             c.APISecret,
 		"target_session_permissions": request.TargetSessionPermissions,
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/set_session_permissions", payload)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
+	if err := c.post(ctx, "/api/public/set_session_permissions", payload, "kasm", request.TargetSessionPermissions.KasmID, nil); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
 
 	// After setting permissions, get the user details for each permission
 	var permissions []SessionPermission
 	for _, perm := range request.TargetSessionPermissions.SessionPermissions {
 		// Get user details
-		user, err := c.GetUser(perm.UserID)
+		user, err := c.GetUser(ctx, perm.UserID)
 		if err != nil {
-			return nil, fmt.Errorf("error getting user details: %v", err)
+			return nil, fmt.Errorf("error getting user details: %w", err)
 		}
 
 		permissions = append(permissions, SessionPermission{
@@ -40,24 +38,18 @@ func (c *Client) SetSessionPermissions(request *SetSessionPermissionsRequest) ([
 }
 
 // GetSessionPermissions retrieves session permissions for a session
-func (c *Client) GetSessionPermissions(request *GetSessionPermissionsRequest) ([]SessionPermission, error) {
+func (c *Client) GetSessionPermissions(ctx context.Context, request *GetSessionPermissionsRequest) ([]SessionPermission, error) {
 	payload := map[string]interface{}{
 		"api_key":                    c.APIKey,
 		"api_key_secret":             c.APISecret,
 		"target_session_permissions": request.TargetSessionPermissions,
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/get_session_permissions", payload)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
 	var result struct {
 		SessionPermissions []SessionPermission `json:"session_permissions"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/get_session_permissions", payload, "kasm", request.TargetSessionPermissions.KasmID, &result); err != nil {
+		return nil, err
 	}
 
 	// If we got permissions but they don't have usernames, fetch them
@@ -65,9 +57,9 @@ func (c *Client) GetSessionPermissions(request *GetSessionPermissionsRequest) ([
 		var permissions []SessionPermission
 		for _, perm := range result.SessionPermissions {
 			// Get user details
-			user, err := c.GetUser(perm.UserID)
+			user, err := c.GetUser(ctx, perm.UserID)
 			if err != nil {
-				return nil, fmt.Errorf("error getting user details: %v", err)
+				return nil, fmt.Errorf("error getting user details: %w", err)
 			}
 
 			// Create new permission with original access level and user details
@@ -85,18 +77,12 @@ func (c *Client) GetSessionPermissions(request *GetSessionPermissionsRequest) ([
 }
 
 // DeleteAllSessionPermissions deletes all session permissions for a session
-func (c *Client) DeleteAllSessionPermissions(request *DeleteAllSessionPermissionsRequest) error {
+func (c *Client) DeleteAllSessionPermissions(ctx context.Context, request *DeleteAllSessionPermissionsRequest) error {
 	payload := map[string]interface{}{
 		"api_key":                    c.APIKey,
 		"api_key_secret":             c.APISecret,
 		"target_session_permissions": request.TargetSessionPermissions,
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/delete_all_session_permissions", payload)
-	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	return nil
+	return c.post(ctx, "/api/public/delete_all_session_permissions", payload, "kasm", request.TargetSessionPermissions.KasmID, nil)
 }
@@ -0,0 +1,137 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionRecording_StartTime(t *testing.T) {
+	testCases := []struct {
+		name     string
+		metadata map[string]interface{}
+		expected time.Time
+		ok       bool
+	}{
+		{
+			name:     "RFC 3339",
+			metadata: map[string]interface{}{"start_time": "2024-03-01T10:00:00Z"},
+			expected: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "Kasm timestamp",
+			metadata: map[string]interface{}{"start_time": "2024-03-01 10:00:00.123456"},
+			expected: time.Date(2024, 3, 1, 10, 0, 0, 123456000, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "Unix seconds",
+			metadata: map[string]interface{}{"start_time": float64(1709287200)},
+			expected: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "Created fallback",
+			metadata: map[string]interface{}{"created": "2024-03-01T10:00:00Z"},
+			expected: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "Missing",
+			metadata: map[string]interface{}{"duration": float64(42)},
+		},
+		{
+			name:     "Unparsable",
+			metadata: map[string]interface{}{"start_time": "yesterday"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start, ok := SessionRecording{SessionRecordingMetadata: tc.metadata}.StartTime()
+			assert.Equal(t, tc.ok, ok)
+			assert.True(t, tc.expected.Equal(start), "got %s", start)
+		})
+	}
+}
+
+func TestClient_GetSessionRecordings(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/public/get_session_recordings", r.URL.Path)
+		json.NewDecoder(r.Body).Decode(&received)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"session_recordings": []map[string]interface{}{
+				{"recording_id": "early", "session_recording_metadata": map[string]interface{}{"start_time": "2024-02-28T23:00:00Z"}},
+				{"recording_id": "inside", "session_recording_metadata": map[string]interface{}{"start_time": "2024-03-01 10:00:00"}},
+				{"recording_id": "late", "session_recording_metadata": map[string]interface{}{"start_time": "2024-03-02T00:00:01Z"}},
+				{"recording_id": "undated", "session_recording_metadata": map[string]interface{}{}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+
+	t.Run("Unfiltered", func(t *testing.T) {
+		recordings, err := client.GetSessionRecordings(context.Background(), "kasm-1", SessionRecordingsOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, recordings, 4)
+		assert.Equal(t, "kasm-1", received["target_kasm_id"])
+		assert.Equal(t, false, received["preauth_download_link"])
+		assert.NotContains(t, received, "preauth_download_link_expiry")
+	})
+
+	t.Run("Date range and link expiry", func(t *testing.T) {
+		recordings, err := client.GetSessionRecordings(context.Background(), "kasm-1", SessionRecordingsOptions{
+			PreauthDownloadLink:       true,
+			PreauthDownloadLinkExpiry: 90 * time.Minute,
+			StartDate:                 time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:                   time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+		})
+		assert.NoError(t, err)
+		if assert.Len(t, recordings, 1) {
+			assert.Equal(t, "inside", recordings[0].RecordingID)
+		}
+		assert.Equal(t, true, received["preauth_download_link"])
+		assert.Equal(t, float64(5400), received["preauth_download_link_expiry"])
+	})
+}
+
+func TestClient_GetSessionsRecordings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/public/get_sessions_recordings", r.URL.Path)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"kasm_sessions": map[string]interface{}{
+				"kasm-1": map[string]interface{}{
+					"session_recordings": []map[string]interface{}{
+						{"recording_id": "a", "session_recording_metadata": map[string]interface{}{"start_time": "2024-03-01T10:00:00Z"}},
+						{"recording_id": "b", "session_recording_metadata": map[string]interface{}{"start_time": "2024-01-01T10:00:00Z"}},
+					},
+				},
+				"kasm-2": map[string]interface{}{"session_recordings": []interface{}{}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+	recordings, err := client.GetSessionsRecordings(context.Background(), []string{"kasm-1", "kasm-2"}, SessionRecordingsOptions{
+		StartDate: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, recordings, 2)
+	if assert.Len(t, recordings["kasm-1"], 1) {
+		assert.Equal(t, "a", recordings["kasm-1"][0].RecordingID)
+	}
+	assert.Empty(t, recordings["kasm-2"])
+}
@@ -1,156 +1,102 @@
 package client
 
 import (
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
+	"context"
 )
 
 // CreateSessionToken creates a new session token for a user
-func (c *Client) CreateSessionToken(request *CreateSessionTokenRequest) (*SessionToken, error) {
+func (c *Client) CreateSessionToken(ctx context.Context, request *CreateSessionTokenRequest) (*SessionToken, error) {
 	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_user":    request.TargetUser,
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/create_session_token", payload)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
 	var result struct {
 		SessionToken *SessionToken `json:"session_token"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/create_session_token", payload, "user", request.TargetUser.UserID, &result); err != nil {
+		return nil, err
 	}
 
 	return result.SessionToken, nil
 }
 
 // GetSessionToken retrieves a specific session token
-func (c *Client) GetSessionToken(request *GetSessionTokenRequest) (*SessionToken, error) {
+func (c *Client) GetSessionToken(ctx context.Context, request *GetSessionTokenRequest) (*SessionToken, error) {
 	payload := map[string]interface{}{
 		"api_key":              c.APIKey,
 		"api_key_secret":       c.APISecret,
 		"target_session_token": request.TargetSessionToken,
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/get_session_token", payload)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
 	var result struct {
 		SessionToken *SessionToken `json:"session_token"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/get_session_token", payload, "session_token", request.TargetSessionToken.SessionToken, &result); err != nil {
+		return nil, err
+	}
+	if result.SessionToken == nil {
+		return nil, &NotFoundError{ResourceType: "session_token", ID: request.TargetSessionToken.SessionToken}
 	}
 
 	return result.SessionToken, nil
 }
 
 // GetSessionTokens retrieves all session tokens for a user
-func (c *Client) GetSessionTokens(request *GetSessionTokensRequest) ([]SessionToken, error) {
+func (c *Client) GetSessionTokens(ctx context.Context, request *GetSessionTokensRequest) ([]SessionToken, error) {
 	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_user":    request.TargetUser,
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/get_session_tokens", payload)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %v", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(body))
-	}
-
 	var result struct {
 		SessionTokens []SessionToken `json:"session_tokens"`
 	}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v, body: %s", err, string(body))
+	if err := c.post(ctx, "/api/public/get_session_tokens", payload, "user", request.TargetUser.UserID, &result); err != nil {
+		return nil, err
 	}
 
 	return result.SessionTokens, nil
 }
 
 // UpdateSessionToken updates (promotes) an existing session token
-func (c *Client) UpdateSessionToken(request *UpdateSessionTokenRequest) (*SessionToken, error) {
+func (c *Client) UpdateSessionToken(ctx context.Context, request *UpdateSessionTokenRequest) (*SessionToken, error) {
 	payload := map[string]interface{}{
 		"api_key":              c.APIKey,
 		"api_key_secret":       c.APISecret,
 		"target_session_token": request.TargetSessionToken,
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/update_session_token", payload)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
 	var result struct {
 		SessionToken *SessionToken `json:"session_token"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/update_session_token", payload, "session_token", request.TargetSessionToken.SessionToken, &result); err != nil {
+		return nil, err
 	}
 
 	return result.SessionToken, nil
 }
 
 // DeleteSessionToken deletes a specific session token
-func (c *Client) DeleteSessionToken(request *DeleteSessionTokenRequest) error {
+func (c *Client) DeleteSessionToken(ctx context.Context, request *DeleteSessionTokenRequest) error {
 	payload := map[string]interface{}{
 		"api_key":              c.APIKey,
 		"api_key_secret":       c.APISecret,
 		"target_session_token": request.TargetSessionToken,
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/delete_session_token", payload)
-	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete session token, status: %d, response: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	return nil
+	return c.post(ctx, "/api/public/delete_session_token", payload, "session_token", request.TargetSessionToken.SessionToken, nil)
 }
 
 // DeleteSessionTokens deletes all session tokens for a user
-func (c *Client) DeleteSessionTokens(request *DeleteSessionTokensRequest) error {
+func (c *Client) DeleteSessionTokens(ctx context.Context, request *DeleteSessionTokensRequest) error {
 	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_user":    request.TargetUser,
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/delete_session_tokens", payload)
-	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete session tokens, status: %d, response: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	return nil
+	return c.post(ctx, "/api/public/delete_session_tokens", payload, "user", request.TargetUser.UserID, nil)
 }
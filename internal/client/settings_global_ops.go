@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNoSettingDefault is returned by ResetGlobalSetting when Kasm does not
+// report the default of a setting.
+var ErrNoSettingDefault = errors.New("Kasm does not report a default value for this setting")
+
+// GetGlobalSettings retrieves all global settings
+func (c *Client) GetGlobalSettings(ctx context.Context) ([]GlobalSetting, error) {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+	}
+
+	var result struct {
+		Settings []GlobalSetting `json:"settings"`
+	}
+	if err := c.post(ctx, "/api/public/get_settings", payload, "global_setting", "", &result); err != nil {
+		return nil, err
+	}
+
+	return result.Settings, nil
+}
+
+// GetGlobalSetting retrieves a global setting by category and name
+func (c *Client) GetGlobalSetting(ctx context.Context, category, name string) (*GlobalSetting, error) {
+	settings, err := c.GetGlobalSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting global settings: %w", err)
+	}
+
+	for _, setting := range settings {
+		if setting.Name != name {
+			continue
+		}
+		if setting.Category != category {
+			return nil, fmt.Errorf("global setting %s is in category %s, not %s", name, setting.Category, category)
+		}
+		return &setting, nil
+	}
+
+	return nil, &NotFoundError{ResourceType: "global_setting", ID: category + ":" + name}
+}
+
+// UpdateGlobalSetting changes the value of a global setting
+func (c *Client) UpdateGlobalSetting(ctx context.Context, setting *GlobalSetting, value string) (*GlobalSetting, error) {
+	if err := validateSettingValue(setting.Name, setting.Type(), value); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_setting": map[string]interface{}{
+			"setting_id": setting.SettingID,
+			"name":       setting.Name,
+			"value":      formatSettingValue(setting.Type(), value),
+		},
+	}
+
+	var result struct {
+		Setting *GlobalSetting `json:"setting"`
+	}
+	if err := c.post(ctx, "/api/public/update_setting", payload, "global_setting", setting.Name, &result); err != nil {
+		return nil, err
+	}
+	if result.Setting == nil {
+		updated := *setting
+		updated.Value = SettingValue(formatSettingValue(setting.Type(), value))
+		return &updated, nil
+	}
+	if result.Setting.DefaultValue == nil {
+		result.Setting.DefaultValue = setting.DefaultValue
+	}
+
+	return result.Setting, nil
+}
+
+// ResetGlobalSetting restores the default value of a global setting. It
+// returns ErrNoSettingDefault when Kasm does not report the default.
+func (c *Client) ResetGlobalSetting(ctx context.Context, setting *GlobalSetting) (*GlobalSetting, error) {
+	if setting.DefaultValue == nil {
+		return nil, fmt.Errorf("global setting %s: %w", setting.Name, ErrNoSettingDefault)
+	}
+
+	return c.UpdateGlobalSetting(ctx, setting, string(*setting.DefaultValue))
+}
@@ -0,0 +1,107 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateGlobalSettingValue(t *testing.T) {
+	testCases := []struct {
+		name        string
+		setting     string
+		value       string
+		expectError bool
+	}{
+		{name: "Integer", setting: "login_session_lifetime", value: "3600"},
+		{name: "Boolean", setting: "login_banner_enabled", value: "true"},
+		{name: "String", setting: "login_banner_text", value: "Authorised use only"},
+		{name: "Unknown setting", setting: "some_future_setting", value: "anything"},
+		{name: "Bad integer", setting: "api_rate_limit", value: "lots", expectError: true},
+		{name: "Bad boolean", setting: "add_images_to_default_group", value: "yes", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateGlobalSettingValue(tc.setting, tc.value)
+			assert.Equal(t, tc.expectError, err != nil, "error: %v", err)
+		})
+	}
+}
+
+func TestGlobalSetting_Type(t *testing.T) {
+	// Known settings use the provider's type, others the reported one.
+	assert.Equal(t, GroupSettingTypeBool, (&GlobalSetting{Name: "login_banner_enabled", ValueType: "string"}).Type())
+	assert.Equal(t, GroupSettingTypeJSON, (&GlobalSetting{Name: "some_future_setting", ValueType: "json"}).Type())
+
+	setting := &GlobalSetting{Name: "login_banner_enabled", Value: "True"}
+	assert.True(t, setting.ValueEqual("true"))
+	assert.False(t, setting.ValueEqual("false"))
+}
+
+func TestClient_GlobalSettings(t *testing.T) {
+	var updates []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		switch r.URL.Path {
+		case "/api/public/get_settings":
+			w.Write([]byte(`{"settings": [
+				{"setting_id": "s1", "name": "login_banner_enabled", "category": "login", "value": true, "value_type": "bool", "default_value": false},
+				{"setting_id": "s2", "name": "login_banner_text", "category": "login", "value": "Hello", "value_type": "string"}
+			]}`))
+		case "/api/public/update_setting":
+			updates = append(updates, req["target_setting"].(map[string]interface{}))
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+	ctx := context.Background()
+
+	setting, err := client.GetGlobalSetting(ctx, "login", "login_banner_enabled")
+	assert.NoError(t, err)
+	assert.Equal(t, SettingValue("True"), setting.Value)
+	if assert.NotNil(t, setting.DefaultValue) {
+		assert.Equal(t, SettingValue("False"), *setting.DefaultValue)
+	}
+
+	_, err = client.GetGlobalSetting(ctx, "auth", "login_banner_enabled")
+	assert.ErrorContains(t, err, "is in category login")
+
+	_, err = client.GetGlobalSetting(ctx, "login", "missing")
+	assert.True(t, IsNotFound(err))
+
+	updated, err := client.UpdateGlobalSetting(ctx, setting, "false")
+	assert.NoError(t, err)
+	assert.Equal(t, SettingValue("False"), updated.Value)
+
+	_, err = client.UpdateGlobalSetting(ctx, setting, "maybe")
+	assert.Error(t, err)
+
+	_, err = client.ResetGlobalSetting(ctx, setting)
+	assert.NoError(t, err)
+
+	text, err := client.GetGlobalSetting(ctx, "login", "login_banner_text")
+	assert.NoError(t, err)
+	assert.Nil(t, text.DefaultValue)
+	_, err = client.ResetGlobalSetting(ctx, text)
+	assert.True(t, errors.Is(err, ErrNoSettingDefault))
+
+	if assert.Len(t, updates, 2) {
+		assert.Equal(t, map[string]interface{}{"setting_id": "s1", "name": "login_banner_enabled", "value": "False"}, updates[0])
+		assert.Equal(t, "False", updates[1]["value"])
+	}
+}
@@ -0,0 +1,82 @@
+package client
+
+import "sort"
+
+// globalSettingInfo describes a global setting the provider knows about.
+type globalSettingInfo struct {
+	category  string
+	valueType string
+}
+
+// globalSettings maps the global settings the provider knows about to their
+// category and the type of their value. Other settings are checked against
+// the value type Kasm reports when they are changed.
+var globalSettings = map[string]globalSettingInfo{
+	"auth_token_lifetime":         {category: "auth", valueType: GroupSettingTypeInt},
+	"login_session_lifetime":      {category: "auth", valueType: GroupSettingTypeInt},
+	"add_images_to_default_group": {category: "images", valueType: GroupSettingTypeBool},
+	"default_image":               {category: "images", valueType: GroupSettingTypeString},
+	"login_banner_enabled":        {category: "login", valueType: GroupSettingTypeBool},
+	"login_banner_text":           {category: "login", valueType: GroupSettingTypeString},
+	"keepalive_expiration":        {category: "session", valueType: GroupSettingTypeInt},
+	"max_session_lifetime":        {category: "session", valueType: GroupSettingTypeInt},
+	"api_rate_limit":              {category: "api", valueType: GroupSettingTypeInt},
+	"api_rate_limit_window":       {category: "api", valueType: GroupSettingTypeInt},
+}
+
+// GlobalSettingCategory returns the category of a known global setting, and
+// whether the setting is known.
+func GlobalSettingCategory(name string) (string, bool) {
+	info, ok := globalSettings[name]
+	return info.category, ok
+}
+
+// KnownGlobalSettings returns the names of the global settings whose values
+// are type checked at plan time, sorted.
+func KnownGlobalSettings() []string {
+	names := make([]string, 0, len(globalSettings))
+	for name := range globalSettings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GlobalSetting is a server-wide Kasm setting. Global settings always
+// exist; they are changed but never created or removed.
+type GlobalSetting struct {
+	SettingID   string       `json:"setting_id,omitempty"`
+	Name        string       `json:"name"`
+	Category    string       `json:"category"`
+	Value       SettingValue `json:"value"`
+	ValueType   string       `json:"value_type,omitempty"`
+	Description string       `json:"description,omitempty"`
+	// DefaultValue is the value Kasm ships with, or nil when the server does
+	// not report it.
+	DefaultValue *SettingValue `json:"default_value,omitempty"`
+}
+
+// Type returns the value type of the setting: the type the provider knows
+// for it, or else the type Kasm reports.
+func (s *GlobalSetting) Type() string {
+	if info, ok := globalSettings[s.Name]; ok {
+		return info.valueType
+	}
+	return s.ValueType
+}
+
+// ValueEqual reports whether value is equivalent to the value of the
+// setting, such as "true" and "True" for a boolean.
+func (s *GlobalSetting) ValueEqual(value string) bool {
+	return settingValuesEqual(s.Type(), string(s.Value), value)
+}
+
+// ValidateGlobalSettingValue checks that value suits the type of the named
+// setting. Values of unknown settings are not checked.
+func ValidateGlobalSettingValue(name, value string) error {
+	info, ok := globalSettings[name]
+	if !ok {
+		return nil
+	}
+	return validateSettingValue(name, info.valueType, value)
+}
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 )
 
@@ -9,25 +11,69 @@ type Setting struct {
 	Value interface{} `json:"value"`
 }
 
-// SetSettingsGroup configures the settings group with the specified settings
-func (c *Client) SetSettingsGroup(settings []Setting) error {
+// SetSettingsGroup applies several settings to a group at once and returns
+// the settings of the group afterwards. Values of any type are converted to
+// strings, checked and formatted like AddGroupSetting does; settings is not
+// modified.
+func (c *Client) SetSettingsGroup(ctx context.Context, groupID string, settings []Setting) ([]GroupSetting, error) {
+	formatted := make([]Setting, len(settings))
+	for i, setting := range settings {
+		value, err := settingValueString(setting.Value)
+		if err != nil {
+			return nil, fmt.Errorf("setting %s: %w", setting.Name, err)
+		}
+		if err := ValidateGroupSettingValue(setting.Name, value); err != nil {
+			return nil, err
+		}
+		formatted[i] = Setting{Name: setting.Name, Value: FormatGroupSettingValue(setting.Name, value)}
+	}
+
 	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
-		"settings":       settings,
+		"target_group": map[string]string{
+			"group_id": groupID,
+		},
+		"settings": formatted,
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/set_settings_group", payload)
-	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+	var result struct {
+		Settings []GroupSetting `json:"settings"`
+	}
+	if err := c.post(ctx, "/api/public/set_settings_group", payload, "group", groupID, &result); err != nil {
+		return nil, err
+	}
+	if result.Settings == nil {
+		// Older servers do not echo the settings back.
+		return c.GetGroupSettings(ctx, groupID)
+	}
+
+	return result.Settings, nil
+}
+
+// settingValueString converts a setting value to the string form Kasm
+// stores: strings are used as is, booleans and numbers are printed, and
+// other values are encoded as JSON documents.
+func settingValueString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(v), nil
 	}
-	defer resp.Body.Close()
 
-	return nil
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("cannot convert %T to a setting value: %w", value, err)
+	}
+	return string(data), nil
 }
 
-// ConfigureDefaultSharingSettings sets up the default sharing configuration
-func (c *Client) ConfigureDefaultSharingSettings(groupID string) error {
+// ConfigureDefaultSharingSettings sets whether members of a group can share
+// their sessions
+func (c *Client) ConfigureDefaultSharingSettings(ctx context.Context, groupID string, allow bool) error {
 	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
@@ -36,15 +82,129 @@ func (c *Client) ConfigureDefaultSharingSettings(groupID string) error {
 		},
 		"target_setting": map[string]interface{}{
 			"name":  "allow_kasm_sharing",
-			"value": "True",
+			"value": formatSettingBool(allow),
+		},
+	}
+
+	return c.post(ctx, "/api/public/add_settings_group", payload, "group", groupID, nil)
+}
+
+// GetGroupSettings retrieves the settings applied to a group
+func (c *Client) GetGroupSettings(ctx context.Context, groupID string) ([]GroupSetting, error) {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_group": map[string]string{
+			"group_id": groupID,
 		},
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/add_settings_group", payload)
+	var result struct {
+		Settings []GroupSetting `json:"settings"`
+	}
+	if err := c.post(ctx, "/api/public/get_settings_group", payload, "group", groupID, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Settings, nil
+}
+
+// GetGroupSetting retrieves a setting of a group by name
+func (c *Client) GetGroupSetting(ctx context.Context, groupID, name string) (*GroupSetting, error) {
+	settings, err := c.GetGroupSettings(ctx, groupID)
 	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
+		return nil, fmt.Errorf("error getting group settings: %w", err)
+	}
+
+	for _, setting := range settings {
+		if setting.Name == name {
+			return &setting, nil
+		}
+	}
+
+	return nil, &NotFoundError{ResourceType: "group_setting", ID: groupID + ":" + name}
+}
+
+// AddGroupSetting applies a setting to a group
+func (c *Client) AddGroupSetting(ctx context.Context, groupID, name, value string) (*GroupSetting, error) {
+	if name == "" {
+		return nil, fmt.Errorf("group setting name is required")
+	}
+	if err := ValidateGroupSettingValue(name, value); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_group": map[string]string{
+			"group_id": groupID,
+		},
+		"target_setting": map[string]interface{}{
+			"name":  name,
+			"value": FormatGroupSettingValue(name, value),
+		},
+	}
+
+	var result struct {
+		Setting *GroupSetting `json:"setting"`
+	}
+	if err := c.post(ctx, "/api/public/add_settings_group", payload, "group_setting", groupID+":"+name, &result); err != nil {
+		return nil, err
+	}
+	if result.Setting == nil {
+		// The setting ID is needed to change or remove it later.
+		return c.GetGroupSetting(ctx, groupID, name)
+	}
+
+	return result.Setting, nil
+}
+
+// UpdateGroupSetting changes the value of a setting of a group
+func (c *Client) UpdateGroupSetting(ctx context.Context, groupID string, setting *GroupSetting) (*GroupSetting, error) {
+	value := string(setting.Value)
+	if err := ValidateGroupSettingValue(setting.Name, value); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_group": map[string]string{
+			"group_id": groupID,
+		},
+		"target_setting": map[string]interface{}{
+			"group_setting_id": setting.GroupSettingID,
+			"name":             setting.Name,
+			"value":            FormatGroupSettingValue(setting.Name, value),
+		},
+	}
+
+	var result struct {
+		Setting *GroupSetting `json:"setting"`
+	}
+	if err := c.post(ctx, "/api/public/update_settings_group", payload, "group_setting", setting.GroupSettingID, &result); err != nil {
+		return nil, err
+	}
+	if result.Setting == nil {
+		return setting, nil
+	}
+
+	return result.Setting, nil
+}
+
+// RemoveGroupSetting removes a setting from a group, restoring the default
+func (c *Client) RemoveGroupSetting(ctx context.Context, groupID, groupSettingID string) error {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_group": map[string]string{
+			"group_id": groupID,
+		},
+		"target_setting": map[string]string{
+			"group_setting_id": groupSettingID,
+		},
 	}
-	defer resp.Body.Close()
 
-	return nil
+	return c.post(ctx, "/api/public/remove_settings_group", payload, "group_setting", groupSettingID, nil)
 }
@@ -0,0 +1,159 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateGroupSettingValue(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setting       string
+		value         string
+		errorContains string
+	}{
+		{name: "Bool", setting: "allow_kasm_downloads", value: "true"},
+		{name: "Bool Kasm spelling", setting: "allow_kasm_downloads", value: "False"},
+		{name: "Int", setting: "max_kasms_per_user", value: "2"},
+		{name: "Float", setting: "idle_disconnect", value: "12.5"},
+		{name: "JSON", setting: "run_config", value: `{"hostname": "kasm"}`},
+		{name: "String", setting: "default_image", value: "anything"},
+		{name: "Unknown setting", setting: "future_setting", value: "anything"},
+		{name: "Bad bool", setting: "allow_kasm_audio", value: "yes", errorContains: "must be true or false"},
+		{name: "Bad int", setting: "keepalive_expiration", value: "1.5", errorContains: "must be an integer"},
+		{name: "Bad float", setting: "idle_disconnect", value: "soon", errorContains: "must be a number"},
+		{name: "Bad JSON", setting: "volume_mapping", value: "{", errorContains: "must be a JSON document"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateGroupSettingValue(tc.setting, tc.value)
+			if tc.errorContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.errorContains)
+			}
+		})
+	}
+}
+
+func TestGroupSettingValuesEqual(t *testing.T) {
+	assert.True(t, GroupSettingValuesEqual("allow_kasm_audio", "true", "True"))
+	assert.False(t, GroupSettingValuesEqual("allow_kasm_audio", "true", "False"))
+	assert.True(t, GroupSettingValuesEqual("idle_disconnect", "30", "30.0"))
+	assert.True(t, GroupSettingValuesEqual("run_config", `{"a": 1, "b": [2]}`, `{"b":[2],"a":1}`))
+	assert.False(t, GroupSettingValuesEqual("run_config", `{"a": 1}`, `{"a": 2}`))
+	assert.False(t, GroupSettingValuesEqual("future_setting", "true", "True"))
+}
+
+func TestFormatGroupSettingValue(t *testing.T) {
+	assert.Equal(t, "True", FormatGroupSettingValue("allow_kasm_sharing", "true"))
+	assert.Equal(t, "False", FormatGroupSettingValue("allow_kasm_sharing", "FALSE"))
+	assert.Equal(t, `{"a":1}`, FormatGroupSettingValue("run_config", `{ "a": 1 }`))
+	assert.Equal(t, "30", FormatGroupSettingValue("idle_disconnect", "30"))
+}
+
+func TestSettingValue_UnmarshalJSON(t *testing.T) {
+	var settings []GroupSetting
+	err := json.Unmarshal([]byte(`[
+		{"name": "a", "value": true},
+		{"name": "b", "value": 2.5},
+		{"name": "c", "value": "text"},
+		{"name": "d", "value": {"x": 1}},
+		{"name": "e", "value": null}
+	]`), &settings)
+	assert.NoError(t, err)
+	assert.Equal(t, SettingValue("True"), settings[0].Value)
+	assert.Equal(t, SettingValue("2.5"), settings[1].Value)
+	assert.Equal(t, SettingValue("text"), settings[2].Value)
+	assert.Equal(t, SettingValue(`{"x": 1}`), settings[3].Value)
+	assert.Equal(t, SettingValue(""), settings[4].Value)
+}
+
+func TestClient_GroupSettings(t *testing.T) {
+	requests := map[string]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+		requests[r.URL.Path] = req
+
+		switch r.URL.Path {
+		case "/api/public/add_settings_group":
+			// Kasm does not echo the setting; the client reads it back.
+			w.Write([]byte(`{}`))
+		case "/api/public/get_settings_group":
+			w.Write([]byte(`{"settings": [{"group_setting_id": "gs1", "group_id": "g1", "name": "allow_kasm_downloads", "value": "True", "value_type": "bool"}]}`))
+		case "/api/public/update_settings_group":
+			w.Write([]byte(`{"setting": {"group_setting_id": "gs1", "name": "allow_kasm_downloads", "value": false, "value_type": "bool"}}`))
+		case "/api/public/remove_settings_group":
+			w.Write([]byte(`{}`))
+		case "/api/public/set_settings_group":
+			w.Write([]byte(`{"settings": [{"group_setting_id": "gs2", "name": "max_kasms_per_user", "value": 3}]}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+	ctx := context.Background()
+
+	_, err := client.AddGroupSetting(ctx, "g1", "allow_kasm_downloads", "maybe")
+	assert.Error(t, err)
+
+	setting, err := client.AddGroupSetting(ctx, "g1", "allow_kasm_downloads", "true")
+	assert.NoError(t, err)
+	assert.Equal(t, "gs1", setting.GroupSettingID)
+	assert.Equal(t, map[string]interface{}{"name": "allow_kasm_downloads", "value": "True"},
+		requests["/api/public/add_settings_group"]["target_setting"])
+
+	setting.Value = "false"
+	updated, err := client.UpdateGroupSetting(ctx, "g1", setting)
+	assert.NoError(t, err)
+	assert.Equal(t, SettingValue("False"), updated.Value)
+	assert.Equal(t, "False", requests["/api/public/update_settings_group"]["target_setting"].(map[string]interface{})["value"])
+
+	_, err = client.GetGroupSetting(ctx, "g1", "idle_disconnect")
+	assert.True(t, IsNotFound(err))
+
+	assert.NoError(t, client.RemoveGroupSetting(ctx, "g1", "gs1"))
+	assert.Equal(t, map[string]interface{}{"group_setting_id": "gs1"}, requests["/api/public/remove_settings_group"]["target_setting"])
+
+	settings, err := client.SetSettingsGroup(ctx, "g1", []Setting{{Name: "max_kasms_per_user", Value: "3"}})
+	assert.NoError(t, err)
+	if assert.Len(t, settings, 1) {
+		assert.Equal(t, SettingValue("3"), settings[0].Value)
+	}
+	assert.Equal(t, map[string]interface{}{"group_id": "g1"}, requests["/api/public/set_settings_group"]["target_group"])
+
+	_, err = client.SetSettingsGroup(ctx, "g1", []Setting{{Name: "max_kasms_per_user", Value: "lots"}})
+	assert.Error(t, err)
+
+	// Non-string values are checked and formatted too, without modifying
+	// the caller's slice.
+	input := []Setting{{Name: "allow_kasm_downloads", Value: true}, {Name: "max_kasms_per_user", Value: 2}}
+	_, err = client.SetSettingsGroup(ctx, "g1", input)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "allow_kasm_downloads", "value": "True"},
+		map[string]interface{}{"name": "max_kasms_per_user", "value": "2"},
+	}, requests["/api/public/set_settings_group"]["settings"])
+	assert.Equal(t, []Setting{{Name: "allow_kasm_downloads", Value: true}, {Name: "max_kasms_per_user", Value: 2}}, input)
+
+	_, err = client.SetSettingsGroup(ctx, "g1", []Setting{{Name: "max_kasms_per_user", Value: 2.5}})
+	assert.Error(t, err)
+
+	assert.NoError(t, client.ConfigureDefaultSharingSettings(ctx, "g1", false))
+	assert.Equal(t, map[string]interface{}{"name": "allow_kasm_sharing", "value": "False"},
+		requests["/api/public/add_settings_group"]["target_setting"])
+}
@@ -0,0 +1,226 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Value types of group and global settings.
+const (
+	GroupSettingTypeBool   = "bool"
+	GroupSettingTypeInt    = "int"
+	GroupSettingTypeFloat  = "float"
+	GroupSettingTypeString = "string"
+	GroupSettingTypeJSON   = "json"
+)
+
+// groupSettingTypes maps the group settings the provider knows about to the
+// type of their value. Settings not listed are passed through as strings.
+var groupSettingTypes = map[string]string{
+	"allow_kasm_audio":               GroupSettingTypeBool,
+	"allow_kasm_audio_input":         GroupSettingTypeBool,
+	"allow_kasm_clipboard_down":      GroupSettingTypeBool,
+	"allow_kasm_clipboard_seamless":  GroupSettingTypeBool,
+	"allow_kasm_clipboard_up":        GroupSettingTypeBool,
+	"allow_kasm_delete":              GroupSettingTypeBool,
+	"allow_kasm_downloads":           GroupSettingTypeBool,
+	"allow_kasm_gamepad":             GroupSettingTypeBool,
+	"allow_kasm_microphone":          GroupSettingTypeBool,
+	"allow_kasm_printing":            GroupSettingTypeBool,
+	"allow_kasm_sharing":             GroupSettingTypeBool,
+	"allow_kasm_uploads":             GroupSettingTypeBool,
+	"allow_kasm_webcam":              GroupSettingTypeBool,
+	"allow_persistent_profile":       GroupSettingTypeBool,
+	"allow_point_of_presence":        GroupSettingTypeBool,
+	"allow_zone_selection":           GroupSettingTypeBool,
+	"allowed_zones":                  GroupSettingTypeJSON,
+	"auto_launch_default_image":      GroupSettingTypeBool,
+	"default_image":                  GroupSettingTypeString,
+	"default_images":                 GroupSettingTypeJSON,
+	"enable_ui_server_logs":          GroupSettingTypeBool,
+	"idle_disconnect":                GroupSettingTypeFloat,
+	"kasm_recording_enabled":         GroupSettingTypeBool,
+	"keepalive_expiration":           GroupSettingTypeInt,
+	"max_kasms_per_user":             GroupSettingTypeInt,
+	"persistent_profile_path":        GroupSettingTypeString,
+	"record_sessions":                GroupSettingTypeBool,
+	"require_subscription":           GroupSettingTypeBool,
+	"run_config":                     GroupSettingTypeJSON,
+	"session_recording_framerate":    GroupSettingTypeInt,
+	"session_recording_queue_length": GroupSettingTypeInt,
+	"session_recording_res_height":   GroupSettingTypeInt,
+	"session_recording_res_width":    GroupSettingTypeInt,
+	"session_time_limit":             GroupSettingTypeInt,
+	"usage_limit":                    GroupSettingTypeJSON,
+	"volume_mapping":                 GroupSettingTypeJSON,
+	"web_filter":                     GroupSettingTypeBool,
+	"web_filter_policy":              GroupSettingTypeString,
+}
+
+// GroupSettingType returns the value type of a known group setting, and
+// whether the setting is known.
+func GroupSettingType(name string) (string, bool) {
+	t, ok := groupSettingTypes[name]
+	return t, ok
+}
+
+// KnownGroupSettings returns the names of the group settings whose values
+// are type checked, sorted.
+func KnownGroupSettings() []string {
+	names := make([]string, 0, len(groupSettingTypes))
+	for name := range groupSettingTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SettingValue is the value of a group setting. Kasm stores values as
+// strings but may return booleans and numbers as JSON literals; they are
+// converted to the string form Kasm accepts.
+type SettingValue string
+
+// UnmarshalJSON accepts a string, boolean, number or JSON document.
+func (v *SettingValue) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*v = ""
+	case bytes.Equal(data, []byte("true")):
+		*v = "True"
+	case bytes.Equal(data, []byte("false")):
+		*v = "False"
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*v = SettingValue(s)
+	default:
+		*v = SettingValue(data)
+	}
+	return nil
+}
+
+// GroupSetting is a setting applied to a group.
+type GroupSetting struct {
+	GroupSettingID string       `json:"group_setting_id,omitempty"`
+	GroupID        string       `json:"group_id,omitempty"`
+	Name           string       `json:"name"`
+	Value          SettingValue `json:"value"`
+	ValueType      string       `json:"value_type,omitempty"`
+	Description    string       `json:"description,omitempty"`
+}
+
+// ValidateGroupSettingValue checks that value suits the type of the named
+// setting. Values of unknown settings are not checked.
+func ValidateGroupSettingValue(name, value string) error {
+	t, ok := groupSettingTypes[name]
+	if !ok {
+		return nil
+	}
+	return validateSettingValue(name, t, value)
+}
+
+// FormatGroupSettingValue converts a value to the form Kasm stores for the
+// named setting: booleans are sent as True or False and JSON documents are
+// compacted. Other values are returned unchanged.
+func FormatGroupSettingValue(name, value string) string {
+	return formatSettingValue(groupSettingTypes[name], value)
+}
+
+// GroupSettingValuesEqual reports whether two values of the named setting
+// are equivalent, such as "true" and "True" for a boolean, "30" and "30.0"
+// for a number, or two spellings of the same JSON document.
+func GroupSettingValuesEqual(name, a, b string) bool {
+	return settingValuesEqual(groupSettingTypes[name], a, b)
+}
+
+// validateSettingValue checks that value suits valueType, one of the
+// GroupSettingType constants. Values of other types are not checked.
+func validateSettingValue(name, valueType, value string) error {
+	switch valueType {
+	case GroupSettingTypeBool:
+		if _, err := parseSettingBool(value); err != nil {
+			return fmt.Errorf("setting %s must be true or false, got %q", name, value)
+		}
+	case GroupSettingTypeInt:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("setting %s must be an integer, got %q", name, value)
+		}
+	case GroupSettingTypeFloat:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("setting %s must be a number, got %q", name, value)
+		}
+	case GroupSettingTypeJSON:
+		if !json.Valid([]byte(value)) {
+			return fmt.Errorf("setting %s must be a JSON document", name)
+		}
+	}
+	return nil
+}
+
+// formatSettingValue converts a value to the form Kasm stores for
+// valueType.
+func formatSettingValue(valueType, value string) string {
+	switch valueType {
+	case GroupSettingTypeBool:
+		if b, err := parseSettingBool(value); err == nil {
+			return formatSettingBool(b)
+		}
+	case GroupSettingTypeJSON:
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, []byte(value)); err == nil {
+			return buf.String()
+		}
+	}
+	return value
+}
+
+// settingValuesEqual reports whether two values of valueType are
+// equivalent.
+func settingValuesEqual(valueType, a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	switch valueType {
+	case GroupSettingTypeBool:
+		x, errA := parseSettingBool(a)
+		y, errB := parseSettingBool(b)
+		return errA == nil && errB == nil && x == y
+	case GroupSettingTypeInt, GroupSettingTypeFloat:
+		x, errA := strconv.ParseFloat(a, 64)
+		y, errB := strconv.ParseFloat(b, 64)
+		return errA == nil && errB == nil && x == y
+	case GroupSettingTypeJSON:
+		var x, y interface{}
+		if json.Unmarshal([]byte(a), &x) != nil || json.Unmarshal([]byte(b), &y) != nil {
+			return false
+		}
+		return reflect.DeepEqual(x, y)
+	}
+	return false
+}
+
+func parseSettingBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid boolean %q", value)
+}
+
+func formatSettingBool(b bool) string {
+	if b {
+		return "True"
+	}
+	return "False"
+}
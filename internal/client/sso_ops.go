@@ -0,0 +1,254 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+// CreateSAMLConfig creates a new SAML identity provider configuration
+func (c *Client) CreateSAMLConfig(ctx context.Context, config *SAMLConfig) (*SAMLConfig, error) {
+	if err := validateSAMLConfig(config); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":            c.APIKey,
+		"api_key_secret":     c.APISecret,
+		"target_saml_config": config,
+	}
+
+	var result struct {
+		SAMLConfig *SAMLConfig `json:"saml_config"`
+	}
+	if err := c.post(ctx, "/api/public/create_saml_config", payload, "saml_config", config.Name, &result); err != nil {
+		return nil, err
+	}
+	if result.SAMLConfig == nil {
+		return nil, fmt.Errorf("create_saml_config returned no SAML configuration")
+	}
+
+	return result.SAMLConfig, nil
+}
+
+// GetSAMLConfig retrieves a SAML identity provider configuration by ID
+func (c *Client) GetSAMLConfig(ctx context.Context, samlID string) (*SAMLConfig, error) {
+	configs, err := c.GetSAMLConfigs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting SAML configurations: %w", err)
+	}
+
+	for _, config := range configs {
+		if config.SAMLID == samlID {
+			return &config, nil
+		}
+	}
+
+	return nil, &NotFoundError{ResourceType: "saml_config", ID: samlID}
+}
+
+// UpdateSAMLConfig updates an existing SAML identity provider configuration.
+// An empty SP private key leaves the stored key unchanged.
+func (c *Client) UpdateSAMLConfig(ctx context.Context, config *SAMLConfig) (*SAMLConfig, error) {
+	if err := validateSAMLConfig(config); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":            c.APIKey,
+		"api_key_secret":     c.APISecret,
+		"target_saml_config": config,
+	}
+
+	var result struct {
+		SAMLConfig *SAMLConfig `json:"saml_config"`
+	}
+	if err := c.post(ctx, "/api/public/update_saml_config", payload, "saml_config", config.SAMLID, &result); err != nil {
+		return nil, err
+	}
+	if result.SAMLConfig == nil {
+		return config, nil
+	}
+
+	return result.SAMLConfig, nil
+}
+
+// DeleteSAMLConfig deletes a SAML identity provider configuration by ID
+func (c *Client) DeleteSAMLConfig(ctx context.Context, samlID string) error {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_saml_config": map[string]string{
+			"saml_id": samlID,
+		},
+	}
+
+	return c.post(ctx, "/api/public/delete_saml_config", payload, "saml_config", samlID, nil)
+}
+
+// GetSAMLConfigs retrieves all SAML identity provider configurations
+func (c *Client) GetSAMLConfigs(ctx context.Context) ([]SAMLConfig, error) {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+	}
+
+	var result struct {
+		SAMLConfigs []SAMLConfig `json:"saml_configs"`
+	}
+	if err := c.post(ctx, "/api/public/get_saml_configs", payload, "saml_config", "", &result); err != nil {
+		return nil, err
+	}
+
+	return result.SAMLConfigs, nil
+}
+
+func validateSAMLConfig(config *SAMLConfig) error {
+	if config.Name == "" {
+		return fmt.Errorf("SAML configuration name is required")
+	}
+	if config.IdPEntityID == "" {
+		return fmt.Errorf("SAML idp_entity_id is required")
+	}
+	if !isHTTPURL(config.IdPSSOURL) {
+		return fmt.Errorf("SAML idp_sso_url must be an http:// or https:// URL, got %q", config.IdPSSOURL)
+	}
+	if config.IdPX509Cert == "" {
+		return fmt.Errorf("SAML idp_x509_cert is required")
+	}
+	if config.SPPrivateKey != "" && config.SPX509Cert == "" {
+		return fmt.Errorf("SAML sp_private_key requires sp_x509_cert")
+	}
+	if config.AuthnRequestsSigned && config.SPX509Cert == "" {
+		return fmt.Errorf("signing authentication requests requires sp_x509_cert and sp_private_key")
+	}
+	for field := range config.AttributeMappings {
+		if !slices.Contains(SAMLAttributeMappingFields, field) {
+			return fmt.Errorf("SAML attribute_mappings key must be one of %v, got %q", SAMLAttributeMappingFields, field)
+		}
+	}
+	return nil
+}
+
+// CreateOIDCConfig creates a new OpenID Connect provider configuration
+func (c *Client) CreateOIDCConfig(ctx context.Context, config *OIDCConfig) (*OIDCConfig, error) {
+	if err := validateOIDCConfig(config); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":            c.APIKey,
+		"api_key_secret":     c.APISecret,
+		"target_oidc_config": config,
+	}
+
+	var result struct {
+		OIDCConfig *OIDCConfig `json:"oidc_config"`
+	}
+	if err := c.post(ctx, "/api/public/create_oidc_config", payload, "oidc_config", config.Name, &result); err != nil {
+		return nil, err
+	}
+	if result.OIDCConfig == nil {
+		return nil, fmt.Errorf("create_oidc_config returned no OIDC configuration")
+	}
+
+	return result.OIDCConfig, nil
+}
+
+// GetOIDCConfig retrieves an OpenID Connect provider configuration by ID
+func (c *Client) GetOIDCConfig(ctx context.Context, oidcID string) (*OIDCConfig, error) {
+	configs, err := c.GetOIDCConfigs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting OIDC configurations: %w", err)
+	}
+
+	for _, config := range configs {
+		if config.OIDCID == oidcID {
+			return &config, nil
+		}
+	}
+
+	return nil, &NotFoundError{ResourceType: "oidc_config", ID: oidcID}
+}
+
+// UpdateOIDCConfig updates an existing OpenID Connect provider
+// configuration. An empty client secret leaves the stored secret unchanged.
+func (c *Client) UpdateOIDCConfig(ctx context.Context, config *OIDCConfig) (*OIDCConfig, error) {
+	if err := validateOIDCConfig(config); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":            c.APIKey,
+		"api_key_secret":     c.APISecret,
+		"target_oidc_config": config,
+	}
+
+	var result struct {
+		OIDCConfig *OIDCConfig `json:"oidc_config"`
+	}
+	if err := c.post(ctx, "/api/public/update_oidc_config", payload, "oidc_config", config.OIDCID, &result); err != nil {
+		return nil, err
+	}
+	if result.OIDCConfig == nil {
+		return config, nil
+	}
+
+	return result.OIDCConfig, nil
+}
+
+// DeleteOIDCConfig deletes an OpenID Connect provider configuration by ID
+func (c *Client) DeleteOIDCConfig(ctx context.Context, oidcID string) error {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_oidc_config": map[string]string{
+			"oidc_id": oidcID,
+		},
+	}
+
+	return c.post(ctx, "/api/public/delete_oidc_config", payload, "oidc_config", oidcID, nil)
+}
+
+// GetOIDCConfigs retrieves all OpenID Connect provider configurations
+func (c *Client) GetOIDCConfigs(ctx context.Context) ([]OIDCConfig, error) {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+	}
+
+	var result struct {
+		OIDCConfigs []OIDCConfig `json:"oidc_configs"`
+	}
+	if err := c.post(ctx, "/api/public/get_oidc_configs", payload, "oidc_config", "", &result); err != nil {
+		return nil, err
+	}
+
+	return result.OIDCConfigs, nil
+}
+
+func validateOIDCConfig(config *OIDCConfig) error {
+	if config.Name == "" {
+		return fmt.Errorf("OIDC configuration name is required")
+	}
+	if !isHTTPURL(config.Issuer) {
+		return fmt.Errorf("OIDC issuer must be an http:// or https:// URL, got %q", config.Issuer)
+	}
+	if config.ClientID == "" {
+		return fmt.Errorf("OIDC client_id is required")
+	}
+	if !slices.Contains(config.Scopes, "openid") {
+		return fmt.Errorf("OIDC scopes must include openid, got %v", config.Scopes)
+	}
+	if config.UsernameClaim == "" {
+		return fmt.Errorf("OIDC username_claim is required")
+	}
+	return nil
+}
+
+// isHTTPURL reports whether s is an absolute http or https URL with a host.
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
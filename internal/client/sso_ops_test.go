@@ -0,0 +1,215 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSAMLConfig(t *testing.T) {
+	valid := func() SAMLConfig {
+		return SAMLConfig{
+			Name:        "Okta",
+			IdPEntityID: "http://www.okta.com/exk1",
+			IdPSSOURL:   "https://example.okta.com/app/sso/saml",
+			IdPX509Cert: "MIIB",
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		modify        func(*SAMLConfig)
+		errorContains string
+	}{
+		{name: "Valid", modify: func(*SAMLConfig) {}},
+		{name: "Mappings", modify: func(c *SAMLConfig) { c.AttributeMappings = map[string]string{"email": "mail"} }},
+		{name: "Missing name", modify: func(c *SAMLConfig) { c.Name = "" }, errorContains: "name is required"},
+		{name: "Missing entity ID", modify: func(c *SAMLConfig) { c.IdPEntityID = "" }, errorContains: "idp_entity_id is required"},
+		{name: "Bad SSO URL", modify: func(c *SAMLConfig) { c.IdPSSOURL = "example.okta.com" }, errorContains: "idp_sso_url must be"},
+		{name: "Missing certificate", modify: func(c *SAMLConfig) { c.IdPX509Cert = "" }, errorContains: "idp_x509_cert is required"},
+		{name: "Key without certificate", modify: func(c *SAMLConfig) { c.SPPrivateKey = "key" }, errorContains: "requires sp_x509_cert"},
+		{name: "Signed without certificate", modify: func(c *SAMLConfig) { c.AuthnRequestsSigned = true }, errorContains: "requires sp_x509_cert"},
+		{
+			name:          "Unknown mapping",
+			modify:        func(c *SAMLConfig) { c.AttributeMappings = map[string]string{"shoe_size": "size"} },
+			errorContains: "attribute_mappings key must be one of",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := valid()
+			tc.modify(&config)
+			err := validateSAMLConfig(&config)
+			if tc.errorContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.errorContains)
+			}
+		})
+	}
+}
+
+func TestValidateOIDCConfig(t *testing.T) {
+	valid := func() OIDCConfig {
+		return OIDCConfig{
+			Name:          "Keycloak",
+			Issuer:        "https://sso.example.com/realms/corp",
+			ClientID:      "kasm",
+			Scopes:        []string{"openid", "email"},
+			UsernameClaim: "email",
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		modify        func(*OIDCConfig)
+		errorContains string
+	}{
+		{name: "Valid", modify: func(*OIDCConfig) {}},
+		{name: "Missing name", modify: func(c *OIDCConfig) { c.Name = "" }, errorContains: "name is required"},
+		{name: "Bad issuer", modify: func(c *OIDCConfig) { c.Issuer = "sso.example.com" }, errorContains: "issuer must be"},
+		{name: "Missing client ID", modify: func(c *OIDCConfig) { c.ClientID = "" }, errorContains: "client_id is required"},
+		{name: "Missing openid", modify: func(c *OIDCConfig) { c.Scopes = []string{"email"} }, errorContains: "must include openid"},
+		{name: "Missing username claim", modify: func(c *OIDCConfig) { c.UsernameClaim = "" }, errorContains: "username_claim is required"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := valid()
+			tc.modify(&config)
+			err := validateOIDCConfig(&config)
+			if tc.errorContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.errorContains)
+			}
+		})
+	}
+}
+
+func TestClient_SAMLConfigCRUD(t *testing.T) {
+	var created map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		switch r.URL.Path {
+		case "/api/public/create_saml_config":
+			created = req["target_saml_config"].(map[string]interface{})
+			w.Write([]byte(`{"saml_config": {"saml_id": "s1", "display_name": "Okta", "sp_acs_url": "https://kasm.example.com/api/sso_callback/s1"}}`))
+		case "/api/public/update_saml_config":
+			assert.Equal(t, "s1", req["target_saml_config"].(map[string]interface{})["saml_id"])
+			w.Write([]byte(`{}`))
+		case "/api/public/get_saml_configs":
+			w.Write([]byte(`{"saml_configs": [{"saml_id": "s1", "display_name": "Okta", "attribute_mappings": {"email": "mail"}}]}`))
+		case "/api/public/delete_saml_config":
+			assert.Equal(t, map[string]interface{}{"saml_id": "s1"}, req["target_saml_config"])
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+	ctx := context.Background()
+
+	config, err := client.CreateSAMLConfig(ctx, &SAMLConfig{
+		Name:              "Okta",
+		IdPEntityID:       "http://www.okta.com/exk1",
+		IdPSSOURL:         "https://example.okta.com/app/sso/saml",
+		IdPX509Cert:       "MIIB",
+		AttributeMappings: map[string]string{"email": "mail"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "s1", config.SAMLID)
+	assert.Equal(t, "https://kasm.example.com/api/sso_callback/s1", config.SPACSURL)
+	assert.NotContains(t, created, "saml_id")
+	assert.NotContains(t, created, "sp_private_key")
+	assert.Equal(t, map[string]interface{}{"email": "mail"}, created["attribute_mappings"])
+
+	config.IdPEntityID = "http://www.okta.com/exk1"
+	config.IdPSSOURL = "https://example.okta.com/app/sso/saml"
+	config.IdPX509Cert = "MIIB"
+	_, err = client.UpdateSAMLConfig(ctx, config)
+	assert.NoError(t, err)
+
+	got, err := client.GetSAMLConfig(ctx, "s1")
+	assert.NoError(t, err)
+	assert.Equal(t, "mail", got.AttributeMappings["email"])
+
+	_, err = client.GetSAMLConfig(ctx, "missing")
+	assert.True(t, IsNotFound(err))
+
+	assert.NoError(t, client.DeleteSAMLConfig(ctx, "s1"))
+}
+
+func TestClient_OIDCConfigCRUD(t *testing.T) {
+	var created map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		switch r.URL.Path {
+		case "/api/public/create_oidc_config":
+			created = req["target_oidc_config"].(map[string]interface{})
+			w.Write([]byte(`{"oidc_config": {"oidc_id": "o1", "display_name": "Keycloak", "redirect_url": "https://kasm.example.com/api/oidc_callback"}}`))
+		case "/api/public/update_oidc_config":
+			assert.Equal(t, "o1", req["target_oidc_config"].(map[string]interface{})["oidc_id"])
+			w.Write([]byte(`{}`))
+		case "/api/public/get_oidc_configs":
+			w.Write([]byte(`{"oidc_configs": [{"oidc_id": "o1", "display_name": "Keycloak", "scope": ["openid", "email"]}]}`))
+		case "/api/public/delete_oidc_config":
+			assert.Equal(t, map[string]interface{}{"oidc_id": "o1"}, req["target_oidc_config"])
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+	ctx := context.Background()
+
+	input := &OIDCConfig{
+		Name:          "Keycloak",
+		Issuer:        "https://sso.example.com/realms/corp",
+		ClientID:      "kasm",
+		ClientSecret:  "s3cret",
+		Scopes:        []string{"openid", "email"},
+		UsernameClaim: "preferred_username",
+		GroupsClaim:   "groups",
+	}
+	config, err := client.CreateOIDCConfig(ctx, input)
+	assert.NoError(t, err)
+	assert.Equal(t, "o1", config.OIDCID)
+	assert.Equal(t, "https://kasm.example.com/api/oidc_callback", config.RedirectURL)
+	assert.Equal(t, []interface{}{"openid", "email"}, created["scope"])
+	assert.Equal(t, "preferred_username", created["username_attribute"])
+	assert.Equal(t, "groups", created["groups_attribute"])
+
+	input.OIDCID = "o1"
+	_, err = client.UpdateOIDCConfig(ctx, input)
+	assert.NoError(t, err)
+
+	got, err := client.GetOIDCConfig(ctx, "o1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"openid", "email"}, got.Scopes)
+
+	_, err = client.GetOIDCConfig(ctx, "missing")
+	assert.True(t, IsNotFound(err))
+
+	assert.NoError(t, client.DeleteOIDCConfig(ctx, "o1"))
+}
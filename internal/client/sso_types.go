@@ -0,0 +1,61 @@
+package client
+
+// SAMLAttributeMappingFields lists the Kasm user fields a SAML attribute can
+// be mapped to.
+var SAMLAttributeMappingFields = []string{
+	"email",
+	"first_name",
+	"last_name",
+	"phone",
+	"organization",
+}
+
+// SAMLConfig is a SAML 2.0 identity provider Kasm users can log in through.
+type SAMLConfig struct {
+	SAMLID  string `json:"saml_id,omitempty"`
+	Name    string `json:"display_name"`
+	Enabled bool   `json:"enabled"`
+	// AutoLogin sends users straight to the identity provider instead of
+	// showing the Kasm login page.
+	AutoLogin bool `json:"auto_login"`
+
+	IdPEntityID string `json:"idp_entity_id"`
+	IdPSSOURL   string `json:"idp_sso_url"`
+	IdPSLOURL   string `json:"idp_slo_url,omitempty"`
+	IdPX509Cert string `json:"idp_x509_cert"`
+
+	SPEntityID   string `json:"sp_entity_id,omitempty"`
+	SPACSURL     string `json:"sp_acs_url,omitempty"`
+	SPSLOURL     string `json:"sp_slo_url,omitempty"`
+	SPX509Cert   string `json:"sp_x509_cert,omitempty"`
+	SPPrivateKey string `json:"sp_private_key,omitempty"`
+
+	WantAssertionsSigned bool `json:"want_assertions_signed"`
+	AuthnRequestsSigned  bool `json:"authn_requests_signed"`
+
+	// UsernameAttribute is the assertion attribute used as the Kasm
+	// username; the NameID is used when it is empty.
+	UsernameAttribute string `json:"username_attribute,omitempty"`
+	GroupsAttribute   string `json:"groups_attribute,omitempty"`
+	// AttributeMappings maps Kasm user fields to assertion attributes.
+	AttributeMappings map[string]string `json:"attribute_mappings,omitempty"`
+}
+
+// OIDCConfig is an OpenID Connect provider Kasm users can log in through.
+type OIDCConfig struct {
+	OIDCID    string `json:"oidc_id,omitempty"`
+	Name      string `json:"display_name"`
+	Enabled   bool   `json:"enabled"`
+	AutoLogin bool   `json:"auto_login"`
+	// Issuer is the base URL the provider's discovery document is served
+	// under.
+	Issuer       string   `json:"issuer"`
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret,omitempty"`
+	Scopes       []string `json:"scope"`
+	// UsernameClaim is the ID token claim used as the Kasm username.
+	UsernameClaim string `json:"username_attribute"`
+	GroupsClaim   string `json:"groups_attribute,omitempty"`
+	// RedirectURL is the callback Kasm registers with the provider.
+	RedirectURL string `json:"redirect_url,omitempty"`
+}
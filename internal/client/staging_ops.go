@@ -1,43 +1,27 @@
 package client
 
 import (
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
+	"context"
 )
 
 // GetStagingConfigs retrieves all staging configurations
-func (c *Client) GetStagingConfigs() ([]StagingConfig, error) {
+func (c *Client) GetStagingConfigs(ctx context.Context) ([]StagingConfig, error) {
 	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/get_staging_configs", payload)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %v", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(body))
-	}
 	var result struct {
 		StagingConfigs []StagingConfig `json:"staging_configs"`
 	}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v, body: %s", err, string(body))
+	if err := c.post(ctx, "/api/public/get_staging_configs", payload, "staging_config", "", &result); err != nil {
+		return nil, err
 	}
 	return result.StagingConfigs, nil
 }
 
 // GetStagingConfig retrieves a specific staging configuration
-func (c *Client) GetStagingConfig(stagingConfigID string) (*StagingConfig, error) {
+func (c *Client) GetStagingConfig(ctx context.Context, stagingConfigID string) (*StagingConfig, error) {
 	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
@@ -46,72 +30,57 @@ func (c *Client) GetStagingConfig(stagingConfigID string) (*StagingConfig, error
 		},
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/get_staging_config", payload)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
 	var result struct {
 		StagingConfig *StagingConfig `json:"staging_config"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/get_staging_config", payload, "staging_config", stagingConfigID, &result); err != nil {
+		return nil, err
+	}
+	if result.StagingConfig == nil {
+		return nil, &NotFoundError{ResourceType: "staging_config", ID: stagingConfigID}
 	}
 
 	return result.StagingConfig, nil
 }
 
 // CreateStagingConfig creates a new staging configuration
-func (c *Client) CreateStagingConfig(request *CreateStagingConfigRequest) (*StagingConfig, error) {
+func (c *Client) CreateStagingConfig(ctx context.Context, request *CreateStagingConfigRequest) (*StagingConfig, error) {
 	payload := map[string]interface{}{
 		"api_key":               c.APIKey,
 		"api_key_secret":        c.APISecret,
 		"target_staging_config": request,
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/create_staging_config", payload)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
 	var result struct {
 		StagingConfig *StagingConfig `json:"staging_config"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/create_staging_config", payload, "staging_config", "", &result); err != nil {
+		return nil, err
 	}
 
 	return result.StagingConfig, nil
 }
 
 // UpdateStagingConfig updates an existing staging configuration
-func (c *Client) UpdateStagingConfig(request *UpdateStagingConfigRequest) (*StagingConfig, error) {
+func (c *Client) UpdateStagingConfig(ctx context.Context, request *UpdateStagingConfigRequest) (*StagingConfig, error) {
 	payload := map[string]interface{}{
 		"api_key":               c.APIKey,
 		"api_key_secret":        c.APISecret,
 		"target_staging_config": request,
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/update_staging_config", payload)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
 	var result struct {
 		StagingConfig *StagingConfig `json:"staging_config"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/update_staging_config", payload, "staging_config", request.StagingConfigID, &result); err != nil {
+		return nil, err
 	}
 
 	return result.StagingConfig, nil
 }
 
 // DeleteStagingConfig deletes a staging configuration
-func (c *Client) DeleteStagingConfig(stagingConfigID string) error {
+func (c *Client) DeleteStagingConfig(ctx context.Context, stagingConfigID string) error {
 	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
@@ -120,16 +89,5 @@ func (c *Client) DeleteStagingConfig(stagingConfigID string) error {
 		},
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/delete_staging_config", payload)
-	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete staging config, status: %d, response: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	return nil
+	return c.post(ctx, "/api/public/delete_staging_config", payload, "staging_config", stagingConfigID, nil)
 }
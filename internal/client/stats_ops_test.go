@@ -4,6 +4,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -51,7 +52,7 @@ func TestClient_GetFrameStats(t *testing.T) {
 			statusCode:     http.StatusInternalServerError,
 			serverResponse: map[string]interface{}{"error": "internal server error"},
 			expectError:    true,
-			errorContains:  "API request failed",
+			errorContains:  "API error (status 500)",
 		},
 		{
 			name:           "invalid response",
@@ -121,14 +122,9 @@ func TestClient_GetFrameStats(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client := &Client{
-				BaseURL:    server.URL,
-				APIKey:     "test-api-key",
-				APISecret:  "test-api-secret",
-				HTTPClient: server.Client(),
-			}
+			client := NewClient(server.URL, "test-api-key", "test-api-secret", false)
 
-			response, err := client.GetFrameStats(tc.kasmID, tc.userID)
+			response, err := client.GetFrameStats(context.Background(), tc.kasmID, tc.userID)
 			if tc.expectError {
 				assert.Error(t, err)
 				assert.Nil(t, response)
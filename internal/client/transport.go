@@ -0,0 +1,77 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// CertPoolFromPEM returns the system certificate pool extended with the
+// certificates in pemData. It fails if pemData contains no certificates.
+func CertPoolFromPEM(pemData []byte) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pemData) {
+		return nil, fmt.Errorf("no PEM encoded certificates found")
+	}
+	return pool, nil
+}
+
+// WithRootCAs makes the client trust the certificate authorities in pool when
+// verifying the Kasm server.
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return func(c *Client) {
+		if tr := c.transport(); tr != nil {
+			tr.TLSClientConfig.RootCAs = pool
+		}
+	}
+}
+
+// WithClientCertificate presents cert to servers that request client
+// certificate authentication (mutual TLS).
+func WithClientCertificate(cert tls.Certificate) ClientOption {
+	return func(c *Client) {
+		if tr := c.transport(); tr != nil {
+			tr.TLSClientConfig.Certificates = []tls.Certificate{cert}
+		}
+	}
+}
+
+// WithProxy sends requests through proxyURL, except for hosts matched by
+// noProxy. noProxy uses the same comma-separated syntax as the NO_PROXY
+// environment variable.
+func WithProxy(proxyURL *url.URL, noProxy string) ClientOption {
+	return func(c *Client) {
+		tr := c.transport()
+		if tr == nil {
+			return
+		}
+		proxy := (&httpproxy.Config{
+			HTTPProxy:  proxyURL.String(),
+			HTTPSProxy: proxyURL.String(),
+			NoProxy:    noProxy,
+		}).ProxyFunc()
+		tr.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxy(req.URL)
+		}
+	}
+}
+
+// transport returns the client's base transport, or nil when it has been
+// replaced with a transport that is not an *http.Transport.
+func (c *Client) transport() *http.Transport {
+	tr, ok := c.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		return nil
+	}
+	if tr.TLSClientConfig == nil {
+		tr.TLSClientConfig = &tls.Config{}
+	}
+	return tr
+}
@@ -0,0 +1,149 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTransportTestClient(serverURL string, options ...ClientOption) *Client {
+	options = append([]ClientOption{WithMaxRetries(0)}, options...)
+	return NewClient(serverURL, "test-key", "test-secret", false, options...)
+}
+
+func getUsers(c *Client) error {
+	return c.post(context.Background(), "/api/public/get_users", map[string]interface{}{}, "user", "", nil)
+}
+
+func TestWithRootCAs(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	t.Run("Untrusted server is rejected", func(t *testing.T) {
+		err := getUsers(newTransportTestClient(server.URL))
+		assert.Error(t, err)
+	})
+
+	t.Run("Server signed by configured CA is trusted", func(t *testing.T) {
+		pool, err := CertPoolFromPEM(caPEM)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		assert.NoError(t, getUsers(newTransportTestClient(server.URL, WithRootCAs(pool))))
+	})
+}
+
+func TestCertPoolFromPEM_Invalid(t *testing.T) {
+	_, err := CertPoolFromPEM([]byte("not a certificate"))
+	assert.Error(t, err)
+}
+
+func TestWithClientCertificate(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	t.Run("Missing client certificate is rejected", func(t *testing.T) {
+		err := getUsers(newTransportTestClient(server.URL, WithRootCAs(pool)))
+		assert.Error(t, err)
+	})
+
+	t.Run("Client certificate is presented", func(t *testing.T) {
+		cert := newTestClientCertificate(t)
+		err := getUsers(newTransportTestClient(server.URL, WithRootCAs(pool), WithClientCertificate(cert)))
+		assert.NoError(t, err)
+	})
+}
+
+func TestWithProxy(t *testing.T) {
+	var proxied int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&proxied, 1)
+		if r.URL.Host != "kasm.example" {
+			t.Errorf("Expected proxied request for kasm.example, got %q", r.URL.Host)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	t.Run("Requests go through the proxy", func(t *testing.T) {
+		atomic.StoreInt32(&proxied, 0)
+		err := getUsers(newTransportTestClient("http://kasm.example", WithProxy(proxyURL, "")))
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&proxied))
+	})
+
+	t.Run("Hosts in no_proxy bypass the proxy", func(t *testing.T) {
+		atomic.StoreInt32(&proxied, 0)
+		client := newTransportTestClient("http://kasm.example", WithProxy(proxyURL, "other.example,.example"),
+			WithRequestTimeout(2*time.Second))
+		_ = getUsers(client)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&proxied))
+	})
+}
+
+func newTestClientCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	cert, err := tls.X509KeyPair(
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to load key pair: %v", err)
+	}
+	return cert
+}
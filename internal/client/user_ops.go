@@ -1,17 +1,13 @@
 package client
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
-	"strings"
 	"time"
 )
 
-func (c *Client) CreateUser(user *User) (*User, error) {
-	body, err := json.Marshal(map[string]interface{}{
+func (c *Client) CreateUser(ctx context.Context, user *User) (*User, error) {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_user": map[string]interface{}{
@@ -25,76 +21,42 @@ func (c *Client) CreateUser(user *User) (*User, error) {
 			"phone":             user.Phone,
 			"authorized_images": user.AuthorizedImages,
 		},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling request body: %v", err)
-	}
-
-	resp, err := c.HTTPClient.Post(
-		c.BaseURL+"/api/public/create_user",
-		"application/json",
-		bytes.NewBuffer(body),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status code: %d, response: %s",
-			resp.StatusCode, string(bodyBytes))
 	}
 
 	var result struct {
-		User         User   `json:"user"`
-		ErrorMessage string `json:"error_message"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+		User User `json:"user"`
 	}
-
-	if result.ErrorMessage != "" {
-		return nil, fmt.Errorf("API returned error: %s", result.ErrorMessage)
+	if err := c.post(ctx, "/api/public/create_user", payload, "user", user.Username, &result); err != nil {
+		return nil, err
 	}
 
 	return &result.User, nil
 }
 
-func (c *Client) GetUser(userID string) (*User, error) {
-	body, err := json.Marshal(map[string]interface{}{
+func (c *Client) GetUser(ctx context.Context, userID string) (*User, error) {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_user": map[string]string{
 			"user_id": userID,
 		},
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/get_user", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
 	}
 
 	var result struct {
-		User User `json:"user"`
+		User *User `json:"user"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := c.post(ctx, "/api/public/get_user", payload, "user", userID, &result); err != nil {
 		return nil, err
 	}
+	if result.User == nil || result.User.UserID == "" {
+		return nil, &NotFoundError{ResourceType: "user", ID: userID}
+	}
 
-	return &result.User, nil
+	return result.User, nil
 }
 
-func (c *Client) UpdateUser(user *User) (*User, error) {
-	body, err := json.Marshal(map[string]interface{}{
+func (c *Client) UpdateUser(ctx context.Context, user *User) (*User, error) {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_user": map[string]interface{}{
@@ -109,118 +71,63 @@ func (c *Client) UpdateUser(user *User) (*User, error) {
 			"phone":             user.Phone,
 			"authorized_images": user.AuthorizedImages,
 		},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling request body: %v", err)
-	}
-
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/update_user", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
 	}
 
 	var result struct {
 		User User `json:"user"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/update_user", payload, "user", user.UserID, &result); err != nil {
+		return nil, err
 	}
 
 	return &result.User, nil
 }
 
-func (c *Client) DeleteUser(userID string) error {
-	body, err := json.Marshal(map[string]interface{}{
+func (c *Client) DeleteUser(ctx context.Context, userID string) error {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_user": map[string]interface{}{
 			"user_id": userID,
 		},
 		"force": true,
-	})
-	if err != nil {
-		return err
-	}
-
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/delete_user", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
-	}
-
-	return nil
+	return c.post(ctx, "/api/public/delete_user", payload, "user", userID, nil)
 }
 
-func (c *Client) LogoutUser(userID string) error {
-	body, err := json.Marshal(map[string]interface{}{
+func (c *Client) LogoutUser(ctx context.Context, userID string) error {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_user": map[string]string{
 			"user_id": userID,
 		},
-	})
-	if err != nil {
-		return fmt.Errorf("error marshaling request body: %v", err)
 	}
 
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/logout_user", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	return nil
+	return c.post(ctx, "/api/public/logout_user", payload, "user", userID, nil)
 }
 
-func (c *Client) GetUserAttributes(userID string) (*UserAttributes, error) {
-	body, err := json.Marshal(map[string]interface{}{
+func (c *Client) GetUserAttributes(ctx context.Context, userID string) (*UserAttributes, error) {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_user": map[string]string{
 			"user_id": userID,
 		},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling request body: %v", err)
-	}
-
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/get_attributes", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	bodyBytes, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
 	}
 
 	var result struct {
 		UserAttributes UserAttributes `json:"user_attributes"`
 	}
-	if err := json.NewDecoder(bytes.NewReader(bodyBytes)).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/get_attributes", payload, "user", userID, &result); err != nil {
+		return nil, err
 	}
 
 	return &result.UserAttributes, nil
 }
 
-func (c *Client) UpdateUserAttributes(userID string, attributes map[string]interface{}) error {
+func (c *Client) UpdateUserAttributes(ctx context.Context, userID string, attributes map[string]interface{}) error {
 	userAttrs := UserAttributes{
 		UserID: userID,
 	}
@@ -237,31 +144,23 @@ func (c *Client) UpdateUserAttributes(userID string, attributes map[string]inter
 		}
 	}
 
-	body, err := json.Marshal(map[string]interface{}{
+	payload := map[string]interface{}{
 		"api_key":                c.APIKey,
 		"api_key_secret":         c.APISecret,
 		"target_user_attributes": userAttrs,
-	})
-	if err != nil {
-		return fmt.Errorf("error marshaling request body: %v", err)
 	}
 
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/update_user_attributes", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
-	}
+	return c.post(ctx, "/api/public/update_user_attributes", payload, "user", userID, nil)
+}
 
-	return nil
+// GetUsers retrieves all users. The result is cached; see NoCache.
+func (c *Client) GetUsers(ctx context.Context) ([]User, error) {
+	return cachedList(ctx, c, cacheKey{collection: collectionUsers}, c.getUsers)
 }
 
-func (c *Client) GetUsers() ([]User, error) {
-	body, err := json.Marshal(map[string]interface{}{
+// getUsers fetches all users, bypassing the cache
+func (c *Client) getUsers(ctx context.Context) ([]User, error) {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"page":           0,
@@ -270,20 +169,6 @@ func (c *Client) GetUsers() ([]User, error) {
 		"sort_direction": "asc",
 		"anonymous":      false,
 		"anonymous_only": false,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling request body: %v", err)
-	}
-
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/get_users", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
 	}
 
 	var result struct {
@@ -291,22 +176,22 @@ func (c *Client) GetUsers() ([]User, error) {
 		Total int    `json:"total"`
 		Page  int    `json:"page"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/get_users", payload, "user", "", &result); err != nil {
+		return nil, err
 	}
 
 	return result.Users, nil
 }
 
 // waitForUserGroups waits for the user's group memberships to be fully processed
-func (c *Client) waitForUserGroups(userID string, expectedGroups []string) error {
+func (c *Client) waitForUserGroups(ctx context.Context, userID string, expectedGroups []string) error {
 	maxAttempts := 10
 	delay := 2 * time.Second
 
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
-		user, err := c.GetUser(userID)
+		user, err := c.GetUser(ctx, userID)
 		if err != nil {
-			return fmt.Errorf("error getting user: %v", err)
+			return fmt.Errorf("error getting user: %w", err)
 		}
 
 		// Create a map of expected group names for easier lookup
@@ -328,17 +213,19 @@ func (c *Client) waitForUserGroups(userID string, expectedGroups []string) error
 		}
 
 		if attempt < maxAttempts {
-			time.Sleep(delay)
+			if err := SleepContext(ctx, delay); err != nil {
+				return err
+			}
 		}
 	}
 
 	return fmt.Errorf("timeout waiting for user groups to be processed")
 }
 
-func (c *Client) UpdateUserGroupsByName(userID string, groupNames []string) error {
-	groups, err := c.GetGroups()
+func (c *Client) UpdateUserGroupsByName(ctx context.Context, userID string, groupNames []string) error {
+	groups, err := c.GetGroups(ctx)
 	if err != nil {
-		return fmt.Errorf("error getting groups: %v", err)
+		return fmt.Errorf("error getting groups: %w", err)
 	}
 
 	// Create a map of group names to IDs
@@ -348,9 +235,9 @@ func (c *Client) UpdateUserGroupsByName(userID string, groupNames []string) erro
 	}
 
 	// Get current user's groups
-	currentUser, err := c.GetUser(userID)
+	currentUser, err := c.GetUser(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("error getting user: %v", err)
+		return fmt.Errorf("error getting user: %w", err)
 	}
 
 	// Create map of current group IDs
@@ -364,7 +251,7 @@ func (c *Client) UpdateUserGroupsByName(userID string, groupNames []string) erro
 	for _, name := range groupNames {
 		id, ok := groupMap[name]
 		if !ok {
-			return fmt.Errorf("group not found: %s", name)
+			return &NotFoundError{ResourceType: "group", ID: name}
 		}
 		desiredGroupIDs[id] = true
 	}
@@ -372,9 +259,9 @@ func (c *Client) UpdateUserGroupsByName(userID string, groupNames []string) erro
 	// Remove user from groups they shouldn't be in
 	for groupID := range currentGroupIDs {
 		if !desiredGroupIDs[groupID] {
-			err := c.RemoveUserFromGroup(userID, groupID)
+			err := c.RemoveUserFromGroup(ctx, userID, groupID)
 			if err != nil {
-				return fmt.Errorf("error removing user from group: %v", err)
+				return fmt.Errorf("error removing user from group: %w", err)
 			}
 		}
 	}
@@ -382,9 +269,9 @@ func (c *Client) UpdateUserGroupsByName(userID string, groupNames []string) erro
 	// Add user to new groups
 	for groupID := range desiredGroupIDs {
 		if !currentGroupIDs[groupID] {
-			err := c.AddUserToGroup(userID, groupID)
+			err := c.AddUserToGroup(ctx, userID, groupID)
 			if err != nil {
-				return fmt.Errorf("error adding user to group: %v", err)
+				return fmt.Errorf("error adding user to group: %w", err)
 			}
 		}
 	}
@@ -392,51 +279,37 @@ func (c *Client) UpdateUserGroupsByName(userID string, groupNames []string) erro
 	return nil
 }
 
-func (c *Client) GetUserAuthorizedImages(userID string) ([]string, error) {
-	body, err := json.Marshal(map[string]interface{}{
+func (c *Client) GetUserAuthorizedImages(ctx context.Context, userID string) ([]string, error) {
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_user": map[string]interface{}{
 			"user_id": userID,
 		},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling request body: %v", err)
-	}
-
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/get_user", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
 	}
 
 	var result struct {
 		User User `json:"user"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v", err)
+	if err := c.post(ctx, "/api/public/get_user", payload, "user", userID, &result); err != nil {
+		return nil, err
 	}
 
 	return result.User.AuthorizedImages, nil
 }
 
-func (c *Client) UpdateUserAuthorizedImages(userID string, imageIDs []string) error {
+func (c *Client) UpdateUserAuthorizedImages(ctx context.Context, userID string, imageIDs []string) error {
 	// First get the current user to preserve all fields
-	currentUser, err := c.GetUser(userID)
+	currentUser, err := c.GetUser(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("error getting user: %v", err)
+		return fmt.Errorf("error getting user: %w", err)
 	}
 
 	// Update only the authorized images field
 	currentUser.AuthorizedImages = imageIDs
 
 	// Use the update_user endpoint with all fields
-	body, err := json.Marshal(map[string]interface{}{
+	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"target_user": map[string]interface{}{
@@ -450,29 +323,12 @@ func (c *Client) UpdateUserAuthorizedImages(userID string, imageIDs []string) er
 			"disabled":          currentUser.Disabled,
 			"authorized_images": imageIDs,
 		},
-	})
-	if err != nil {
-		return fmt.Errorf("error marshaling request body: %v", err)
-	}
-
-	resp, err := c.HTTPClient.Post(c.BaseURL+"/api/public/update_user", "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		return fmt.Errorf("error making request: %v", err)
 	}
-	defer resp.Body.Close()
 
-	bodyBytes, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	return nil
+	return c.post(ctx, "/api/public/update_user", payload, "user", userID, nil)
 }
 
 // IsUserNotFoundError checks if the error is due to a user not being found
 func IsUserNotFoundError(err error) bool {
-	if err == nil {
-		return false
-	}
-	return strings.Contains(err.Error(), "User not found")
+	return IsNotFoundOf(err, "user")
 }
@@ -0,0 +1,138 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseServerVersion(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{input: "1.15.0", expected: "1.15.0"},
+		{input: "1.16.1.6fdc8a", expected: "1.16.1"},
+		{input: "v1.14", expected: "1.14.0"},
+		{input: "latest", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			v, err := ParseServerVersion(tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, v.String())
+			assert.Equal(t, tc.input, v.Raw)
+		})
+	}
+}
+
+func TestServerVersion_Compare(t *testing.T) {
+	v114 := mustServerVersion("1.14.0")
+	v115 := mustServerVersion("1.15.0")
+	v1151 := mustServerVersion("1.15.1")
+
+	assert.Equal(t, -1, v114.Compare(v115))
+	assert.Equal(t, 1, v1151.Compare(v115))
+	assert.Equal(t, 0, v115.Compare(mustServerVersion("1.15")))
+	assert.True(t, v1151.AtLeast(v115))
+	assert.False(t, v114.AtLeast(v115))
+	assert.False(t, ServerVersion{}.IsKnown())
+	assert.Equal(t, "unknown", ServerVersion{}.String())
+}
+
+func newServerInfoTestClient(t *testing.T, handler http.HandlerFunc, options ...ClientOption) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	options = append([]ClientOption{WithMaxRetries(0)}, options...)
+	return NewClient(server.URL, "test-key", "test-secret", false, options...)
+}
+
+func TestDetectServerInfo(t *testing.T) {
+	t.Run("Top level version", func(t *testing.T) {
+		c := newServerInfoTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"version": "1.15.0", "installation_id": "inst-1"}`))
+		})
+
+		info, err := c.DetectServerInfo(context.Background())
+		assert.NoError(t, err)
+		assert.True(t, info.Detected)
+		assert.Equal(t, "1.15.0", info.Version.String())
+		assert.Equal(t, "inst-1", c.ServerInfo().InstallationID)
+	})
+
+	t.Run("Nested system info", func(t *testing.T) {
+		c := newServerInfoTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"system_info": {"kasm_version": "1.16.0", "build_id": "b1"}}`))
+		})
+
+		info, err := c.DetectServerInfo(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "1.16.0", info.Version.String())
+		assert.Equal(t, "b1", info.BuildID)
+	})
+
+	t.Run("Endpoint not available", func(t *testing.T) {
+		c := newServerInfoTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+
+		info, err := c.DetectServerInfo(context.Background())
+		assert.NoError(t, err)
+		assert.False(t, info.Version.IsKnown())
+		assert.NoError(t, c.RequireFeature(FeaturePersistentSessions), "unknown versions must not be gated")
+	})
+
+	t.Run("Server error", func(t *testing.T) {
+		c := newServerInfoTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+
+		_, err := c.DetectServerInfo(context.Background())
+		assert.Error(t, err)
+	})
+
+	t.Run("Pinned version is not probed", func(t *testing.T) {
+		var requests int32
+		c := newServerInfoTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			w.Write([]byte(`{"version": "1.16.0"}`))
+		}, WithServerVersion(mustServerVersion("1.14.2")))
+
+		info, err := c.DetectServerInfo(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "1.14.2", info.Version.String())
+		assert.False(t, info.Detected)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+	})
+}
+
+func TestRequireFeature(t *testing.T) {
+	c := NewClient("http://kasm.invalid", "test-key", "test-secret", false,
+		WithServerVersion(mustServerVersion("1.15.0")))
+
+	assert.NoError(t, c.RequireFeature(FeatureEnableSharing))
+
+	err := c.RequireFeature(FeaturePersistentSessions)
+	var ufe *UnsupportedFeatureError
+	if assert.True(t, errors.As(err, &ufe)) {
+		assert.Equal(t, FeaturePersistentSessions.Name, ufe.Feature.Name)
+		assert.Contains(t, err.Error(), "requires Kasm 1.16.0 or later")
+		assert.Contains(t, err.Error(), "1.15.0")
+	}
+}
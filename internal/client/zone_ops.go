@@ -1,49 +1,33 @@
 package client
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 )
 
 // GetZones retrieves all deployment zones
-func (c *Client) GetZones(brief bool) ([]Zone, error) {
+func (c *Client) GetZones(ctx context.Context, brief bool) ([]Zone, error) {
 	payload := map[string]interface{}{
 		"api_key":        c.APIKey,
 		"api_key_secret": c.APISecret,
 		"brief":          brief,
 	}
 
-	resp, err := c.doRequestLegacy("POST", "/api/public/get_zones", payload)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %v", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status code: %d, body: %s", resp.StatusCode, string(body))
-	}
-
 	var result struct {
 		Zones []Zone `json:"zones"`
 	}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %v, body: %s", err, string(body))
+	if err := c.post(ctx, "/api/public/get_zones", payload, "zone", "", &result); err != nil {
+		return nil, err
 	}
 
 	return result.Zones, nil
 }
 
 // GetZone retrieves a specific deployment zone by ID
-func (c *Client) GetZone(zoneID string) (*Zone, error) {
-	zones, err := c.GetZones(false)
+func (c *Client) GetZone(ctx context.Context, zoneID string) (*Zone, error) {
+	zones, err := c.GetZones(ctx, false)
 	if err != nil {
-		return nil, fmt.Errorf("error getting zones: %v", err)
+		return nil, fmt.Errorf("error getting zones: %w", err)
 	}
 
 	for _, zone := range zones {
@@ -52,5 +36,92 @@ func (c *Client) GetZone(zoneID string) (*Zone, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("zone with ID %s not found", zoneID)
+	return nil, &NotFoundError{ResourceType: "zone", ID: zoneID}
+}
+
+// CreateZone creates a new deployment zone
+func (c *Client) CreateZone(ctx context.Context, zone *Zone) (*Zone, error) {
+	if err := validateZone(zone); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_zone":    zone,
+	}
+
+	var result struct {
+		Zone *Zone `json:"zone"`
+	}
+	if err := c.post(ctx, "/api/public/create_zone", payload, "zone", zone.ZoneName, &result); err != nil {
+		return nil, err
+	}
+	if result.Zone == nil {
+		return nil, fmt.Errorf("create_zone returned no zone")
+	}
+
+	return result.Zone, nil
+}
+
+// UpdateZone updates an existing deployment zone
+func (c *Client) UpdateZone(ctx context.Context, zone *Zone) (*Zone, error) {
+	if err := validateZone(zone); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_zone":    zone,
+	}
+
+	var result struct {
+		Zone *Zone `json:"zone"`
+	}
+	if err := c.post(ctx, "/api/public/update_zone", payload, "zone", zone.ZoneID, &result); err != nil {
+		return nil, err
+	}
+	if result.Zone == nil {
+		return zone, nil
+	}
+
+	return result.Zone, nil
+}
+
+// DeleteZone deletes a deployment zone by ID
+func (c *Client) DeleteZone(ctx context.Context, zoneID string) error {
+	payload := map[string]interface{}{
+		"api_key":        c.APIKey,
+		"api_key_secret": c.APISecret,
+		"target_zone": map[string]string{
+			"zone_id": zoneID,
+		},
+	}
+
+	return c.post(ctx, "/api/public/delete_zone", payload, "zone", zoneID, nil)
+}
+
+func validateZone(zone *Zone) error {
+	if zone.ZoneName == "" {
+		return fmt.Errorf("zone name is required")
+	}
+	if zone.LoadBalancingStrategy != "" && !IsValidZoneLoadBalancingStrategy(zone.LoadBalancingStrategy) {
+		return fmt.Errorf("zone load_balancing_strategy must be one of %v, got %q", ZoneLoadBalancingStrategies, zone.LoadBalancingStrategy)
+	}
+	if zone.ProxyPort != nil && (*zone.ProxyPort < 0 || *zone.ProxyPort > 65535) {
+		return fmt.Errorf("zone proxy_port must be between 0 and 65535, got %d", *zone.ProxyPort)
+	}
+	return nil
+}
+
+// IsValidZoneLoadBalancingStrategy reports whether s is one of
+// ZoneLoadBalancingStrategies.
+func IsValidZoneLoadBalancingStrategy(s string) bool {
+	for _, valid := range ZoneLoadBalancingStrategies {
+		if s == valid {
+			return true
+		}
+	}
+	return false
 }
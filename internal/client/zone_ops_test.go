@@ -0,0 +1,104 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateZone(t *testing.T) {
+	port := func(p int) *int { return &p }
+
+	testCases := []struct {
+		name          string
+		zone          Zone
+		errorContains string
+	}{
+		{name: "Defaults", zone: Zone{ZoneName: "eu-west"}},
+		{name: "Client port", zone: Zone{ZoneName: "eu-west", LoadBalancingStrategy: ZoneLoadBalancingMostLoad, ProxyPort: port(0)}},
+		{name: "Missing name", zone: Zone{}, errorContains: "name is required"},
+		{
+			name:          "Unknown strategy",
+			zone:          Zone{ZoneName: "eu-west", LoadBalancingStrategy: "random"},
+			errorContains: "load_balancing_strategy must be one of",
+		},
+		{
+			name:          "Port out of range",
+			zone:          Zone{ZoneName: "eu-west", ProxyPort: port(70000)},
+			errorContains: "proxy_port must be between 0 and 65535",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateZone(&tc.zone)
+			if tc.errorContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.errorContains)
+			}
+		})
+	}
+}
+
+func TestClient_ZoneCRUD(t *testing.T) {
+	var created, updated map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		switch r.URL.Path {
+		case "/api/public/create_zone":
+			created = req["target_zone"].(map[string]interface{})
+			w.Write([]byte(`{"zone": {"zone_id": "z1", "zone_name": "eu-west", "load_balancing_strategy": "least_load", "proxy_hostname": "$request_host$", "proxy_port": 443}}`))
+		case "/api/public/update_zone":
+			updated = req["target_zone"].(map[string]interface{})
+			w.Write([]byte(`{}`))
+		case "/api/public/get_zones":
+			w.Write([]byte(`{"zones": [{"zone_id": "z1", "zone_name": "eu-west", "proxy_port": 0, "aws_secret_access_key": "secret"}]}`))
+		case "/api/public/delete_zone":
+			assert.Equal(t, map[string]interface{}{"zone_id": "z1"}, req["target_zone"])
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", "test-secret", false, WithMaxRetries(0))
+	ctx := context.Background()
+
+	zone, err := client.CreateZone(ctx, &Zone{ZoneName: "eu-west", SearchAlternateZones: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "z1", zone.ZoneID)
+	if assert.NotNil(t, zone.ProxyPort) {
+		assert.Equal(t, 443, *zone.ProxyPort)
+	}
+	assert.NotContains(t, created, "zone_id")
+	assert.NotContains(t, created, "proxy_port", "an unset proxy port leaves the server default")
+	assert.Equal(t, true, created["search_alternate_zones"])
+
+	port := 0
+	zone.ProxyPort = &port
+	_, err = client.UpdateZone(ctx, zone)
+	assert.NoError(t, err)
+	assert.Equal(t, "z1", updated["zone_id"])
+	assert.Equal(t, float64(0), updated["proxy_port"])
+
+	got, err := client.GetZone(ctx, "z1")
+	assert.NoError(t, err)
+	if assert.NotNil(t, got.ProxyPort) {
+		assert.Equal(t, 0, *got.ProxyPort)
+	}
+
+	assert.NoError(t, client.DeleteZone(ctx, "z1"))
+}
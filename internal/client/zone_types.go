@@ -1,15 +1,42 @@
 package client
 
+// Load balancing strategies accepted by Kasm for a zone.
+const (
+	ZoneLoadBalancingLeastLoad = "least_load"
+	ZoneLoadBalancingMostLoad  = "most_load"
+)
+
+// ZoneLoadBalancingStrategies lists the valid values of
+// Zone.LoadBalancingStrategy.
+var ZoneLoadBalancingStrategies = []string{
+	ZoneLoadBalancingLeastLoad,
+	ZoneLoadBalancingMostLoad,
+}
+
 // Zone represents a Kasm deployment zone
 type Zone struct {
-	ZoneID             string `json:"zone_id"`
+	ZoneID             string `json:"zone_id,omitempty"`
 	ZoneName           string `json:"zone_name"`
 	AutoScalingEnabled bool   `json:"auto_scaling_enabled"`
 	AWSEnabled         bool   `json:"aws_enabled"`
-	AWSRegion          string `json:"aws_region"`
-	AWSAccessKeyID     string `json:"aws_access_key_id"`
-	AWSSecretAccessKey string `json:"aws_secret_access_key"`
-	EC2AgentAMIID      string `json:"ec2_agent_ami_id"`
+	AWSRegion          string `json:"aws_region,omitempty"`
+	AWSAccessKeyID     string `json:"aws_access_key_id,omitempty"`
+	AWSSecretAccessKey string `json:"aws_secret_access_key,omitempty"`
+	EC2AgentAMIID      string `json:"ec2_agent_ami_id,omitempty"`
+
+	// LoadBalancingStrategy decides which agent a new session is placed on.
+	LoadBalancingStrategy string `json:"load_balancing_strategy,omitempty"`
+	// SearchAlternateZones lets sessions be placed in another zone when this
+	// zone has no capacity.
+	SearchAlternateZones   bool   `json:"search_alternate_zones"`
+	PrioritizeStaticAgents bool   `json:"prioritize_static_agents"`
+	UpstreamAuthAddress    string `json:"upstream_auth_address,omitempty"`
+	AllowOriginDomain      string `json:"allow_origin_domain,omitempty"`
+	ProxyHostname          string `json:"proxy_hostname,omitempty"`
+	ProxyPath              string `json:"proxy_path,omitempty"`
+	// ProxyPort is the port clients connect to sessions on; 0 means the port
+	// the client used to reach Kasm. Nil leaves the server default.
+	ProxyPort *int `json:"proxy_port,omitempty"`
 }
 
 // GetZonesRequest represents the request to get zones
@@ -0,0 +1,160 @@
+package effective_egress
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kasm/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &effectiveEgressDataSource{}
+	_ datasource.DataSourceWithConfigure = &effectiveEgressDataSource{}
+)
+
+// effectiveEgressDataSource is the data source implementation
+type effectiveEgressDataSource struct {
+	client *client.Client
+}
+
+// effectiveEgressDataSourceModel maps the data source schema data
+type effectiveEgressDataSourceModel struct {
+	UserID                  types.String        `tfsdk:"user_id"`
+	ImageID                 types.String        `tfsdk:"image_id"`
+	DefaultEgressProviderID types.String        `tfsdk:"default_egress_provider_id"`
+	Options                 []egressOptionModel `tfsdk:"options"`
+}
+
+// egressOptionModel maps effective egress option schema data
+type egressOptionModel struct {
+	EgressProviderID types.String `tfsdk:"egress_provider_id"`
+	Name             types.String `tfsdk:"name"`
+	Type             types.String `tfsdk:"type"`
+	Default          types.Bool   `tfsdk:"default"`
+	Source           types.String `tfsdk:"source"`
+	SourceID         types.String `tfsdk:"source_id"`
+}
+
+// New creates a new effective egress data source
+func New() datasource.DataSource {
+	return &effectiveEgressDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *effectiveEgressDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_effective_egress"
+}
+
+// Schema defines the schema for the data source
+func (d *effectiveEgressDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Computes the egress providers a Kasm user can choose from, combining the egress mappings of the user, " +
+			"their groups and optionally a workspace image. For each provider the most specific mapping applies: user, " +
+			"then image, then group, with groups ordered by priority.",
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				Description: "The ID of the user.",
+				Required:    true,
+			},
+			"image_id": schema.StringAttribute{
+				Description: "The ID of a workspace image the user launches. Mappings of this image are included.",
+				Optional:    true,
+			},
+			"default_egress_provider_id": schema.StringAttribute{
+				Description: "The ID of the preselected egress provider, or null when there is no default.",
+				Computed:    true,
+			},
+			"options": schema.ListNestedAttribute{
+				Description: "The egress providers the user can choose from, sorted by name. Disabled providers are not included.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"egress_provider_id": schema.StringAttribute{
+							Description: "Egress provider ID",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Egress provider name",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Egress provider type",
+							Computed:    true,
+						},
+						"default": schema.BoolAttribute{
+							Description: "Whether the provider is preselected",
+							Computed:    true,
+						},
+						"source": schema.StringAttribute{
+							Description: "Kind of mapping that granted access: user, image or group",
+							Computed:    true,
+						},
+						"source_id": schema.StringAttribute{
+							Description: "ID of the user, image or group of that mapping",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *effectiveEgressDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *effectiveEgressDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state effectiveEgressDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	options, err := d.client.GetEffectiveEgress(ctx, state.UserID.ValueString(), state.ImageID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Kasm Effective Egress",
+			fmt.Sprintf("Could not compute the egress options of user %s: %s", state.UserID.ValueString(), err),
+		)
+		return
+	}
+
+	state.DefaultEgressProviderID = types.StringNull()
+	state.Options = make([]egressOptionModel, 0, len(options))
+	for _, option := range options {
+		if option.Default {
+			state.DefaultEgressProviderID = types.StringValue(option.EgressProviderID)
+		}
+		state.Options = append(state.Options, egressOptionModel{
+			EgressProviderID: types.StringValue(option.EgressProviderID),
+			Name:             types.StringValue(option.Name),
+			Type:             types.StringValue(option.Type),
+			Default:          types.BoolValue(option.Default),
+			Source:           types.StringValue(option.Source),
+			SourceID:         types.StringValue(option.SourceID),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,147 @@
+package egress_gateways
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kasm/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &egressGatewaysDataSource{}
+	_ datasource.DataSourceWithConfigure = &egressGatewaysDataSource{}
+)
+
+// egressGatewaysDataSource is the data source implementation
+type egressGatewaysDataSource struct {
+	client *client.Client
+}
+
+// egressGatewaysDataSourceModel maps the data source schema data
+type egressGatewaysDataSourceModel struct {
+	EgressProviderID types.String         `tfsdk:"egress_provider_id"`
+	EgressGateways   []egressGatewayModel `tfsdk:"egress_gateways"`
+}
+
+// egressGatewayModel maps egress gateway schema data
+type egressGatewayModel struct {
+	ID               types.String `tfsdk:"id"`
+	EgressProviderID types.String `tfsdk:"egress_provider_id"`
+	Name             types.String `tfsdk:"name"`
+	Country          types.String `tfsdk:"country"`
+	City             types.String `tfsdk:"city"`
+	Enabled          types.Bool   `tfsdk:"enabled"`
+}
+
+// New creates a new egress gateways data source
+func New() datasource.DataSource {
+	return &egressGatewaysDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *egressGatewaysDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_egress_gateways"
+}
+
+// Schema defines the schema for the data source
+func (d *egressGatewaysDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the list of Kasm egress gateways.",
+		Attributes: map[string]schema.Attribute{
+			"egress_provider_id": schema.StringAttribute{
+				Description: "Only return the gateways of this egress provider.",
+				Optional:    true,
+			},
+			"egress_gateways": schema.ListNestedAttribute{
+				Description: "List of egress gateways. Configuration is not included.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Egress gateway ID",
+							Computed:    true,
+						},
+						"egress_provider_id": schema.StringAttribute{
+							Description: "ID of the egress provider the gateway belongs to",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Egress gateway name",
+							Computed:    true,
+						},
+						"country": schema.StringAttribute{
+							Description: "Country the gateway is located in",
+							Computed:    true,
+						},
+						"city": schema.StringAttribute{
+							Description: "City the gateway is located in",
+							Computed:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether sessions can use the gateway",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *egressGatewaysDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *egressGatewaysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state egressGatewaysDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	gateways, err := d.client.GetEgressGateways(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Kasm Egress Gateways",
+			fmt.Sprintf("Could not read Kasm egress gateways: %s", err),
+		)
+		return
+	}
+
+	state.EgressGateways = make([]egressGatewayModel, 0, len(gateways))
+	for _, gateway := range gateways {
+		if !state.EgressProviderID.IsNull() && gateway.EgressProviderID != state.EgressProviderID.ValueString() {
+			continue
+		}
+		state.EgressGateways = append(state.EgressGateways, egressGatewayModel{
+			ID:               types.StringValue(gateway.EgressGatewayID),
+			EgressProviderID: types.StringValue(gateway.EgressProviderID),
+			Name:             types.StringValue(gateway.Name),
+			Country:          types.StringValue(gateway.Country),
+			City:             types.StringValue(gateway.City),
+			Enabled:          types.BoolValue(gateway.Enabled),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,152 @@
+package egress_providers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kasm/internal/client"
+	"terraform-provider-kasm/internal/validators"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &egressProvidersDataSource{}
+	_ datasource.DataSourceWithConfigure = &egressProvidersDataSource{}
+)
+
+// egressProvidersDataSource is the data source implementation
+type egressProvidersDataSource struct {
+	client *client.Client
+}
+
+// egressProvidersDataSourceModel maps the data source schema data
+type egressProvidersDataSourceModel struct {
+	Type            types.String          `tfsdk:"type"`
+	EgressProviders []egressProviderModel `tfsdk:"egress_providers"`
+}
+
+// egressProviderModel maps egress provider schema data
+type egressProviderModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+	Description types.String `tfsdk:"description"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+	EgressImage types.String `tfsdk:"egress_image"`
+}
+
+// New creates a new egress providers data source
+func New() datasource.DataSource {
+	return &egressProvidersDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *egressProvidersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_egress_providers"
+}
+
+// Schema defines the schema for the data source
+func (d *egressProvidersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the list of Kasm egress providers.",
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Description: "Only return egress providers of this type.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.StringOneOf(client.EgressProviderTypes...),
+				},
+			},
+			"egress_providers": schema.ListNestedAttribute{
+				Description: "List of egress providers. Configuration is not included.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Egress provider ID",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Egress provider name",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Egress provider type",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Egress provider description",
+							Computed:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether sessions can use the egress provider",
+							Computed:    true,
+						},
+						"egress_image": schema.StringAttribute{
+							Description: "Container image that runs the egress connection",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *egressProvidersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *egressProvidersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state egressProvidersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providers, err := d.client.GetEgressProviders(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Kasm Egress Providers",
+			fmt.Sprintf("Could not read Kasm egress providers: %s", err),
+		)
+		return
+	}
+
+	state.EgressProviders = make([]egressProviderModel, 0, len(providers))
+	for _, provider := range providers {
+		if !state.Type.IsNull() && provider.Type != state.Type.ValueString() {
+			continue
+		}
+		state.EgressProviders = append(state.EgressProviders, egressProviderModel{
+			ID:          types.StringValue(provider.EgressProviderID),
+			Name:        types.StringValue(provider.Name),
+			Type:        types.StringValue(provider.Type),
+			Description: types.StringValue(provider.Description),
+			Enabled:     types.BoolValue(provider.Enabled),
+			EgressImage: types.StringValue(provider.Image),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,179 @@
+package group_sso_mappings
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kasm/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &groupSSOMappingsDataSource{}
+	_ datasource.DataSourceWithConfigure = &groupSSOMappingsDataSource{}
+)
+
+// groupSSOMappingsDataSource is the data source implementation
+type groupSSOMappingsDataSource struct {
+	client *client.Client
+}
+
+// groupSSOMappingsDataSourceModel maps the data source schema data
+type groupSSOMappingsDataSourceModel struct {
+	GroupID  types.String           `tfsdk:"group_id"`
+	SSOID    types.String           `tfsdk:"sso_id"`
+	Mappings []groupSSOMappingModel `tfsdk:"mappings"`
+}
+
+// groupSSOMappingModel maps SSO mapping schema data
+type groupSSOMappingModel struct {
+	ID              types.String `tfsdk:"id"`
+	GroupID         types.String `tfsdk:"group_id"`
+	GroupName       types.String `tfsdk:"group_name"`
+	SSOType         types.String `tfsdk:"sso_type"`
+	SSOID           types.String `tfsdk:"sso_id"`
+	GroupAttribute  types.String `tfsdk:"group_attribute"`
+	ApplyToAllUsers types.Bool   `tfsdk:"apply_to_all_users"`
+}
+
+// New creates a new group SSO mappings data source
+func New() datasource.DataSource {
+	return &groupSSOMappingsDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *groupSSOMappingsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_sso_mappings"
+}
+
+// Schema defines the schema for the data source
+func (d *groupSSOMappingsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the SSO mappings that add LDAP, SAML and OIDC users to Kasm groups, across every group " +
+			"or for a single group or provider.",
+		Attributes: map[string]schema.Attribute{
+			"group_id": schema.StringAttribute{
+				Description: "Only list the mappings of this group.",
+				Optional:    true,
+			},
+			"sso_id": schema.StringAttribute{
+				Description: "Only list the mappings of this LDAP, SAML or OIDC configuration.",
+				Optional:    true,
+			},
+			"mappings": schema.ListNestedAttribute{
+				Description: "The SSO mappings, sorted by group name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "SSO mapping ID",
+							Computed:    true,
+						},
+						"group_id": schema.StringAttribute{
+							Description: "ID of the Kasm group",
+							Computed:    true,
+						},
+						"group_name": schema.StringAttribute{
+							Description: "Name of the Kasm group",
+							Computed:    true,
+						},
+						"sso_type": schema.StringAttribute{
+							Description: "Type of the provider: ldap, saml or oidc",
+							Computed:    true,
+						},
+						"sso_id": schema.StringAttribute{
+							Description: "ID of the LDAP, SAML or OIDC configuration",
+							Computed:    true,
+						},
+						"group_attribute": schema.StringAttribute{
+							Description: "Group DN or groups attribute value users must have, empty when the mapping applies to all users",
+							Computed:    true,
+						},
+						"apply_to_all_users": schema.BoolAttribute{
+							Description: "Whether every user of the provider is added to the group",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *groupSSOMappingsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *groupSSOMappingsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state groupSSOMappingsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groups, err := d.client.GetGroups(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Kasm Groups",
+			fmt.Sprintf("Could not read Kasm groups: %s", err),
+		)
+		return
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+
+	state.Mappings = []groupSSOMappingModel{}
+	for _, group := range groups {
+		if !state.GroupID.IsNull() && group.GroupID != state.GroupID.ValueString() {
+			continue
+		}
+
+		mappings, err := d.client.GetGroupSSOMappings(ctx, group.GroupID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Kasm Group SSO Mappings",
+				fmt.Sprintf("Could not read the SSO mappings of group %s: %s", group.Name, err),
+			)
+			return
+		}
+
+		for _, mapping := range mappings {
+			if !state.SSOID.IsNull() && mapping.SSOID() != state.SSOID.ValueString() {
+				continue
+			}
+			state.Mappings = append(state.Mappings, groupSSOMappingModel{
+				ID:              types.StringValue(mapping.SSOGroupMappingID),
+				GroupID:         types.StringValue(group.GroupID),
+				GroupName:       types.StringValue(group.Name),
+				SSOType:         types.StringValue(mapping.SSOType()),
+				SSOID:           types.StringValue(mapping.SSOID()),
+				GroupAttribute:  types.StringValue(mapping.GroupAttribute),
+				ApplyToAllUsers: types.BoolValue(mapping.ApplyToAllUsers),
+			})
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
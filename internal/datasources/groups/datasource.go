@@ -106,7 +106,7 @@ func (d *groupsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	tflog.Debug(ctx, "Preparing to read groups data source")
 	var state groupsDataSourceModel
 
-	groups, err := d.client.GetGroups()
+	groups, err := d.client.GetGroups(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Kasm Groups",
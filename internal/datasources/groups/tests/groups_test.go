@@ -4,6 +4,7 @@
 package tests
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
@@ -18,7 +19,7 @@ func TestAccKasmGroups_Basic(t *testing.T) {
 			testutils.TestAccPreCheck(t)
 			// Add additional validation
 			c := testutils.GetTestClient(t)
-			groups, err := c.GetGroups()
+			groups, err := c.GetGroups(context.Background())
 			if err != nil {
 				t.Skipf("No groups available: %v", err)
 			}
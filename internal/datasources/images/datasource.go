@@ -2,13 +2,18 @@ package images
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"terraform-provider-kasm/internal/client"
+	"terraform-provider-kasm/internal/validators"
 )
 
 // Ensure the implementations satisfy the expected interfaces.
@@ -119,7 +124,7 @@ func (d *imagesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	var state imagesDataSourceModel
 
-	images, err := d.client.GetImages()
+	images, err := d.client.GetImages(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Kasm Images",
@@ -160,19 +165,33 @@ func (d *imagesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 }
 
+// NewSessionRecordingDataSource is a helper function to simplify the provider implementation.
+func NewSessionRecordingDataSource() datasource.DataSource {
+	return &sessionRecordingDataSource{}
+}
+
+// NewSessionsRecordingsDataSource is a helper function to simplify the provider implementation.
+func NewSessionsRecordingsDataSource() datasource.DataSource {
+	return &sessionsRecordingsDataSource{}
+}
+
 type sessionRecordingDataSource struct {
 	client *client.Client
 }
 
 type sessionRecordingModel struct {
-	KasmID              types.String     `tfsdk:"kasm_id"`
-	PreauthDownloadLink types.Bool       `tfsdk:"preauth_download_link"`
-	Recordings          []recordingModel `tfsdk:"recordings"`
+	KasmID                    types.String     `tfsdk:"kasm_id"`
+	PreauthDownloadLink       types.Bool       `tfsdk:"preauth_download_link"`
+	PreauthDownloadLinkExpiry types.String     `tfsdk:"preauth_download_link_expiry"`
+	StartDate                 types.String     `tfsdk:"start_date"`
+	EndDate                   types.String     `tfsdk:"end_date"`
+	Recordings                []recordingModel `tfsdk:"recordings"`
 }
 
 type recordingModel struct {
 	RecordingID                 types.String `tfsdk:"recording_id"`
 	AccountID                   types.String `tfsdk:"account_id"`
+	StartTime                   types.String `tfsdk:"start_time"`
 	SessionRecordingURL         types.String `tfsdk:"session_recording_url"`
 	SessionRecordingMetadata    types.Map    `tfsdk:"session_recording_metadata"`
 	SessionRecordingDownloadURL types.String `tfsdk:"session_recording_download_url"`
@@ -183,12 +202,20 @@ type sessionsRecordingsDataSource struct {
 }
 
 type sessionsRecordingsModel struct {
-	KasmIDs             types.List                  `tfsdk:"kasm_ids"` // Changed from []types.String
-	PreauthDownloadLink types.Bool                  `tfsdk:"preauth_download_link"`
-	Sessions            map[string][]recordingModel `tfsdk:"sessions"`
+	KasmIDs                   types.List                        `tfsdk:"kasm_ids"`
+	PreauthDownloadLink       types.Bool                        `tfsdk:"preauth_download_link"`
+	PreauthDownloadLinkExpiry types.String                      `tfsdk:"preauth_download_link_expiry"`
+	StartDate                 types.String                      `tfsdk:"start_date"`
+	EndDate                   types.String                      `tfsdk:"end_date"`
+	Sessions                  map[string]sessionRecordingsModel `tfsdk:"sessions"`
+}
+
+// sessionRecordingsModel holds the recordings of one session in the sessions
+// map.
+type sessionRecordingsModel struct {
+	Recordings []recordingModel `tfsdk:"recordings"`
 }
 
-// Implement Metadata, Schema, Configure, and Read methods for each data source
 func (d *sessionRecordingDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_session_recording"
 }
@@ -197,84 +224,169 @@ func (d *sessionsRecordingsDataSource) Metadata(_ context.Context, req datasourc
 	resp.TypeName = req.ProviderTypeName + "_sessions_recordings"
 }
 
-// Schema implementations for the session recording data sources
-func (d *sessionRecordingDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
-	resp.Schema = schema.Schema{
-		Description: "Fetches recordings for a specific Kasm session.",
-		Attributes: map[string]schema.Attribute{
-			"kasm_id": schema.StringAttribute{
-				Required:    true,
-				Description: "ID of the Kasm session",
+// recordingFilterAttributes are the optional arguments shared by both session
+// recording data sources.
+func recordingFilterAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"preauth_download_link": schema.BoolAttribute{
+			Optional:    true,
+			Description: "Whether to include pre-authorized download links",
+		},
+		"preauth_download_link_expiry": schema.StringAttribute{
+			Optional:    true,
+			Description: "How long the pre-authorized download links stay valid, as a duration such as \"1h\". Only used with preauth_download_link. Defaults to the server setting.",
+			Validators: []validator.String{
+				validators.Duration(),
 			},
-			"preauth_download_link": schema.BoolAttribute{
-				Optional:    true,
-				Description: "Whether to include pre-authorized download links",
+		},
+		"start_date": schema.StringAttribute{
+			Optional:    true,
+			Description: "Only return recordings that started at or after this RFC 3339 timestamp",
+			Validators: []validator.String{
+				validators.RFC3339(),
 			},
-			"recordings": schema.ListNestedAttribute{
-				Computed: true,
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"recording_id": schema.StringAttribute{
-							Computed: true,
-						},
-						"account_id": schema.StringAttribute{
-							Computed: true,
-						},
-						"session_recording_url": schema.StringAttribute{
-							Computed: true,
-						},
-						"session_recording_metadata": schema.MapAttribute{
-							Computed:    true,
-							ElementType: types.StringType,
-						},
-						"session_recording_download_url": schema.StringAttribute{
-							Computed: true,
-						},
-					},
+		},
+		"end_date": schema.StringAttribute{
+			Optional:    true,
+			Description: "Only return recordings that started at or before this RFC 3339 timestamp",
+			Validators: []validator.String{
+				validators.RFC3339(),
+			},
+		},
+	}
+}
+
+// recordingsAttribute is the computed list of recordings of one session.
+func recordingsAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Computed:    true,
+		Description: "Recordings of the session",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"recording_id": schema.StringAttribute{
+					Computed: true,
+				},
+				"account_id": schema.StringAttribute{
+					Computed: true,
+				},
+				"start_time": schema.StringAttribute{
+					Computed:    true,
+					Description: "When the recording started, in RFC 3339 format. Empty when the server does not report it.",
+				},
+				"session_recording_url": schema.StringAttribute{
+					Computed: true,
+				},
+				"session_recording_metadata": schema.MapAttribute{
+					Computed:    true,
+					ElementType: types.StringType,
+					Description: "Recording metadata. Values that are not strings are JSON encoded.",
+				},
+				"session_recording_download_url": schema.StringAttribute{
+					Computed:  true,
+					Sensitive: true,
 				},
 			},
 		},
 	}
 }
 
-func (d *sessionsRecordingsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+func (d *sessionRecordingDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := recordingFilterAttributes()
+	attributes["kasm_id"] = schema.StringAttribute{
+		Required:    true,
+		Description: "ID of the Kasm session",
+	}
+	attributes["recordings"] = recordingsAttribute()
+
 	resp.Schema = schema.Schema{
-		Description: "Fetches recordings for multiple Kasm sessions.",
-		Attributes: map[string]schema.Attribute{
-			"kasm_ids": schema.ListAttribute{
-				Required:    true,
-				ElementType: types.StringType,
-				Description: "List of Kasm session IDs",
-			},
-			"preauth_download_link": schema.BoolAttribute{
-				Optional:    true,
-				Description: "Whether to include pre-authorized download links",
-			},
-			"sessions": schema.MapNestedAttribute{
-				Computed: true,
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"recording_id": schema.StringAttribute{
-							Computed: true,
-						},
-						"account_id": schema.StringAttribute{
-							Computed: true,
-						},
-						"session_recording_url": schema.StringAttribute{
-							Computed: true,
-						},
-						"session_recording_metadata": schema.MapAttribute{
-							Computed:    true,
-							ElementType: types.StringType,
-						},
-						"session_recording_download_url": schema.StringAttribute{
-							Computed: true,
-						},
-					},
-				},
+		Description: "Fetches recordings for a specific Kasm session.",
+		Attributes:  attributes,
+	}
+}
+
+func (d *sessionsRecordingsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := recordingFilterAttributes()
+	attributes["kasm_ids"] = schema.ListAttribute{
+		Required:    true,
+		ElementType: types.StringType,
+		Description: "List of Kasm session IDs",
+	}
+	attributes["sessions"] = schema.MapNestedAttribute{
+		Computed:    true,
+		Description: "Recordings keyed by Kasm session ID",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"recordings": recordingsAttribute(),
 			},
 		},
 	}
+
+	resp.Schema = schema.Schema{
+		Description: "Fetches recordings for multiple Kasm sessions.",
+		Attributes:  attributes,
+	}
+}
+
+// recordingsOptions builds the client options from the optional arguments.
+// The values have already been checked by the schema validators.
+func recordingsOptions(preauth types.Bool, expiry, startDate, endDate types.String) client.SessionRecordingsOptions {
+	opts := client.SessionRecordingsOptions{
+		PreauthDownloadLink: preauth.ValueBool(),
+	}
+	if !expiry.IsNull() {
+		opts.PreauthDownloadLinkExpiry, _ = time.ParseDuration(expiry.ValueString())
+	}
+	if !startDate.IsNull() {
+		opts.StartDate, _ = time.Parse(time.RFC3339, startDate.ValueString())
+	}
+	if !endDate.IsNull() {
+		opts.EndDate, _ = time.Parse(time.RFC3339, endDate.ValueString())
+	}
+	return opts
+}
+
+// flattenRecordings maps API recordings to the data source model.
+func flattenRecordings(ctx context.Context, recordings []client.SessionRecording) ([]recordingModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	models := make([]recordingModel, 0, len(recordings))
+	for _, rec := range recordings {
+		metadata := make(map[string]string, len(rec.SessionRecordingMetadata))
+		for key, value := range rec.SessionRecordingMetadata {
+			if s, ok := value.(string); ok {
+				metadata[key] = s
+				continue
+			}
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				diags.AddError("Error Encoding Recording Metadata",
+					fmt.Sprintf("Could not encode metadata field %s of recording %s: %s", key, rec.RecordingID, err))
+				return nil, diags
+			}
+			metadata[key] = string(encoded)
+		}
+
+		metadataValue, d := types.MapValueFrom(ctx, types.StringType, metadata)
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		startTime := ""
+		if t, ok := rec.StartTime(); ok {
+			startTime = t.Format(time.RFC3339)
+		}
+
+		models = append(models, recordingModel{
+			RecordingID:                 types.StringValue(rec.RecordingID),
+			AccountID:                   types.StringValue(rec.AccountID),
+			StartTime:                   types.StringValue(startTime),
+			SessionRecordingURL:         types.StringValue(rec.SessionRecordingURL),
+			SessionRecordingMetadata:    metadataValue,
+			SessionRecordingDownloadURL: types.StringValue(rec.SessionRecordingDownloadURL),
+		})
+	}
+	return models, diags
 }
 
 // Read method for sessionRecordingDataSource
@@ -289,9 +401,9 @@ func (d *sessionRecordingDataSource) Read(ctx context.Context, req datasource.Re
 	}
 
 	// Get recordings from the API
-	recordings, err := d.client.GetSessionRecordings(
+	recordings, err := d.client.GetSessionRecordings(ctx,
 		state.KasmID.ValueString(),
-		state.PreauthDownloadLink.ValueBool(),
+		recordingsOptions(state.PreauthDownloadLink, state.PreauthDownloadLinkExpiry, state.StartDate, state.EndDate),
 	)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -302,23 +414,10 @@ func (d *sessionRecordingDataSource) Read(ctx context.Context, req datasource.Re
 		return
 	}
 
-	// Map the recordings to our model
-	state.Recordings = make([]recordingModel, 0, len(recordings))
-	for _, rec := range recordings {
-		metadata, diags := types.MapValueFrom(ctx, types.StringType, rec.SessionRecordingMetadata)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		recordingState := recordingModel{
-			RecordingID:                 types.StringValue(rec.RecordingID),
-			AccountID:                   types.StringValue(rec.AccountID),
-			SessionRecordingURL:         types.StringValue(rec.SessionRecordingURL),
-			SessionRecordingMetadata:    metadata,
-			SessionRecordingDownloadURL: types.StringValue(rec.SessionRecordingDownloadURL),
-		}
-		state.Recordings = append(state.Recordings, recordingState)
+	state.Recordings, diags = flattenRecordings(ctx, recordings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Set state
@@ -346,9 +445,9 @@ func (d *sessionsRecordingsDataSource) Read(ctx context.Context, req datasource.
 	}
 
 	// Get recordings from the API
-	recordings, err := d.client.GetSessionsRecordings(
+	recordings, err := d.client.GetSessionsRecordings(ctx,
 		kasmIDs,
-		state.PreauthDownloadLink.ValueBool(),
+		recordingsOptions(state.PreauthDownloadLink, state.PreauthDownloadLinkExpiry, state.StartDate, state.EndDate),
 	)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -358,29 +457,14 @@ func (d *sessionsRecordingsDataSource) Read(ctx context.Context, req datasource.
 		return
 	}
 
-	// Initialize the sessions map
-	state.Sessions = make(map[string][]recordingModel)
-
-	// Map the recordings to our model
+	state.Sessions = make(map[string]sessionRecordingsModel, len(recordings))
 	for kasmID, sessionRecordings := range recordings {
-		recordingModels := make([]recordingModel, 0, len(sessionRecordings))
-		for _, rec := range sessionRecordings {
-			metadata, diags := types.MapValueFrom(ctx, types.StringType, rec.SessionRecordingMetadata)
-			resp.Diagnostics.Append(diags...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			recordingState := recordingModel{
-				RecordingID:                 types.StringValue(rec.RecordingID),
-				AccountID:                   types.StringValue(rec.AccountID),
-				SessionRecordingURL:         types.StringValue(rec.SessionRecordingURL),
-				SessionRecordingMetadata:    metadata,
-				SessionRecordingDownloadURL: types.StringValue(rec.SessionRecordingDownloadURL),
-			}
-			recordingModels = append(recordingModels, recordingState)
+		models, diags := flattenRecordings(ctx, sessionRecordings)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
-		state.Sessions[kasmID] = recordingModels
+		state.Sessions[kasmID] = sessionRecordingsModel{Recordings: models}
 	}
 
 	// Set state
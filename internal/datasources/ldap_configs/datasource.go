@@ -0,0 +1,165 @@
+package ldap_configs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kasm/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &ldapConfigsDataSource{}
+	_ datasource.DataSourceWithConfigure = &ldapConfigsDataSource{}
+)
+
+// ldapConfigsDataSource is the data source implementation
+type ldapConfigsDataSource struct {
+	client *client.Client
+}
+
+// ldapConfigsDataSourceModel maps the data source schema data
+type ldapConfigsDataSourceModel struct {
+	LDAPConfigs []ldapConfigModel `tfsdk:"ldap_configs"`
+}
+
+// ldapConfigModel maps LDAP configuration schema data
+type ldapConfigModel struct {
+	ID                    types.String `tfsdk:"id"`
+	Name                  types.String `tfsdk:"name"`
+	Enabled               types.Bool   `tfsdk:"enabled"`
+	URL                   types.String `tfsdk:"url"`
+	SearchBase            types.String `tfsdk:"search_base"`
+	SearchFilter          types.String `tfsdk:"search_filter"`
+	GroupMembershipFilter types.String `tfsdk:"group_membership_filter"`
+	ServiceAccountDN      types.String `tfsdk:"service_account_dn"`
+	UsernameDomainMatch   types.String `tfsdk:"username_domain_match"`
+	AutoCreateUsers       types.Bool   `tfsdk:"auto_create_users"`
+	EmailAttribute        types.String `tfsdk:"email_attribute"`
+}
+
+// New creates a new LDAP configurations data source
+func New() datasource.DataSource {
+	return &ldapConfigsDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *ldapConfigsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ldap_configs"
+}
+
+// Schema defines the schema for the data source
+func (d *ldapConfigsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the list of LDAP directories configured in Kasm.",
+		Attributes: map[string]schema.Attribute{
+			"ldap_configs": schema.ListNestedAttribute{
+				Description: "List of LDAP configurations. Service account passwords are not included.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "LDAP configuration ID",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "LDAP configuration name",
+							Computed:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether users can log in through the directory",
+							Computed:    true,
+						},
+						"url": schema.StringAttribute{
+							Description: "Address of the directory",
+							Computed:    true,
+						},
+						"search_base": schema.StringAttribute{
+							Description: "DN users are searched under",
+							Computed:    true,
+						},
+						"search_filter": schema.StringAttribute{
+							Description: "Filter that finds the entry of a user",
+							Computed:    true,
+						},
+						"group_membership_filter": schema.StringAttribute{
+							Description: "Filter that finds the groups of a user",
+							Computed:    true,
+						},
+						"service_account_dn": schema.StringAttribute{
+							Description: "DN of the account Kasm binds with",
+							Computed:    true,
+						},
+						"username_domain_match": schema.StringAttribute{
+							Description: "Domain usernames must end in to use the directory",
+							Computed:    true,
+						},
+						"auto_create_users": schema.BoolAttribute{
+							Description: "Whether Kasm users are created on first login",
+							Computed:    true,
+						},
+						"email_attribute": schema.StringAttribute{
+							Description: "Directory attribute copied to the email of the user",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *ldapConfigsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *ldapConfigsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ldapConfigsDataSourceModel
+
+	configs, err := d.client.GetLDAPConfigs(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Kasm LDAP Configurations",
+			fmt.Sprintf("Could not read Kasm LDAP configurations: %s", err),
+		)
+		return
+	}
+
+	state.LDAPConfigs = make([]ldapConfigModel, 0, len(configs))
+	for _, config := range configs {
+		state.LDAPConfigs = append(state.LDAPConfigs, ldapConfigModel{
+			ID:                    types.StringValue(config.LDAPID),
+			Name:                  types.StringValue(config.Name),
+			Enabled:               types.BoolValue(config.Enabled),
+			URL:                   types.StringValue(config.URL),
+			SearchBase:            types.StringValue(config.SearchBase),
+			SearchFilter:          types.StringValue(config.SearchFilter),
+			GroupMembershipFilter: types.StringValue(config.GroupMembershipFilter),
+			ServiceAccountDN:      types.StringValue(config.ServiceAccountDN),
+			UsernameDomainMatch:   types.StringValue(config.UsernameDomainMatch),
+			AutoCreateUsers:       types.BoolValue(config.AutoCreateAppUser),
+			EmailAttribute:        types.StringValue(config.EmailAttribute),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -107,7 +107,7 @@ func (d *rdpClientConnectionInfoDataSource) Read(ctx context.Context, req dataso
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Getting RDP connection info for user %s, kasm %s, type %s", state.UserID.ValueString(), state.KasmID.ValueString(), connectionType))
-	connectionInfo, err := d.client.GetRDPConnectionInfo(state.UserID.ValueString(), state.KasmID.ValueString(), connectionType)
+	connectionInfo, err := d.client.GetRDPConnectionInfo(ctx, state.UserID.ValueString(), state.KasmID.ValueString(), connectionType)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error getting RDP connection info",
@@ -4,6 +4,7 @@
 package tests
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -22,7 +23,7 @@ var createdImageID string // Track the created image ID for cleanup
 // cleanupTestImage deletes the test image if it was created
 func cleanupTestImage(t *testing.T, c *client.Client) {
 	if createdImageID != "" {
-		if err := c.DeleteImage(createdImageID); err != nil {
+		if err := c.DeleteImage(context.Background(), createdImageID); err != nil {
 			t.Logf("Warning: Failed to delete test image %s: %v", createdImageID, err)
 		} else {
 			log.Printf("[DEBUG] Successfully deleted test image: %s", createdImageID)
@@ -41,7 +42,7 @@ func ensureWorkspaceImage(t *testing.T, c *client.Client) string {
 	}
 
 	// Try to find an existing image first
-	images, err := c.GetImages()
+	images, err := c.GetImages(context.Background())
 	if err != nil {
 		log.Printf("[DEBUG] Error getting images: %v", err)
 		log.Printf("[DEBUG] Will attempt to create new image")
@@ -105,7 +106,7 @@ func ensureWorkspaceImage(t *testing.T, c *client.Client) string {
 	for i := 0; i < maxRetries; i++ {
 		log.Printf("[DEBUG] Attempt %d/%d to create image", i+1, maxRetries)
 
-		createdImage, lastErr = c.AddWorkspaceImage(image)
+		createdImage, lastErr = c.AddWorkspaceImage(context.Background(), image)
 
 		if lastErr != nil {
 			log.Printf("[DEBUG] Attempt %d failed with error: %v", i+1, lastErr)
@@ -159,7 +160,7 @@ func waitForImageAvailable(t *testing.T, c *client.Client, imageID string) {
 	retryInterval := 10 * time.Second
 
 	for i := 0; i < maxRetries; i++ {
-		images, err := c.GetImages()
+		images, err := c.GetImages(context.Background())
 		if err != nil {
 			log.Printf("[DEBUG] Error getting images: %v", err)
 		} else {
@@ -212,7 +213,7 @@ func TestAccKasmRDPClientConnectionInfo(t *testing.T) {
 	// Create a new Kasm session
 	log.Printf("[DEBUG] Creating Kasm session for user %s with image %s", userID, imageID)
 	sessionToken := uuid.New().String()
-	kasm, err := c.CreateKasm(userID, imageID, sessionToken, "test", true, false, false, false)
+	kasm, err := c.CreateKasm(context.Background(), userID, imageID, sessionToken, "test", true, true, false, false, false)
 	if err != nil {
 		t.Fatalf("Failed to create Kasm session: %v", err)
 	}
@@ -220,7 +221,7 @@ func TestAccKasmRDPClientConnectionInfo(t *testing.T) {
 	// Ensure the Kasm session is deleted when the test is done
 	defer func() {
 		log.Printf("[DEBUG] Cleaning up Kasm session %s", kasm.KasmID)
-		err := c.DestroyKasm(userID, kasm.KasmID)
+		err := c.DestroyKasm(context.Background(), userID, kasm.KasmID)
 		if err != nil {
 			log.Printf("[WARN] Failed to delete Kasm session: %v", err)
 		}
@@ -238,7 +239,7 @@ func TestAccKasmRDPClientConnectionInfo(t *testing.T) {
 
 	for i := 0; i < maxRetries; i++ {
 		var err error
-		sessionDetails, err = c.GetKasmStatus(userID, kasm.KasmID, true)
+		sessionDetails, err = c.GetKasmStatus(context.Background(), userID, kasm.KasmID, true)
 		if err != nil {
 			log.Printf("[DEBUG] Error getting session status: %v. Retrying...", err)
 		} else {
@@ -261,7 +262,7 @@ func TestAccKasmRDPClientConnectionInfo(t *testing.T) {
 
 	// Test RDP connection info for file type
 	log.Printf("[DEBUG] Getting RDP connection info for file type")
-	fileConnectionInfo, err := c.GetRDPConnectionInfo(userID, kasm.KasmID, "file")
+	fileConnectionInfo, err := c.GetRDPConnectionInfo(context.Background(), userID, kasm.KasmID, "file")
 	if err != nil {
 		t.Fatalf("Failed to get RDP connection info for file type: %v", err)
 	}
@@ -273,7 +274,7 @@ func TestAccKasmRDPClientConnectionInfo(t *testing.T) {
 
 	// Test RDP connection info for URL type
 	log.Printf("[DEBUG] Getting RDP connection info for URL type")
-	urlConnectionInfo, err := c.GetRDPConnectionInfo(userID, kasm.KasmID, "url")
+	urlConnectionInfo, err := c.GetRDPConnectionInfo(context.Background(), userID, kasm.KasmID, "url")
 	if err != nil {
 		t.Fatalf("Failed to get RDP connection info for URL type: %v", err)
 	}
@@ -97,7 +97,7 @@ func (d *RegistriesDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 func (d *RegistriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state RegistriesDataSourceModel
 
-	registries, err := d.client.GetRegistries()
+	registries, err := d.client.GetRegistries(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading registries",
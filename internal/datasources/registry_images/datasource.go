@@ -117,7 +117,7 @@ func (d *registryImageDataSource) Read(ctx context.Context, req datasource.ReadR
 	}
 
 	// Get all images from the client
-	images, err := d.client.ListRegistryImages()
+	images, err := d.client.ListRegistryImages(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Kasm Images",
@@ -150,7 +150,7 @@ func (d *registryImageDataSource) Read(ctx context.Context, req datasource.ReadR
 		}
 
 		// Get registries to validate the registry ID
-		registries, err := d.client.GetRegistries()
+		registries, err := d.client.GetRegistries(ctx)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Reading Kasm Registries",
@@ -0,0 +1,143 @@
+package server_info
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-kasm/internal/client"
+)
+
+var (
+	_ datasource.DataSource = &serverInfoDataSource{}
+)
+
+// serverInfoDataSource is the data source implementation.
+type serverInfoDataSource struct {
+	client *client.Client
+}
+
+// serverInfoDataSourceModel maps the data source schema data
+type serverInfoDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Version        types.String `tfsdk:"version"`
+	Major          types.Int64  `tfsdk:"major"`
+	Minor          types.Int64  `tfsdk:"minor"`
+	Patch          types.Int64  `tfsdk:"patch"`
+	BuildID        types.String `tfsdk:"build_id"`
+	InstallationID types.String `tfsdk:"installation_id"`
+	Detected       types.Bool   `tfsdk:"detected"`
+	Features       types.Map    `tfsdk:"features"`
+}
+
+// New creates a new server info data source
+func New() datasource.DataSource {
+	return &serverInfoDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *serverInfoDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_info"
+}
+
+// Schema defines the schema for the data source
+func (d *serverInfoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reports the version of the Kasm server and which version-dependent API features it supports.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"version": schema.StringAttribute{
+				Computed:    true,
+				Description: "The server version as major.minor.patch, or \"unknown\" when the server does not report it",
+			},
+			"major": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The major version number",
+			},
+			"minor": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The minor version number",
+			},
+			"patch": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The patch version number",
+			},
+			"build_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The build identifier reported by the server",
+			},
+			"installation_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The installation ID reported by the server",
+			},
+			"detected": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the version was reported by the server rather than set with the provider's server_version",
+			},
+			"features": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.BoolType,
+				Description: "Version-dependent features and whether the provider will use them with this server",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *serverInfoDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *serverInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	info, err := d.client.DetectServerInfo(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Kasm Server Info",
+			fmt.Sprintf("Could not read server info: %v", err),
+		)
+		return
+	}
+
+	features := make(map[string]bool, len(client.Features))
+	for _, feature := range client.Features {
+		features[feature.Name] = d.client.SupportsFeature(feature)
+	}
+	featuresValue, diags := types.MapValueFrom(ctx, types.BoolType, features)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := serverInfoDataSourceModel{
+		ID:             types.StringValue("server_info"),
+		Version:        types.StringValue(info.Version.String()),
+		Major:          types.Int64Value(int64(info.Version.Major)),
+		Minor:          types.Int64Value(int64(info.Version.Minor)),
+		Patch:          types.Int64Value(int64(info.Version.Patch)),
+		BuildID:        types.StringValue(info.BuildID),
+		InstallationID: types.StringValue(info.InstallationID),
+		Detected:       types.BoolValue(info.Detected),
+		Features:       featuresValue,
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,167 @@
+package session_screenshot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-kasm/internal/client"
+	"terraform-provider-kasm/internal/validators"
+)
+
+var (
+	_ datasource.DataSource = &sessionScreenshotDataSource{}
+)
+
+// sessionScreenshotDataSource is the data source implementation.
+type sessionScreenshotDataSource struct {
+	client *client.Client
+}
+
+// sessionScreenshotDataSourceModel maps the data source schema data
+type sessionScreenshotDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	KasmID        types.String `tfsdk:"kasm_id"`
+	UserID        types.String `tfsdk:"user_id"`
+	Width         types.Int64  `tfsdk:"width"`
+	OutputPath    types.String `tfsdk:"output_path"`
+	ContentType   types.String `tfsdk:"content_type"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	SHA256        types.String `tfsdk:"sha256"`
+	Size          types.Int64  `tfsdk:"size"`
+}
+
+// New creates a new session screenshot data source
+func New() datasource.DataSource {
+	return &sessionScreenshotDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *sessionScreenshotDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_session_screenshot"
+}
+
+// Schema defines the schema for the data source
+func (d *sessionScreenshotDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Captures a screenshot of a running Kasm session, either as base64 content or written to a local file.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"kasm_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the session to capture",
+			},
+			"user_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the user who owns the session",
+			},
+			"width": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Width in pixels to scale the screenshot to. Defaults to the server's default width",
+				Validators: []validator.Int64{
+					validators.Int64AtLeast(1),
+				},
+			},
+			"output_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Local path to write the image to. When set, content_base64 is left empty to keep the image out of state",
+			},
+			"content_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "The media type of the image, such as image/jpeg or image/png",
+			},
+			"content_base64": schema.StringAttribute{
+				Computed:    true,
+				Description: "The image encoded as base64, when output_path is not set",
+			},
+			"sha256": schema.StringAttribute{
+				Computed:    true,
+				Description: "Hex encoded SHA-256 checksum of the image",
+			},
+			"size": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Size of the image in bytes",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *sessionScreenshotDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *sessionScreenshotDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state sessionScreenshotDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	screenshot, err := d.client.GetKasmScreenshot(ctx, state.KasmID.ValueString(), state.UserID.ValueString(), int(state.Width.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Kasm Session Screenshot",
+			fmt.Sprintf("Could not capture screenshot of session %s: %v", state.KasmID.ValueString(), err),
+		)
+		return
+	}
+
+	sum := sha256.Sum256(screenshot.Data)
+	checksum := hex.EncodeToString(sum[:])
+
+	if outputPath := state.OutputPath.ValueString(); outputPath != "" {
+		if err := writeFile(outputPath, screenshot.Data); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("output_path"),
+				"Error Writing Screenshot",
+				fmt.Sprintf("Could not write screenshot to %s: %v", outputPath, err),
+			)
+			return
+		}
+		state.ContentBase64 = types.StringNull()
+	} else {
+		state.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(screenshot.Data))
+	}
+
+	state.ID = types.StringValue(checksum)
+	state.ContentType = types.StringValue(screenshot.ContentType)
+	state.SHA256 = types.StringValue(checksum)
+	state.Size = types.Int64Value(int64(len(screenshot.Data)))
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// writeFile writes data to name, creating its parent directories.
+func writeFile(name string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(name, data, 0o644)
+}
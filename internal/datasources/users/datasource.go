@@ -107,7 +107,7 @@ func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	user, err := d.client.GetUser(state.UserID.ValueString())
+	user, err := d.client.GetUser(ctx, state.UserID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Kasm User",
@@ -117,7 +117,7 @@ func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 
 	// Get authorized images
-	authorizedImages, err := d.client.GetUserAuthorizedImages(user.UserID)
+	authorizedImages, err := d.client.GetUserAuthorizedImages(ctx, user.UserID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading user authorized images",
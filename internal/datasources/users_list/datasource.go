@@ -118,7 +118,7 @@ func (d *usersDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	var state usersDataSourceModel
 
 	// Get list of users from API
-	users, err := d.client.GetUsers()
+	users, err := d.client.GetUsers(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read users, got error: %s", err))
 		return
@@ -29,6 +29,15 @@ type zoneModel struct {
 	AWSAccessKeyID     types.String `tfsdk:"aws_access_key_id"`
 	AWSSecretAccessKey types.String `tfsdk:"aws_secret_access_key"`
 	EC2AgentAMIID      types.String `tfsdk:"ec2_agent_ami_id"`
+
+	LoadBalancingStrategy  types.String `tfsdk:"load_balancing_strategy"`
+	SearchAlternateZones   types.Bool   `tfsdk:"search_alternate_zones"`
+	PrioritizeStaticAgents types.Bool   `tfsdk:"prioritize_static_agents"`
+	UpstreamAuthAddress    types.String `tfsdk:"upstream_auth_address"`
+	AllowOriginDomain      types.String `tfsdk:"allow_origin_domain"`
+	ProxyHostname          types.String `tfsdk:"proxy_hostname"`
+	ProxyPath              types.String `tfsdk:"proxy_path"`
+	ProxyPort              types.Int64  `tfsdk:"proxy_port"`
 }
 
 // zonesDataSourceModel maps the data source schema data
@@ -86,17 +95,51 @@ func (d *zonesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						},
 						"aws_access_key_id": schema.StringAttribute{
 							Computed:    true,
+							Sensitive:   true,
 							Description: "The AWS access key ID",
 						},
 						"aws_secret_access_key": schema.StringAttribute{
-							Computed:    true,
-							Sensitive:   true,
-							Description: "The AWS secret access key",
+							Computed:           true,
+							Sensitive:          true,
+							Description:        "Always null. The secret key is no longer read into state.",
+							DeprecationMessage: "The AWS secret access key is no longer returned by this data source and will be removed in a future release.",
 						},
 						"ec2_agent_ami_id": schema.StringAttribute{
 							Computed:    true,
 							Description: "The EC2 agent AMI ID",
 						},
+						"load_balancing_strategy": schema.StringAttribute{
+							Computed:    true,
+							Description: "How new sessions are placed on the zone's agents",
+						},
+						"search_alternate_zones": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether sessions can be placed in another zone when this zone has no capacity",
+						},
+						"prioritize_static_agents": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether static agents are used before auto-scaled agents",
+						},
+						"upstream_auth_address": schema.StringAttribute{
+							Computed:    true,
+							Description: "The address agents use to authenticate sessions",
+						},
+						"allow_origin_domain": schema.StringAttribute{
+							Computed:    true,
+							Description: "The domain allowed as the origin of session connections",
+						},
+						"proxy_hostname": schema.StringAttribute{
+							Computed:    true,
+							Description: "The hostname clients use to connect to sessions",
+						},
+						"proxy_path": schema.StringAttribute{
+							Computed:    true,
+							Description: "The path clients use to connect to sessions",
+						},
+						"proxy_port": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The port clients use to connect to sessions",
+						},
 					},
 				},
 			},
@@ -135,7 +178,7 @@ func (d *zonesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		brief = state.Brief.ValueBool()
 	}
 
-	zones, err := d.client.GetZones(brief)
+	zones, err := d.client.GetZones(ctx, brief)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Kasm Zones",
@@ -153,8 +196,20 @@ func (d *zonesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 			AWSEnabled:         types.BoolValue(zone.AWSEnabled),
 			AWSRegion:          types.StringValue(zone.AWSRegion),
 			AWSAccessKeyID:     types.StringValue(zone.AWSAccessKeyID),
-			AWSSecretAccessKey: types.StringValue(zone.AWSSecretAccessKey),
+			AWSSecretAccessKey: types.StringNull(),
 			EC2AgentAMIID:      types.StringValue(zone.EC2AgentAMIID),
+
+			LoadBalancingStrategy:  types.StringValue(zone.LoadBalancingStrategy),
+			SearchAlternateZones:   types.BoolValue(zone.SearchAlternateZones),
+			PrioritizeStaticAgents: types.BoolValue(zone.PrioritizeStaticAgents),
+			UpstreamAuthAddress:    types.StringValue(zone.UpstreamAuthAddress),
+			AllowOriginDomain:      types.StringValue(zone.AllowOriginDomain),
+			ProxyHostname:          types.StringValue(zone.ProxyHostname),
+			ProxyPath:              types.StringValue(zone.ProxyPath),
+			ProxyPort:              types.Int64Null(),
+		}
+		if zone.ProxyPort != nil {
+			zoneState.ProxyPort = types.Int64Value(int64(*zone.ProxyPort))
 		}
 		state.Zones = append(state.Zones, zoneState)
 	}
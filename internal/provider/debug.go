@@ -1,8 +1,10 @@
 package provider
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"terraform-provider-kasm/internal/client"
 	"terraform-provider-kasm/internal/resources/user"
 )
 
@@ -21,7 +23,7 @@ func debugLog(format string, v ...interface{}) {
 // For pretty printing API responses
 func debugLogObject(prefix string, obj interface{}) {
 	if debugMode {
-		log.Printf("[DEBUG] %s: %+v", prefix, obj)
+		log.Printf("[DEBUG] %s: %s", prefix, client.Redact(fmt.Sprintf("%+v", obj)))
 	}
 }
 
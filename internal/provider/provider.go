@@ -2,40 +2,69 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"terraform-provider-kasm/internal/client"
+	effectiveegressds "terraform-provider-kasm/internal/datasources/effective_egress"
+	egressgatewaysds "terraform-provider-kasm/internal/datasources/egress_gateways"
+	egressprovidersds "terraform-provider-kasm/internal/datasources/egress_providers"
+	groupssomappingsds "terraform-provider-kasm/internal/datasources/group_sso_mappings"
 	groupsds "terraform-provider-kasm/internal/datasources/groups"
 	imageds "terraform-provider-kasm/internal/datasources/images"
+	ldapconfigsds "terraform-provider-kasm/internal/datasources/ldap_configs"
 	rdpds "terraform-provider-kasm/internal/datasources/rdp"
 	registryds "terraform-provider-kasm/internal/datasources/registries"
 	registryimageds "terraform-provider-kasm/internal/datasources/registry_images"
+	serverinfods "terraform-provider-kasm/internal/datasources/server_info"
+	screenshotds "terraform-provider-kasm/internal/datasources/session_screenshot"
 	usersds "terraform-provider-kasm/internal/datasources/users_list"
 	zonesds "terraform-provider-kasm/internal/datasources/zones"
+	"terraform-provider-kasm/internal/resources/autoscale_config"
 	"terraform-provider-kasm/internal/resources/cast"
+	"terraform-provider-kasm/internal/resources/egress_credential"
+	"terraform-provider-kasm/internal/resources/egress_gateway"
+	"terraform-provider-kasm/internal/resources/egress_mapping"
+	"terraform-provider-kasm/internal/resources/egress_provider"
+	"terraform-provider-kasm/internal/resources/exec"
+	"terraform-provider-kasm/internal/resources/global_setting"
 	"terraform-provider-kasm/internal/resources/group"
 	"terraform-provider-kasm/internal/resources/group_image"
 	"terraform-provider-kasm/internal/resources/group_membership"
+	"terraform-provider-kasm/internal/resources/group_setting"
+	"terraform-provider-kasm/internal/resources/group_sso_mapping"
 	imageres "terraform-provider-kasm/internal/resources/image"
 	"terraform-provider-kasm/internal/resources/join"
 	"terraform-provider-kasm/internal/resources/kasm"
+	"terraform-provider-kasm/internal/resources/ldap_config"
 	"terraform-provider-kasm/internal/resources/license"
 	"terraform-provider-kasm/internal/resources/login"
+	"terraform-provider-kasm/internal/resources/oidc_config"
 	"terraform-provider-kasm/internal/resources/registry"
+	"terraform-provider-kasm/internal/resources/saml_config"
+	"terraform-provider-kasm/internal/resources/server"
+	"terraform-provider-kasm/internal/resources/server_pool"
 	"terraform-provider-kasm/internal/resources/session"
 	"terraform-provider-kasm/internal/resources/session_permission"
+	"terraform-provider-kasm/internal/resources/session_recording_export"
 	"terraform-provider-kasm/internal/resources/staging"
 	"terraform-provider-kasm/internal/resources/stats"
 	"terraform-provider-kasm/internal/resources/user"
+	"terraform-provider-kasm/internal/resources/vm_provider_config"
+	"terraform-provider-kasm/internal/resources/zone"
+	"terraform-provider-kasm/internal/validators"
 )
 
 var _ provider.Provider = &kasmProvider{}
@@ -45,6 +74,9 @@ type kasmProvider struct {
 	client *client.Client
 	// Custom server URL for testing
 	testServerURL string
+	// Extra client options, applied after those derived from the
+	// configuration
+	clientOptions []client.ClientOption
 }
 
 type kasmProviderModel struct {
@@ -52,6 +84,26 @@ type kasmProviderModel struct {
 	APIKey    types.String `tfsdk:"api_key"`
 	APISecret types.String `tfsdk:"api_secret"`
 	Insecure  types.Bool   `tfsdk:"insecure"`
+
+	RequestsPerSecond types.Float64 `tfsdk:"requests_per_second"`
+	Burst             types.Int64   `tfsdk:"burst"`
+
+	MaxRetries           types.Int64  `tfsdk:"max_retries"`
+	RetryInitialInterval types.String `tfsdk:"retry_initial_interval"`
+	RetryMaxInterval     types.String `tfsdk:"retry_max_interval"`
+	RequestTimeout       types.String `tfsdk:"request_timeout"`
+	CacheTTL             types.String `tfsdk:"cache_ttl"`
+
+	Headers types.Map `tfsdk:"headers"`
+
+	CACertPEM  types.String `tfsdk:"ca_cert_pem"`
+	CACertFile types.String `tfsdk:"ca_cert_file"`
+	ClientCert types.String `tfsdk:"client_cert"`
+	ClientKey  types.String `tfsdk:"client_key"`
+	ProxyURL   types.String `tfsdk:"proxy_url"`
+	NoProxy    types.String `tfsdk:"no_proxy"`
+
+	ServerVersion types.String `tfsdk:"server_version"`
 }
 
 func New(opts ...string) provider.Provider {
@@ -62,6 +114,13 @@ func New(opts ...string) provider.Provider {
 	return p
 }
 
+// NewWithClientOptions returns a provider whose API client is built with the
+// given options in addition to those derived from the configuration. It is
+// used to add middleware such as tracing or fault injection.
+func NewWithClientOptions(options ...client.ClientOption) provider.Provider {
+	return &kasmProvider{clientOptions: options}
+}
+
 func (p *kasmProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "kasm"
 }
@@ -87,6 +146,93 @@ func (p *kasmProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				Optional:    true,
 				Description: "Skip TLS verification",
 			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Maximum average rate of Kasm API requests per second. Requests beyond the limit wait for capacity instead of failing. Defaults to %d.", client.DefaultRequestsPerSecond),
+				Validators: []validator.Float64{
+					validators.Float64GreaterThan(0),
+				},
+			},
+			"burst": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Maximum number of Kasm API requests allowed in a single burst. Defaults to %d.", client.DefaultBurst),
+				Validators: []validator.Int64{
+					validators.Int64AtLeast(1),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Maximum number of times a failed Kasm API request is retried. Defaults to %d.", client.DefaultMaxRetries),
+				Validators: []validator.Int64{
+					validators.Int64AtLeast(0),
+				},
+			},
+			"retry_initial_interval": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Backoff before the first retry, as a duration such as \"500ms\". Defaults to %s. A Retry-After header from the server takes precedence.", client.DefaultRetryInitialInterval),
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"retry_max_interval": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Upper bound for the backoff between retries, including waits requested with a Retry-After header, as a duration such as \"30s\". Defaults to %s.", client.DefaultRetryMaxInterval),
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"request_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Timeout for a single Kasm API request, as a duration such as \"2m\". Defaults to %s.", client.DefaultRequestTimeout),
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"cache_ttl": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("How long lists of images, groups, users, group images, registries and egress providers, gateways, credentials and mappings are reused within a Terraform run, as a duration such as \"1m\". Changes made through the provider invalidate the affected lists. \"0s\" disables caching. Defaults to %s.", client.DefaultCacheTTL),
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"headers": schema.MapAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+				Description: "Additional HTTP headers sent with every Kasm API request, for example a token required by a proxy or web application firewall.",
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded CA certificates to trust in addition to the system pool when verifying the Kasm server. Conflicts with ca_cert_file.",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a file of PEM encoded CA certificates to trust in addition to the system pool when verifying the Kasm server. Conflicts with ca_cert_pem.",
+			},
+			"client_cert": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded client certificate presented to servers that require mutual TLS. Requires client_key.",
+			},
+			"client_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "PEM encoded private key for client_cert.",
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the HTTP proxy used to reach the Kasm API. Defaults to the HTTPS_PROXY and HTTP_PROXY environment variables.",
+				Validators: []validator.String{
+					validators.ValidateURL(),
+				},
+			},
+			"no_proxy": schema.StringAttribute{
+				Optional:    true,
+				Description: "Comma-separated hosts, domains and CIDR ranges that bypass proxy_url, in the same format as the NO_PROXY environment variable.",
+			},
+			"server_version": schema.StringAttribute{
+				Optional:    true,
+				Description: "Kasm server version, such as \"1.15.0\", used to decide which API options are supported. By default the version is detected when the provider is configured.",
+			},
 		},
 	}
 }
@@ -179,7 +325,10 @@ func (p *kasmProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		apiSecret = os.Getenv("KASM_API_SECRET")
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Configuration values - Base URL: %s, API Key: %s", baseURL, apiKey))
+	ctx = client.MaskLogs(ctx, apiSecret)
+	tflog.Debug(ctx, "Configuration values", map[string]interface{}{
+		"base_url": baseURL,
+	})
 
 	// Create client with provided configuration
 	insecure := false
@@ -187,16 +336,128 @@ func (p *kasmProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		insecure = config.Insecure.ValueBool()
 	}
 
+	requestsPerSecond := float64(client.DefaultRequestsPerSecond)
+	if !config.RequestsPerSecond.IsNull() {
+		requestsPerSecond = config.RequestsPerSecond.ValueFloat64()
+	}
+	burst := client.DefaultBurst
+	if !config.Burst.IsNull() {
+		burst = int(config.Burst.ValueInt64())
+	}
+	if requestsPerSecond <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Invalid Requests Per Second",
+			"The requests_per_second value must be greater than 0.",
+		)
+		return
+	}
+	if burst < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("burst"),
+			"Invalid Burst",
+			"The burst value must be at least 1.",
+		)
+		return
+	}
+
+	maxRetries := client.DefaultMaxRetries
+	if !config.MaxRetries.IsNull() {
+		maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	if maxRetries < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid Max Retries",
+			"The max_retries value must be at least 0.",
+		)
+		return
+	}
+	retryInitialInterval := parseDuration(config.RetryInitialInterval, "retry_initial_interval", client.DefaultRetryInitialInterval, &resp.Diagnostics)
+	retryMaxInterval := parseDuration(config.RetryMaxInterval, "retry_max_interval", client.DefaultRetryMaxInterval, &resp.Diagnostics)
+	requestTimeout := parseDuration(config.RequestTimeout, "request_timeout", client.DefaultRequestTimeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	cacheTTL := client.DefaultCacheTTL
+	if !config.CacheTTL.IsNull() && !config.CacheTTL.IsUnknown() {
+		d, err := time.ParseDuration(config.CacheTTL.ValueString())
+		if err != nil || d < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("cache_ttl"),
+				"Invalid Duration",
+				fmt.Sprintf("The cache_ttl value must be a duration such as \"1m\", or \"0s\" to disable caching, got %q.", config.CacheTTL.ValueString()),
+			)
+			return
+		}
+		cacheTTL = d
+	}
+	if retryMaxInterval < retryInitialInterval {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_max_interval"),
+			"Invalid Retry Max Interval",
+			fmt.Sprintf("The retry_max_interval value (%s) must not be less than retry_initial_interval (%s).", retryMaxInterval, retryInitialInterval),
+		)
+		return
+	}
+
+	options := []client.ClientOption{
+		client.WithRateLimit(requestsPerSecond, burst),
+		client.WithMaxRetries(maxRetries),
+		client.WithRetryInterval(retryInitialInterval, retryMaxInterval),
+		client.WithRequestTimeout(requestTimeout),
+		client.WithCacheTTL(cacheTTL),
+	}
+	if !config.Headers.IsNull() && !config.Headers.IsUnknown() {
+		headers := make(map[string]string, len(config.Headers.Elements()))
+		resp.Diagnostics.Append(config.Headers.ElementsAs(ctx, &headers, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		options = append(options, client.WithHeaders(headers))
+	}
+	options = append(options, transportOptions(config, &resp.Diagnostics)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !config.ServerVersion.IsNull() && !config.ServerVersion.IsUnknown() {
+		version, err := client.ParseServerVersion(config.ServerVersion.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("server_version"),
+				"Invalid Server Version",
+				fmt.Sprintf("The server_version value must be a version such as \"1.15.0\": %v", err),
+			)
+			return
+		}
+		options = append(options, client.WithServerVersion(version))
+	}
+	options = append(options, p.clientOptions...)
+
 	tflog.Info(ctx, "Creating Kasm client")
-	client := client.NewClient(baseURL, apiKey, apiSecret, insecure)
+	client := client.NewClient(baseURL, apiKey, apiSecret, insecure, options...)
 	if client == nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Client",
-			fmt.Sprintf("Failed to create Kasm API client with base_url=%s, api_key=%s, api_secret=%s, insecure=%v", baseURL, apiKey, apiSecret, insecure),
+			fmt.Sprintf("Failed to create Kasm API client with base_url=%s, insecure=%v", baseURL, insecure),
 		)
 		return
 	}
 
+	// Detect the server version so resources can check which options the
+	// server supports. Failure is not fatal; the API calls themselves will
+	// report an unreachable or misconfigured server.
+	if info, err := client.DetectServerInfo(ctx); err != nil {
+		tflog.Warn(ctx, "Unable to detect Kasm server version", map[string]interface{}{
+			"error": err.Error(),
+		})
+	} else {
+		tflog.Info(ctx, "Detected Kasm server", map[string]interface{}{
+			"version":  info.Version.String(),
+			"build_id": info.BuildID,
+		})
+	}
+
 	// Store the client in the provider
 	p.client = client
 	tflog.Info(ctx, "Successfully stored client in provider")
@@ -216,6 +477,109 @@ func (p *kasmProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	tflog.Info(ctx, "Successfully configured Kasm provider")
 }
 
+// parseDuration returns the duration configured in value, or def when value is
+// null. Invalid durations are reported against the named attribute.
+func parseDuration(value types.String, attribute string, def time.Duration, diags *diag.Diagnostics) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return def
+	}
+
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil || d <= 0 {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid Duration",
+			fmt.Sprintf("The %s value must be a positive duration such as \"500ms\" or \"2m\", got %q.", attribute, value.ValueString()),
+		)
+		return def
+	}
+
+	return d
+}
+
+// transportOptions returns the client options for the CA bundle, client
+// certificate and proxy settings in config. Invalid settings are reported in
+// diags.
+func transportOptions(config kasmProviderModel, diags *diag.Diagnostics) []client.ClientOption {
+	var options []client.ClientOption
+
+	var caPEM []byte
+	switch {
+	case !config.CACertPEM.IsNull() && !config.CACertFile.IsNull():
+		diags.AddAttributeError(
+			path.Root("ca_cert_pem"),
+			"Conflicting CA Certificate Configuration",
+			"Only one of ca_cert_pem and ca_cert_file may be set.",
+		)
+		return nil
+	case !config.CACertPEM.IsNull():
+		caPEM = []byte(config.CACertPEM.ValueString())
+	case !config.CACertFile.IsNull():
+		data, err := os.ReadFile(config.CACertFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Unable to Read CA Certificate File",
+				fmt.Sprintf("Could not read %s: %v", config.CACertFile.ValueString(), err),
+			)
+			return nil
+		}
+		caPEM = data
+	}
+	if caPEM != nil {
+		pool, err := client.CertPoolFromPEM(caPEM)
+		if err != nil {
+			attribute := "ca_cert_pem"
+			if !config.CACertFile.IsNull() {
+				attribute = "ca_cert_file"
+			}
+			diags.AddAttributeError(
+				path.Root(attribute),
+				"Invalid CA Certificate",
+				fmt.Sprintf("Could not load CA certificates: %v", err),
+			)
+			return nil
+		}
+		options = append(options, client.WithRootCAs(pool))
+	}
+
+	if config.ClientCert.IsNull() != config.ClientKey.IsNull() {
+		diags.AddAttributeError(
+			path.Root("client_cert"),
+			"Incomplete Client Certificate Configuration",
+			"client_cert and client_key must be set together.",
+		)
+		return nil
+	}
+	if !config.ClientCert.IsNull() {
+		cert, err := tls.X509KeyPair([]byte(config.ClientCert.ValueString()), []byte(config.ClientKey.ValueString()))
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("client_cert"),
+				"Invalid Client Certificate",
+				fmt.Sprintf("Could not load client certificate and key: %v", err),
+			)
+			return nil
+		}
+		options = append(options, client.WithClientCertificate(cert))
+	}
+
+	if !config.ProxyURL.IsNull() {
+		proxyURL, err := url.Parse(config.ProxyURL.ValueString())
+		if err != nil || proxyURL.Host == "" {
+			diags.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid Proxy URL",
+				fmt.Sprintf("The proxy_url value must be a valid URL such as \"http://proxy:3128\", got %q.", config.ProxyURL.ValueString()),
+			)
+			return nil
+		}
+		options = append(options, client.WithProxy(proxyURL, config.NoProxy.ValueString()))
+	}
+
+	return options
+}
+
 func (p *kasmProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		user.New,
@@ -231,19 +595,49 @@ func (p *kasmProvider) Resources(_ context.Context) []func() resource.Resource {
 		session_permission.New,
 		group_image.New,
 		group_membership.New,
+		group_setting.New,
 		join.New,
 		stats.NewStatsResource,
+		exec.New,
+		session_recording_export.New,
+		egress_provider.New,
+		egress_gateway.New,
+		egress_credential.New,
+		egress_mapping.New,
+		zone.New,
+		autoscale_config.New,
+		vm_provider_config.NewAWS,
+		vm_provider_config.NewAzure,
+		vm_provider_config.NewGCP,
+		vm_provider_config.NewDigitalOcean,
+		vm_provider_config.NewOCI,
+		server.New,
+		server_pool.New,
+		ldap_config.New,
+		saml_config.New,
+		oidc_config.New,
+		group_sso_mapping.New,
+		global_setting.New,
 	}
 }
 
 func (p *kasmProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		imageds.New,
+		imageds.NewSessionRecordingDataSource,
+		imageds.NewSessionsRecordingsDataSource,
 		registryds.New,
 		zonesds.New,
 		registryimageds.New,
 		groupsds.New,
 		usersds.New,
 		rdpds.NewRDPClientConnectionInfoDataSource,
+		serverinfods.New,
+		screenshotds.New,
+		egressprovidersds.New,
+		egressgatewaysds.New,
+		effectiveegressds.New,
+		ldapconfigsds.New,
+		groupssomappingsds.New,
 	}
 }
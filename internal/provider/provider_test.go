@@ -2,16 +2,21 @@ package provider
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
-	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-kasm/internal/client"
 )
 
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
@@ -82,6 +87,150 @@ func TestProvider_Configure(t *testing.T) {
 			},
 			expectError: true,
 		},
+		"rate_limit": {
+			values: map[string]tftypes.Value{
+				"base_url":            tftypes.NewValue(tftypes.String, "https://example.com"),
+				"api_key":             tftypes.NewValue(tftypes.String, "test-key"),
+				"api_secret":          tftypes.NewValue(tftypes.String, "test-secret"),
+				"requests_per_second": tftypes.NewValue(tftypes.Number, 5),
+				"burst":               tftypes.NewValue(tftypes.Number, 10),
+			},
+			expectError: false,
+		},
+		"invalid_requests_per_second": {
+			values: map[string]tftypes.Value{
+				"base_url":            tftypes.NewValue(tftypes.String, "https://example.com"),
+				"api_key":             tftypes.NewValue(tftypes.String, "test-key"),
+				"api_secret":          tftypes.NewValue(tftypes.String, "test-secret"),
+				"requests_per_second": tftypes.NewValue(tftypes.Number, 0),
+			},
+			expectError: true,
+		},
+		"retry_tuning": {
+			values: map[string]tftypes.Value{
+				"base_url":               tftypes.NewValue(tftypes.String, "https://example.com"),
+				"api_key":                tftypes.NewValue(tftypes.String, "test-key"),
+				"api_secret":             tftypes.NewValue(tftypes.String, "test-secret"),
+				"max_retries":            tftypes.NewValue(tftypes.Number, 5),
+				"retry_initial_interval": tftypes.NewValue(tftypes.String, "500ms"),
+				"retry_max_interval":     tftypes.NewValue(tftypes.String, "1m"),
+				"request_timeout":        tftypes.NewValue(tftypes.String, "5m"),
+			},
+			expectError: false,
+		},
+		"cache_ttl_disabled": {
+			values: map[string]tftypes.Value{
+				"base_url":   tftypes.NewValue(tftypes.String, "https://example.com"),
+				"api_key":    tftypes.NewValue(tftypes.String, "test-key"),
+				"api_secret": tftypes.NewValue(tftypes.String, "test-secret"),
+				"cache_ttl":  tftypes.NewValue(tftypes.String, "0s"),
+			},
+			expectError: false,
+		},
+		"negative_cache_ttl": {
+			values: map[string]tftypes.Value{
+				"base_url":   tftypes.NewValue(tftypes.String, "https://example.com"),
+				"api_key":    tftypes.NewValue(tftypes.String, "test-key"),
+				"api_secret": tftypes.NewValue(tftypes.String, "test-secret"),
+				"cache_ttl":  tftypes.NewValue(tftypes.String, "-1m"),
+			},
+			expectError: true,
+		},
+		"invalid_request_timeout": {
+			values: map[string]tftypes.Value{
+				"base_url":        tftypes.NewValue(tftypes.String, "https://example.com"),
+				"api_key":         tftypes.NewValue(tftypes.String, "test-key"),
+				"api_secret":      tftypes.NewValue(tftypes.String, "test-secret"),
+				"request_timeout": tftypes.NewValue(tftypes.String, "thirty seconds"),
+			},
+			expectError: true,
+		},
+		"retry_max_below_initial": {
+			values: map[string]tftypes.Value{
+				"base_url":               tftypes.NewValue(tftypes.String, "https://example.com"),
+				"api_key":                tftypes.NewValue(tftypes.String, "test-key"),
+				"api_secret":             tftypes.NewValue(tftypes.String, "test-secret"),
+				"retry_initial_interval": tftypes.NewValue(tftypes.String, "10s"),
+				"retry_max_interval":     tftypes.NewValue(tftypes.String, "1s"),
+			},
+			expectError: true,
+		},
+		"headers": {
+			values: map[string]tftypes.Value{
+				"base_url":   tftypes.NewValue(tftypes.String, "https://example.com"),
+				"api_key":    tftypes.NewValue(tftypes.String, "test-key"),
+				"api_secret": tftypes.NewValue(tftypes.String, "test-secret"),
+				"headers": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+					"X-WAF-Token": tftypes.NewValue(tftypes.String, "token"),
+				}),
+			},
+			expectError: false,
+		},
+		"proxy": {
+			values: map[string]tftypes.Value{
+				"base_url":   tftypes.NewValue(tftypes.String, "https://example.com"),
+				"api_key":    tftypes.NewValue(tftypes.String, "test-key"),
+				"api_secret": tftypes.NewValue(tftypes.String, "test-secret"),
+				"proxy_url":  tftypes.NewValue(tftypes.String, "http://proxy.example.com:3128"),
+				"no_proxy":   tftypes.NewValue(tftypes.String, "localhost,.internal"),
+			},
+			expectError: false,
+		},
+		"invalid_ca_cert_pem": {
+			values: map[string]tftypes.Value{
+				"base_url":    tftypes.NewValue(tftypes.String, "https://example.com"),
+				"api_key":     tftypes.NewValue(tftypes.String, "test-key"),
+				"api_secret":  tftypes.NewValue(tftypes.String, "test-secret"),
+				"ca_cert_pem": tftypes.NewValue(tftypes.String, "not a certificate"),
+			},
+			expectError: true,
+		},
+		"missing_ca_cert_file": {
+			values: map[string]tftypes.Value{
+				"base_url":     tftypes.NewValue(tftypes.String, "https://example.com"),
+				"api_key":      tftypes.NewValue(tftypes.String, "test-key"),
+				"api_secret":   tftypes.NewValue(tftypes.String, "test-secret"),
+				"ca_cert_file": tftypes.NewValue(tftypes.String, "/nonexistent/ca.pem"),
+			},
+			expectError: true,
+		},
+		"conflicting_ca_cert": {
+			values: map[string]tftypes.Value{
+				"base_url":     tftypes.NewValue(tftypes.String, "https://example.com"),
+				"api_key":      tftypes.NewValue(tftypes.String, "test-key"),
+				"api_secret":   tftypes.NewValue(tftypes.String, "test-secret"),
+				"ca_cert_pem":  tftypes.NewValue(tftypes.String, "pem"),
+				"ca_cert_file": tftypes.NewValue(tftypes.String, "/tmp/ca.pem"),
+			},
+			expectError: true,
+		},
+		"client_cert_without_key": {
+			values: map[string]tftypes.Value{
+				"base_url":    tftypes.NewValue(tftypes.String, "https://example.com"),
+				"api_key":     tftypes.NewValue(tftypes.String, "test-key"),
+				"api_secret":  tftypes.NewValue(tftypes.String, "test-secret"),
+				"client_cert": tftypes.NewValue(tftypes.String, "cert"),
+			},
+			expectError: true,
+		},
+		"server_version": {
+			values: map[string]tftypes.Value{
+				"base_url":       tftypes.NewValue(tftypes.String, "https://example.com"),
+				"api_key":        tftypes.NewValue(tftypes.String, "test-key"),
+				"api_secret":     tftypes.NewValue(tftypes.String, "test-secret"),
+				"server_version": tftypes.NewValue(tftypes.String, "1.15.0"),
+			},
+			expectError: false,
+		},
+		"invalid_server_version": {
+			values: map[string]tftypes.Value{
+				"base_url":       tftypes.NewValue(tftypes.String, "https://example.com"),
+				"api_key":        tftypes.NewValue(tftypes.String, "test-key"),
+				"api_secret":     tftypes.NewValue(tftypes.String, "test-secret"),
+				"server_version": tftypes.NewValue(tftypes.String, "latest"),
+			},
+			expectError: true,
+		},
 		"invalid_base_url": {
 			values: map[string]tftypes.Value{
 				"base_url":   tftypes.NewValue(tftypes.String, "not-a-url"),
@@ -98,52 +247,7 @@ func TestProvider_Configure(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			p := &kasmProvider{}
-			var diags diag.Diagnostics
-
-			schemaType := tftypes.Object{
-				AttributeTypes: map[string]tftypes.Type{
-					"base_url":   tftypes.String,
-					"api_key":    tftypes.String,
-					"api_secret": tftypes.String,
-					"insecure":   tftypes.Bool,
-				},
-			}
-
-			configValue := tftypes.NewValue(schemaType, tc.values)
-
-			resp := &provider.ConfigureResponse{
-				DataSourceData: nil,
-				ResourceData:   nil,
-				Diagnostics:    diags,
-			}
-
-			req := provider.ConfigureRequest{
-				Config: tfsdk.Config{
-					Raw: configValue,
-					Schema: schema.Schema{
-						Attributes: map[string]schema.Attribute{
-							"base_url": schema.StringAttribute{
-								Required: true,
-							},
-							"api_key": schema.StringAttribute{
-								Required:  true,
-								Sensitive: true,
-							},
-							"api_secret": schema.StringAttribute{
-								Required:  true,
-								Sensitive: true,
-							},
-							"insecure": schema.BoolAttribute{
-								Optional: true,
-							},
-						},
-					},
-				},
-			}
-
-			ctx := context.Background()
-			p.Configure(ctx, req, resp)
+			resp := configureProvider(t, &kasmProvider{}, tc.values)
 
 			if tc.expectError && !resp.Diagnostics.HasError() {
 				t.Error("expected error but got none")
@@ -155,6 +259,83 @@ func TestProvider_Configure(t *testing.T) {
 	}
 }
 
+// configureProvider runs Configure on p with values; attributes not given are
+// left null.
+func configureProvider(t *testing.T, p provider.Provider, values map[string]tftypes.Value) *provider.ConfigureResponse {
+	t.Helper()
+
+	ctx := context.Background()
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	schemaType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	config := make(map[string]tftypes.Value, len(schemaType.AttributeTypes))
+	for name, attrType := range schemaType.AttributeTypes {
+		config[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range values {
+		config[name] = value
+	}
+
+	req := provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Raw:    tftypes.NewValue(schemaType, config),
+			Schema: schemaResp.Schema,
+		},
+	}
+	resp := &provider.ConfigureResponse{Diagnostics: diag.Diagnostics{}}
+	p.Configure(ctx, req, resp)
+	return resp
+}
+
+func TestProvider_ClientMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var gotHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-WAF-Token")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"users": []}`))
+	}))
+	defer server.Close()
+
+	var observed int32
+	p := NewWithClientOptions(client.WithMiddleware(client.MetricsMiddleware(
+		func(req *http.Request, resp *http.Response, err error, duration time.Duration) {
+			atomic.AddInt32(&observed, 1)
+		},
+	)))
+
+	resp := configureProvider(t, p, map[string]tftypes.Value{
+		"base_url":   tftypes.NewValue(tftypes.String, server.URL),
+		"api_key":    tftypes.NewValue(tftypes.String, "test-key"),
+		"api_secret": tftypes.NewValue(tftypes.String, "test-secret"),
+		"headers": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"X-WAF-Token": tftypes.NewValue(tftypes.String, "token"),
+		}),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	c, ok := resp.ResourceData.(*client.Client)
+	if !ok {
+		t.Fatalf("expected *client.Client, got %T", resp.ResourceData)
+	}
+	// Ignore the server version probe made during Configure.
+	atomic.StoreInt32(&observed, 0)
+	if _, err := c.GetUsers(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotHeader != "token" {
+		t.Errorf("expected X-WAF-Token header to be sent, got %q", gotHeader)
+	}
+	if atomic.LoadInt32(&observed) != 1 {
+		t.Errorf("expected metrics middleware to observe 1 request, got %d", observed)
+	}
+}
+
 func TestProvider_Schema(t *testing.T) {
 	t.Parallel()
 
@@ -183,7 +364,7 @@ func TestProvider_Schema(t *testing.T) {
 	}
 
 	// Verify optional attributes
-	optionalAttrs := []string{"insecure"}
+	optionalAttrs := []string{"insecure", "requests_per_second", "burst", "max_retries", "retry_initial_interval", "retry_max_interval", "request_timeout", "headers", "ca_cert_pem", "ca_cert_file", "client_cert", "client_key", "proxy_url", "no_proxy"}
 	for _, attrName := range optionalAttrs {
 		attr := resp.Schema.Attributes[attrName]
 		if attr == nil {
@@ -196,7 +377,7 @@ func TestProvider_Schema(t *testing.T) {
 	}
 
 	// Verify sensitive attributes
-	sensitiveAttrs := []string{"api_key", "api_secret"}
+	sensitiveAttrs := []string{"api_key", "api_secret", "headers", "client_key"}
 	for _, attrName := range sensitiveAttrs {
 		attr := resp.Schema.Attributes[attrName]
 		if attr == nil {
@@ -221,6 +402,38 @@ func TestProvider_Metadata(t *testing.T) {
 	}
 }
 
+func TestProvider_DetectsServerVersion(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/public/get_system_info" {
+			w.Write([]byte(`{"system_info": {"server_version": "1.14.0", "build_id": "abc"}}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	resp := configureProvider(t, &kasmProvider{}, map[string]tftypes.Value{
+		"base_url":   tftypes.NewValue(tftypes.String, server.URL),
+		"api_key":    tftypes.NewValue(tftypes.String, "test-key"),
+		"api_secret": tftypes.NewValue(tftypes.String, "test-secret"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	c := resp.ResourceData.(*client.Client)
+	info := c.ServerInfo()
+	if info.Version.String() != "1.14.0" || info.BuildID != "abc" {
+		t.Errorf("expected version 1.14.0 build abc, got %s build %s", info.Version, info.BuildID)
+	}
+	if c.SupportsFeature(client.FeatureEnableSharing) {
+		t.Errorf("expected %s to be unsupported on 1.14.0", client.FeatureEnableSharing.Name)
+	}
+}
+
 func TestProvider_Resources(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,348 @@
+package autoscale_config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-kasm/internal/client"
+	"terraform-provider-kasm/internal/validators"
+)
+
+// defaultDownscaleBackoff is Kasm's default delay before an idle agent is
+// destroyed.
+const defaultDownscaleBackoff = "15m"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &autoScaleConfigResource{}
+	_ resource.ResourceWithConfigure   = &autoScaleConfigResource{}
+	_ resource.ResourceWithImportState = &autoScaleConfigResource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func New() resource.Resource {
+	return &autoScaleConfigResource{}
+}
+
+// autoScaleConfigResource manages a Kasm autoscale config.
+type autoScaleConfigResource struct {
+	client *client.Client
+}
+
+// autoScaleConfigResourceModel maps the resource schema data.
+type autoScaleConfigResourceModel struct {
+	ID                 types.String  `tfsdk:"id"`
+	Name               types.String  `tfsdk:"name"`
+	ZoneID             types.String  `tfsdk:"zone_id"`
+	Enabled            types.Bool    `tfsdk:"enabled"`
+	VMProviderConfigID types.String  `tfsdk:"vm_provider_config_id"`
+	StandbyCores       types.Float64 `tfsdk:"standby_cores"`
+	StandbyMemoryMB    types.Int64   `tfsdk:"standby_memory_mb"`
+	StandbyGPUs        types.Int64   `tfsdk:"standby_gpus"`
+	DownscaleBackoff   types.String  `tfsdk:"downscale_backoff"`
+	AgentCoresOverride types.Float64 `tfsdk:"agent_cores_override"`
+}
+
+// Metadata returns the resource type name.
+func (r *autoScaleConfigResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_autoscale_config"
+}
+
+// Schema defines the schema for the resource.
+func (r *autoScaleConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Kasm autoscale config, which keeps spare agent capacity in a zone by launching and " +
+			"destroying VMs through a VM provider config. Autoscaling requires a license with the auto_scaling feature.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the autoscale config.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the autoscale config.",
+				Required:    true,
+			},
+			"zone_id": schema.StringAttribute{
+				Description: "The ID of the zone agents are launched in.",
+				Required:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether agents are launched and destroyed. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"vm_provider_config_id": schema.StringAttribute{
+				Description: "The ID of the VM provider config agents are launched with, such as a kasm_aws_vm_provider_config.",
+				Optional:    true,
+			},
+			"standby_cores": schema.Float64Attribute{
+				Description: "CPU cores kept free across the zone's agents. Defaults to 0.",
+				Optional:    true,
+				Computed:    true,
+				Default:     float64default.StaticFloat64(0),
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"standby_memory_mb": schema.Int64Attribute{
+				Description: "Memory, in MB, kept free across the zone's agents. Defaults to 0.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"standby_gpus": schema.Int64Attribute{
+				Description: "GPUs kept free across the zone's agents. Defaults to 0.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"downscale_backoff": schema.StringAttribute{
+				Description: fmt.Sprintf("How long an idle agent is kept before it is destroyed, as a duration such as \"10m\". Defaults to %s.", defaultDownscaleBackoff),
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(defaultDownscaleBackoff),
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"agent_cores_override": schema.Float64Attribute{
+				Description: "The number of cores launched agents report, to over- or under-provision sessions. " +
+					"The cores of the VM are used when not set. Must be greater than 0.",
+				Optional: true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+					float64validator.NoneOf(0),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *autoScaleConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates the autoscale config.
+func (r *autoScaleConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan autoScaleConfigResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := expandAutoScaleConfig(plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("downscale_backoff"), "Invalid Downscale Backoff", err.Error())
+		return
+	}
+
+	created, err := r.client.CreateAutoScaleConfig(ctx, config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Autoscale Config",
+			fmt.Sprintf("Could not create autoscale config %s: %s", plan.Name.ValueString(), err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(created.AutoScaleConfigID)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the autoscale config from the server.
+func (r *autoScaleConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state autoScaleConfigResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetAutoScaleConfig(ctx, state.ID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Debug(ctx, "Autoscale config not found, removing from state", map[string]interface{}{
+				"id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Autoscale Config",
+			fmt.Sprintf("Could not read autoscale config %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	state.Name = types.StringValue(config.Name)
+	state.ZoneID = types.StringValue(config.ZoneID)
+	state.Enabled = types.BoolValue(config.Enabled)
+	if config.VMProviderConfigID != "" || !state.VMProviderConfigID.IsNull() {
+		state.VMProviderConfigID = types.StringValue(config.VMProviderConfigID)
+	}
+	state.StandbyCores = types.Float64Value(config.StandbyCores)
+	state.StandbyMemoryMB = types.Int64Value(int64(config.StandbyMemoryMB))
+	state.StandbyGPUs = types.Int64Value(int64(config.StandbyGPUs))
+	state.DownscaleBackoff = flattenBackoff(state.DownscaleBackoff, config.DownscaleBackoff)
+	// Kasm reports 0 when there is no override, which cannot be configured.
+	state.AgentCoresOverride = types.Float64Null()
+	if config.AgentCoresOverride != 0 {
+		state.AgentCoresOverride = types.Float64Value(config.AgentCoresOverride)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the autoscale config in place.
+func (r *autoScaleConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan autoScaleConfigResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := expandAutoScaleConfig(plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("downscale_backoff"), "Invalid Downscale Backoff", err.Error())
+		return
+	}
+	config.AutoScaleConfigID = plan.ID.ValueString()
+
+	if _, err := r.client.UpdateAutoScaleConfig(ctx, config); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Autoscale Config",
+			fmt.Sprintf("Could not update autoscale config %s: %s", plan.ID.ValueString(), err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the autoscale config.
+func (r *autoScaleConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state autoScaleConfigResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteAutoScaleConfig(ctx, state.ID.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Autoscale Config",
+			fmt.Sprintf("Could not delete autoscale config %s: %s", state.ID.ValueString(), err),
+		)
+	}
+}
+
+// ImportState imports an autoscale config by ID or by name.
+func (r *autoScaleConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if name, ok := strings.CutPrefix(req.ID, "name:"); ok {
+		configs, err := r.client.GetAutoScaleConfigs(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Autoscale Config",
+				fmt.Sprintf("Could not list autoscale configs: %s", err),
+			)
+			return
+		}
+
+		id = ""
+		for _, config := range configs {
+			if config.Name == name {
+				id = config.AutoScaleConfigID
+				break
+			}
+		}
+		if id == "" {
+			resp.Diagnostics.AddError(
+				"Autoscale Config Not Found",
+				fmt.Sprintf("Could not find an autoscale config named %s", name),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// expandAutoScaleConfig builds the API object from the plan.
+func expandAutoScaleConfig(plan autoScaleConfigResourceModel) (*client.AutoScaleConfig, error) {
+	backoff, err := time.ParseDuration(plan.DownscaleBackoff.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	return &client.AutoScaleConfig{
+		Name:               plan.Name.ValueString(),
+		ZoneID:             plan.ZoneID.ValueString(),
+		Enabled:            plan.Enabled.ValueBool(),
+		VMProviderConfigID: plan.VMProviderConfigID.ValueString(),
+		StandbyCores:       plan.StandbyCores.ValueFloat64(),
+		StandbyMemoryMB:    int(plan.StandbyMemoryMB.ValueInt64()),
+		StandbyGPUs:        int(plan.StandbyGPUs.ValueInt64()),
+		DownscaleBackoff:   int(backoff / time.Second),
+		AgentCoresOverride: plan.AgentCoresOverride.ValueFloat64(),
+	}, nil
+}
+
+// flattenBackoff keeps the configured spelling of the backoff, such as "15m"
+// rather than "15m0s", when it matches the server's value in seconds.
+func flattenBackoff(current types.String, seconds int) types.String {
+	server := time.Duration(seconds) * time.Second
+	if configured, err := time.ParseDuration(current.ValueString()); err == nil && configured.Truncate(time.Second) == server {
+		return current
+	}
+	return types.StringValue(server.String())
+}
@@ -0,0 +1,28 @@
+//go:build unit
+// +build unit
+
+package autoscale_config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoScaleConfigResource_Metadata(t *testing.T) {
+	r := New()
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "kasm"}, resp)
+
+	assert.Equal(t, "kasm_autoscale_config", resp.TypeName)
+}
+
+func TestFlattenBackoff(t *testing.T) {
+	assert.Equal(t, types.StringValue("15m"), flattenBackoff(types.StringValue("15m"), 900))
+	assert.Equal(t, types.StringValue("10m0s"), flattenBackoff(types.StringValue("15m"), 600))
+	assert.Equal(t, types.StringValue("15m0s"), flattenBackoff(types.StringNull(), 900))
+}
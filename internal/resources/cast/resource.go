@@ -479,7 +479,7 @@ func (r *castConfigResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	// Create the resource
-	createdConfig, err := r.client.CreateCastConfig(config)
+	createdConfig, err := r.client.CreateCastConfig(ctx, config)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating cast config",
@@ -489,7 +489,7 @@ func (r *castConfigResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	// Convert response to model
-	state := r.toResourceModel(createdConfig)
+	state := r.toResourceModel(ctx, createdConfig)
 
 	// Set state
 	diags = resp.State.Set(ctx, state)
@@ -506,7 +506,7 @@ func (r *castConfigResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Get cast config from API
-	castConfig, err := r.client.GetCastConfig(state.ID.ValueString())
+	castConfig, err := r.client.GetCastConfig(ctx, state.ID.ValueString())
 	if err != nil {
 		var notFoundErr *client.NotFoundError
 		if errors.As(err, &notFoundErr) {
@@ -522,7 +522,7 @@ func (r *castConfigResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Map response to state
-	updatedState := r.toResourceModel(castConfig)
+	updatedState := r.toResourceModel(ctx, castConfig)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, updatedState)
@@ -608,7 +608,7 @@ func (r *castConfigResource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 
 	// Update the resource
-	updatedConfig, err := r.client.UpdateCastConfig(config)
+	updatedConfig, err := r.client.UpdateCastConfig(ctx, config)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating cast config",
@@ -618,7 +618,7 @@ func (r *castConfigResource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 
 	// Convert response to model
-	newState := r.toResourceModel(updatedConfig)
+	newState := r.toResourceModel(ctx, updatedConfig)
 
 	// Set state
 	diags = resp.State.Set(ctx, newState)
@@ -633,7 +633,7 @@ func (r *castConfigResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	err := r.client.DeleteCastConfig(state.ID.ValueString())
+	err := r.client.DeleteCastConfig(ctx, state.ID.ValueString())
 	if err != nil {
 		var notFoundErr *client.NotFoundError
 		var unauthorizedErr *client.UnauthorizedError
@@ -667,7 +667,7 @@ func (r *castConfigResource) ImportState(ctx context.Context, req resource.Impor
 }
 
 // Helper functions for model conversion
-func (r *castConfigResource) toAPIModel(plan *CastConfigResourceModel) *client.CastConfig {
+func (r *castConfigResource) toAPIModel(ctx context.Context, plan *CastConfigResourceModel) *client.CastConfig {
 	config := &client.CastConfig{
 		CastingConfigName:     plan.Name.ValueString(),
 		ImageID:               plan.ImageID.ValueString(),
@@ -705,7 +705,7 @@ func (r *castConfigResource) toAPIModel(plan *CastConfigResourceModel) *client.C
 	// Handle optional arrays properly
 	if !plan.AllowedReferrers.IsNull() {
 		var referrers []string
-		plan.AllowedReferrers.ElementsAs(context.Background(), &referrers, false)
+		plan.AllowedReferrers.ElementsAs(ctx, &referrers, false)
 		config.AllowedReferrers = referrers
 	} else {
 		config.AllowedReferrers = []string{} // Always set an empty array if null
@@ -714,7 +714,7 @@ func (r *castConfigResource) toAPIModel(plan *CastConfigResourceModel) *client.C
 	return config
 }
 
-func (r *castConfigResource) toResourceModel(api *client.CastConfig) *CastConfigResourceModel {
+func (r *castConfigResource) toResourceModel(ctx context.Context, api *client.CastConfig) *CastConfigResourceModel {
 	model := &CastConfigResourceModel{
 		// Required fields always get set
 		ID:      types.StringValue(api.ID),
@@ -748,7 +748,7 @@ func (r *castConfigResource) toResourceModel(api *client.CastConfig) *CastConfig
 
 	// Handle list field
 	if api.AllowedReferrers != nil {
-		referrers, diags := types.ListValueFrom(context.Background(), types.StringType, api.AllowedReferrers)
+		referrers, diags := types.ListValueFrom(ctx, types.StringType, api.AllowedReferrers)
 		if !diags.HasError() {
 			model.AllowedReferrers = referrers
 		} else {
@@ -0,0 +1,269 @@
+package egress_credential
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-kasm/internal/client"
+	"terraform-provider-kasm/internal/validators"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &egressCredentialResource{}
+	_ resource.ResourceWithConfigure        = &egressCredentialResource{}
+	_ resource.ResourceWithImportState      = &egressCredentialResource{}
+	_ resource.ResourceWithConfigValidators = &egressCredentialResource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func New() resource.Resource {
+	return &egressCredentialResource{}
+}
+
+// egressCredentialResource manages the secrets sessions use to connect
+// through an egress provider.
+type egressCredentialResource struct {
+	client *client.Client
+}
+
+// egressCredentialResourceModel maps the resource schema data.
+type egressCredentialResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	EgressProviderID types.String `tfsdk:"egress_provider_id"`
+	Name             types.String `tfsdk:"name"`
+	WireGuardConfig  types.String `tfsdk:"wireguard_config"`
+	OpenVPNConfig    types.String `tfsdk:"openvpn_config"`
+	Username         types.String `tfsdk:"username"`
+	Password         types.String `tfsdk:"password"`
+}
+
+// Metadata returns the resource type name.
+func (r *egressCredentialResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_egress_credential"
+}
+
+// Schema defines the schema for the resource.
+func (r *egressCredentialResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the credentials sessions use to connect through a Kasm egress provider: a WireGuard " +
+			"configuration, an OpenVPN profile, or a username and password. Configuration files are checked at plan time. " +
+			"Kasm does not return the secrets, so changes made outside Terraform are not detected.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the egress credential.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"egress_provider_id": schema.StringAttribute{
+				Description: "The ID of the egress provider the credential belongs to. Changing this forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the credential.",
+				Required:    true,
+			},
+			"wireguard_config": schema.StringAttribute{
+				Description: "A WireGuard configuration in wg-quick format. Cannot be combined with the other credentials.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					validators.WireGuardConfig(),
+					stringvalidator.ConflictsWith(
+						path.MatchRoot("openvpn_config"),
+						path.MatchRoot("username"),
+						path.MatchRoot("password"),
+					),
+				},
+			},
+			"openvpn_config": schema.StringAttribute{
+				Description: "An OpenVPN client profile, with any certificates and keys inline.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					validators.OpenVPNConfig(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "The username to authenticate with, for providers that use username and password authentication.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("password")),
+				},
+			},
+			"password": schema.StringAttribute{
+				Description: "The password to authenticate with.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("username")),
+				},
+			},
+		},
+	}
+}
+
+// ConfigValidators requires at least one kind of credential.
+func (r *egressCredentialResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("wireguard_config"),
+			path.MatchRoot("openvpn_config"),
+			path.MatchRoot("username"),
+		),
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *egressCredentialResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates the egress credential.
+func (r *egressCredentialResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan egressCredentialResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credential, err := r.client.CreateEgressCredential(ctx, expandEgressCredential(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Egress Credential",
+			fmt.Sprintf("Could not create egress credential %s: %s", plan.Name.ValueString(), err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(credential.EgressCredentialID)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the name, provider and username. The secrets are kept as
+// applied because the server does not return them.
+func (r *egressCredentialResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state egressCredentialResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credential, err := r.client.GetEgressCredential(ctx, state.ID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Debug(ctx, "Egress credential not found, removing from state", map[string]interface{}{
+				"id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Egress Credential",
+			fmt.Sprintf("Could not read egress credential %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	state.EgressProviderID = types.StringValue(credential.EgressProviderID)
+	state.Name = types.StringValue(credential.Name)
+	if credential.Username != "" || !state.Username.IsNull() {
+		state.Username = types.StringValue(credential.Username)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the egress credential in place.
+func (r *egressCredentialResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan egressCredentialResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credential := expandEgressCredential(plan)
+	credential.EgressCredentialID = plan.ID.ValueString()
+	if _, err := r.client.UpdateEgressCredential(ctx, credential); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Egress Credential",
+			fmt.Sprintf("Could not update egress credential %s: %s", plan.ID.ValueString(), err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the egress credential.
+func (r *egressCredentialResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state egressCredentialResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteEgressCredential(ctx, state.ID.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Egress Credential",
+			fmt.Sprintf("Could not delete egress credential %s: %s", state.ID.ValueString(), err),
+		)
+	}
+}
+
+// ImportState imports an egress credential by ID. The secrets are not
+// imported and must be set in configuration.
+func (r *egressCredentialResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// expandEgressCredential builds the API object from the plan.
+func expandEgressCredential(plan egressCredentialResourceModel) *client.EgressCredential {
+	return &client.EgressCredential{
+		EgressProviderID: plan.EgressProviderID.ValueString(),
+		Name:             plan.Name.ValueString(),
+		WireGuardConfig:  plan.WireGuardConfig.ValueString(),
+		OpenVPNConfig:    plan.OpenVPNConfig.ValueString(),
+		Username:         plan.Username.ValueString(),
+		Password:         plan.Password.ValueString(),
+	}
+}
@@ -0,0 +1,70 @@
+//go:build unit
+// +build unit
+
+package egress_credential
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-kasm/internal/testutil"
+)
+
+func TestEgressCredentialResource_Metadata(t *testing.T) {
+	r := New()
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "kasm"}, resp)
+
+	assert.Equal(t, "kasm_egress_credential", resp.TypeName)
+}
+
+func TestEgressCredentialResource_Schema(t *testing.T) {
+	r := New()
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	for _, name := range []string{"wireguard_config", "openvpn_config", "password"} {
+		assert.True(t, resp.Schema.Attributes[name].IsSensitive(), name)
+	}
+	assert.False(t, resp.Schema.Attributes["username"].IsSensitive())
+}
+
+func TestEgressCredentialResource_ConfigValidators(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+
+	config := func(openvpn, username interface{}) tfsdk.Config {
+		return testutil.Config(ctx, r, testutil.Attrs{
+			"egress_provider_id": "ep1",
+			"name":               "office",
+			"openvpn_config":     openvpn,
+			"username":           username,
+		})
+	}
+
+	testCases := []struct {
+		name        string
+		config      tfsdk.Config
+		expectError bool
+	}{
+		{name: "OpenVPN profile", config: config("dev tun\nremote vpn.example.com\n", nil)},
+		{name: "Username", config: config(nil, "alice")},
+		{name: "No credential", config: config(nil, nil), expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &resource.ValidateConfigResponse{}
+			for _, v := range r.(resource.ResourceWithConfigValidators).ConfigValidators(ctx) {
+				v.ValidateResource(ctx, resource.ValidateConfigRequest{Config: tc.config}, resp)
+			}
+			assert.Equal(t, tc.expectError, resp.Diagnostics.HasError())
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package egress_gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-kasm/internal/client"
+	"terraform-provider-kasm/internal/schemautil"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &egressGatewayResource{}
+	_ resource.ResourceWithConfigure   = &egressGatewayResource{}
+	_ resource.ResourceWithImportState = &egressGatewayResource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func New() resource.Resource {
+	return &egressGatewayResource{}
+}
+
+// egressGatewayResource manages a gateway of a Kasm egress provider.
+type egressGatewayResource struct {
+	client *client.Client
+}
+
+// egressGatewayResourceModel maps the resource schema data.
+type egressGatewayResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	EgressProviderID types.String `tfsdk:"egress_provider_id"`
+	Name             types.String `tfsdk:"name"`
+	Country          types.String `tfsdk:"country"`
+	City             types.String `tfsdk:"city"`
+	Enabled          types.Bool   `tfsdk:"enabled"`
+	Config           types.String `tfsdk:"config"`
+}
+
+// Metadata returns the resource type name.
+func (r *egressGatewayResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_egress_gateway"
+}
+
+// Schema defines the schema for the resource.
+func (r *egressGatewayResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a gateway of a Kasm egress provider, such as one WireGuard peer or OpenVPN server.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the egress gateway.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"egress_provider_id": schema.StringAttribute{
+				Description: "The ID of the egress provider the gateway belongs to. Changing this forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the gateway.",
+				Required:    true,
+			},
+			"country": schema.StringAttribute{
+				Description: "The country the gateway is located in, shown to users choosing an egress.",
+				Optional:    true,
+			},
+			"city": schema.StringAttribute{
+				Description: "The city the gateway is located in, shown to users choosing an egress.",
+				Optional:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether sessions can use the gateway. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"config": schema.StringAttribute{
+				Description: "The connection configuration, such as the contents of a WireGuard .conf or OpenVPN .ovpn file.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *egressGatewayResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates the egress gateway.
+func (r *egressGatewayResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan egressGatewayResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	gateway, err := r.client.CreateEgressGateway(ctx, expandEgressGateway(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Egress Gateway",
+			fmt.Sprintf("Could not create egress gateway %s: %s", plan.Name.ValueString(), err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(gateway.EgressGatewayID)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the egress gateway from the server.
+func (r *egressGatewayResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state egressGatewayResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	gateway, err := r.client.GetEgressGateway(ctx, state.ID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Debug(ctx, "Egress gateway not found, removing from state", map[string]interface{}{
+				"id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Egress Gateway",
+			fmt.Sprintf("Could not read egress gateway %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	state.EgressProviderID = types.StringValue(gateway.EgressProviderID)
+	state.Name = types.StringValue(gateway.Name)
+	state.Enabled = types.BoolValue(gateway.Enabled)
+	state.Country = schemautil.OptionalString(state.Country, gateway.Country)
+	state.City = schemautil.OptionalString(state.City, gateway.City)
+	// The server may not return the configuration; keep what was applied.
+	if gateway.Config != "" {
+		state.Config = types.StringValue(gateway.Config)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the egress gateway in place.
+func (r *egressGatewayResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan egressGatewayResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	gateway := expandEgressGateway(plan)
+	gateway.EgressGatewayID = plan.ID.ValueString()
+	if _, err := r.client.UpdateEgressGateway(ctx, gateway); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Egress Gateway",
+			fmt.Sprintf("Could not update egress gateway %s: %s", plan.ID.ValueString(), err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the egress gateway.
+func (r *egressGatewayResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state egressGatewayResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteEgressGateway(ctx, state.ID.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Egress Gateway",
+			fmt.Sprintf("Could not delete egress gateway %s: %s", state.ID.ValueString(), err),
+		)
+	}
+}
+
+// ImportState imports an egress gateway by ID, or by
+// "<egress_provider_id>:<name>".
+func (r *egressGatewayResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	providerID, name, byName := strings.Cut(req.ID, ":")
+	if !byName {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+		return
+	}
+
+	gateways, err := r.client.GetEgressGateways(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Egress Gateway",
+			fmt.Sprintf("Could not list egress gateways: %s", err),
+		)
+		return
+	}
+
+	for _, gateway := range gateways {
+		if gateway.EgressProviderID == providerID && gateway.Name == name {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), gateway.EgressGatewayID)...)
+			return
+		}
+	}
+
+	resp.Diagnostics.AddError(
+		"Egress Gateway Not Found",
+		fmt.Sprintf("Could not find egress gateway %s of egress provider %s", name, providerID),
+	)
+}
+
+// expandEgressGateway builds the API object from the plan.
+func expandEgressGateway(plan egressGatewayResourceModel) *client.EgressGateway {
+	return &client.EgressGateway{
+		EgressProviderID: plan.EgressProviderID.ValueString(),
+		Name:             plan.Name.ValueString(),
+		Country:          plan.Country.ValueString(),
+		City:             plan.City.ValueString(),
+		Enabled:          plan.Enabled.ValueBool(),
+		Config:           plan.Config.ValueString(),
+	}
+}
@@ -0,0 +1,272 @@
+package egress_mapping
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-kasm/internal/client"
+	"terraform-provider-kasm/internal/schemautil"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &egressMappingResource{}
+	_ resource.ResourceWithConfigure        = &egressMappingResource{}
+	_ resource.ResourceWithImportState      = &egressMappingResource{}
+	_ resource.ResourceWithConfigValidators = &egressMappingResource{}
+	_ resource.ResourceWithValidateConfig   = &egressMappingResource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func New() resource.Resource {
+	return &egressMappingResource{}
+}
+
+// egressMappingResource maps an egress provider to a user, group or
+// workspace image.
+type egressMappingResource struct {
+	client *client.Client
+}
+
+// egressMappingResourceModel maps the resource schema data.
+type egressMappingResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	EgressProviderID types.String `tfsdk:"egress_provider_id"`
+	UserID           types.String `tfsdk:"user_id"`
+	GroupID          types.String `tfsdk:"group_id"`
+	ImageID          types.String `tfsdk:"image_id"`
+	Default          types.Bool   `tfsdk:"default"`
+	Allowed          types.Bool   `tfsdk:"allowed"`
+}
+
+// Metadata returns the resource type name.
+func (r *egressMappingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_egress_mapping"
+}
+
+// Schema defines the schema for the resource.
+func (r *egressMappingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	replace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		Description: "Maps a Kasm egress provider to a user, a group or a workspace image. " +
+			"Exactly one of user_id, group_id and image_id must be set.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the egress provider mapping.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"egress_provider_id": schema.StringAttribute{
+				Description:   "The ID of the egress provider. Changing this forces a new resource.",
+				Required:      true,
+				PlanModifiers: replace,
+			},
+			"user_id": schema.StringAttribute{
+				Description:   "The ID of the user the provider is mapped to. Changing this forces a new resource.",
+				Optional:      true,
+				PlanModifiers: replace,
+			},
+			"group_id": schema.StringAttribute{
+				Description:   "The ID of the group the provider is mapped to. Changing this forces a new resource.",
+				Optional:      true,
+				PlanModifiers: replace,
+			},
+			"image_id": schema.StringAttribute{
+				Description:   "The ID of the workspace image the provider is mapped to. Changing this forces a new resource.",
+				Optional:      true,
+				PlanModifiers: replace,
+			},
+			"default": schema.BoolAttribute{
+				Description: "Whether the provider is preselected when a session is launched. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"allowed": schema.BoolAttribute{
+				Description: "Whether the target may use the provider. Set to false to withdraw access granted by a " +
+					"less specific mapping, such as one user's access granted through a group. Defaults to true.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+// ConfigValidators requires exactly one mapping target.
+func (r *egressMappingResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("user_id"),
+			path.MatchRoot("group_id"),
+			path.MatchRoot("image_id"),
+		),
+	}
+}
+
+// ValidateConfig rejects a default mapping that does not allow the provider.
+func (r *egressMappingResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config egressMappingResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Default.ValueBool() && !config.Allowed.IsNull() && !config.Allowed.IsUnknown() && !config.Allowed.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default"),
+			"Invalid Egress Mapping",
+			"A mapping cannot make a provider the default while setting allowed to false.",
+		)
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *egressMappingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates the egress provider mapping.
+func (r *egressMappingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan egressMappingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mapping, err := r.client.CreateEgressMapping(ctx, expandEgressMapping(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Egress Mapping",
+			fmt.Sprintf("Could not map egress provider %s: %s", plan.EgressProviderID.ValueString(), err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(mapping.EgressMappingID)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the egress provider mapping from the server.
+func (r *egressMappingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state egressMappingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mapping, err := r.client.GetEgressMapping(ctx, state.ID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Debug(ctx, "Egress mapping not found, removing from state", map[string]interface{}{
+				"id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Egress Mapping",
+			fmt.Sprintf("Could not read egress mapping %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	state.EgressProviderID = types.StringValue(mapping.EgressProviderID)
+	state.UserID = schemautil.OptionalString(state.UserID, mapping.UserID)
+	state.GroupID = schemautil.OptionalString(state.GroupID, mapping.GroupID)
+	state.ImageID = schemautil.OptionalString(state.ImageID, mapping.ImageID)
+	state.Default = types.BoolValue(mapping.Default)
+	state.Allowed = types.BoolValue(mapping.Allowed)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the flags of the egress provider mapping in place.
+func (r *egressMappingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan egressMappingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mapping := expandEgressMapping(plan)
+	mapping.EgressMappingID = plan.ID.ValueString()
+	if _, err := r.client.UpdateEgressMapping(ctx, mapping); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Egress Mapping",
+			fmt.Sprintf("Could not update egress mapping %s: %s", plan.ID.ValueString(), err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the egress provider mapping.
+func (r *egressMappingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state egressMappingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteEgressMapping(ctx, state.ID.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Egress Mapping",
+			fmt.Sprintf("Could not delete egress mapping %s: %s", state.ID.ValueString(), err),
+		)
+	}
+}
+
+// ImportState imports an egress provider mapping by ID.
+func (r *egressMappingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// expandEgressMapping builds the API object from the plan.
+func expandEgressMapping(plan egressMappingResourceModel) *client.EgressMapping {
+	return &client.EgressMapping{
+		EgressProviderID: plan.EgressProviderID.ValueString(),
+		UserID:           plan.UserID.ValueString(),
+		GroupID:          plan.GroupID.ValueString(),
+		ImageID:          plan.ImageID.ValueString(),
+		Default:          plan.Default.ValueBool(),
+		Allowed:          plan.Allowed.ValueBool(),
+	}
+}
@@ -0,0 +1,66 @@
+//go:build unit
+// +build unit
+
+package egress_mapping
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-kasm/internal/testutil"
+)
+
+func TestEgressMappingResource_Metadata(t *testing.T) {
+	r := New()
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "kasm"}, resp)
+
+	assert.Equal(t, "kasm_egress_mapping", resp.TypeName)
+}
+
+func TestEgressMappingResource_ValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+
+	config := func(userID, groupID interface{}, isDefault, allowed interface{}) tfsdk.Config {
+		return testutil.Config(ctx, r, testutil.Attrs{
+			"egress_provider_id": "ep1",
+			"user_id":            userID,
+			"group_id":           groupID,
+			"default":            isDefault,
+			"allowed":            allowed,
+		})
+	}
+
+	testCases := []struct {
+		name        string
+		config      tfsdk.Config
+		expectError bool
+	}{
+		{name: "Group default", config: config(nil, "g1", true, nil)},
+		{name: "User opt out", config: config("u1", nil, nil, false)},
+		{name: "No target", config: config(nil, nil, nil, nil), expectError: true},
+		{name: "Two targets", config: config("u1", "g1", nil, nil), expectError: true},
+		{name: "Default but not allowed", config: config("u1", nil, true, false), expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := resource.ValidateConfigRequest{Config: tc.config}
+			resp := &resource.ValidateConfigResponse{}
+			r.(resource.ResourceWithValidateConfig).ValidateConfig(ctx, req, resp)
+			diags := resp.Diagnostics
+			for _, v := range r.(resource.ResourceWithConfigValidators).ConfigValidators(ctx) {
+				resp := &resource.ValidateConfigResponse{}
+				v.ValidateResource(ctx, req, resp)
+				diags.Append(resp.Diagnostics...)
+			}
+			assert.Equal(t, tc.expectError, diags.HasError())
+		})
+	}
+}
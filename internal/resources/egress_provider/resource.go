@@ -0,0 +1,291 @@
+package egress_provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-kasm/internal/client"
+	"terraform-provider-kasm/internal/schemautil"
+	"terraform-provider-kasm/internal/validators"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &egressProviderResource{}
+	_ resource.ResourceWithConfigure      = &egressProviderResource{}
+	_ resource.ResourceWithImportState    = &egressProviderResource{}
+	_ resource.ResourceWithValidateConfig = &egressProviderResource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func New() resource.Resource {
+	return &egressProviderResource{}
+}
+
+// egressProviderResource manages a Kasm egress provider.
+type egressProviderResource struct {
+	client *client.Client
+}
+
+// egressProviderResourceModel maps the resource schema data.
+type egressProviderResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+	Description types.String `tfsdk:"description"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+	EgressImage types.String `tfsdk:"egress_image"`
+	Config      types.String `tfsdk:"config"`
+}
+
+// Metadata returns the resource type name.
+func (r *egressProviderResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_egress_provider"
+}
+
+// Schema defines the schema for the resource.
+func (r *egressProviderResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Kasm egress provider, a VPN service that session traffic can be routed through. " +
+			"The endpoints of the service are managed with kasm_egress_gateway.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the egress provider.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the egress provider.",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: fmt.Sprintf("The type of the egress provider, one of %s. Changing this forces a new resource.",
+					strings.Join(client.EgressProviderTypes, ", ")),
+				Required: true,
+				Validators: []validator.String{
+					validators.StringOneOf(client.EgressProviderTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "A description of the egress provider.",
+				Optional:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether sessions can use the egress provider. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"egress_image": schema.StringAttribute{
+				Description: "The container image that runs the egress connection. Required for custom providers; " +
+					"overrides the built-in image for wireguard and openvpn.",
+				Optional: true,
+			},
+			"config": schema.StringAttribute{
+				Description: "Configuration shared by every gateway of the provider.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+// ValidateConfig requires an image for custom providers.
+func (r *egressProviderResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config egressProviderResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Type.ValueString() == client.EgressProviderTypeCustom && config.EgressImage.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("egress_image"),
+			"Missing Egress Image",
+			"egress_image is required when type is custom.",
+		)
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *egressProviderResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates the egress provider.
+func (r *egressProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan egressProviderResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	provider, err := r.client.CreateEgressProvider(ctx, expandEgressProvider(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Egress Provider",
+			fmt.Sprintf("Could not create egress provider %s: %s", plan.Name.ValueString(), err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(provider.EgressProviderID)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the egress provider from the server.
+func (r *egressProviderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state egressProviderResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	provider, err := r.client.GetEgressProvider(ctx, state.ID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Debug(ctx, "Egress provider not found, removing from state", map[string]interface{}{
+				"id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Egress Provider",
+			fmt.Sprintf("Could not read egress provider %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	state.Name = types.StringValue(provider.Name)
+	state.Type = types.StringValue(provider.Type)
+	state.Enabled = types.BoolValue(provider.Enabled)
+	state.Description = schemautil.OptionalString(state.Description, provider.Description)
+	state.EgressImage = schemautil.OptionalString(state.EgressImage, provider.Image)
+	// The server may not return the configuration; keep what was applied.
+	if provider.Config != "" {
+		state.Config = types.StringValue(provider.Config)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the egress provider in place.
+func (r *egressProviderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan egressProviderResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	provider := expandEgressProvider(plan)
+	provider.EgressProviderID = plan.ID.ValueString()
+	if _, err := r.client.UpdateEgressProvider(ctx, provider); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Egress Provider",
+			fmt.Sprintf("Could not update egress provider %s: %s", plan.ID.ValueString(), err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the egress provider.
+func (r *egressProviderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state egressProviderResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteEgressProvider(ctx, state.ID.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Egress Provider",
+			fmt.Sprintf("Could not delete egress provider %s: %s", state.ID.ValueString(), err),
+		)
+	}
+}
+
+// ImportState imports an egress provider by ID or by name.
+func (r *egressProviderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if name, ok := strings.CutPrefix(req.ID, "name:"); ok {
+		providers, err := r.client.GetEgressProviders(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Egress Provider",
+				fmt.Sprintf("Could not list egress providers: %s", err),
+			)
+			return
+		}
+
+		id = ""
+		for _, provider := range providers {
+			if provider.Name == name {
+				id = provider.EgressProviderID
+				break
+			}
+		}
+		if id == "" {
+			resp.Diagnostics.AddError(
+				"Egress Provider Not Found",
+				fmt.Sprintf("Could not find egress provider with name: %s", name),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// expandEgressProvider builds the API object from the plan.
+func expandEgressProvider(plan egressProviderResourceModel) *client.EgressProvider {
+	return &client.EgressProvider{
+		Name:        plan.Name.ValueString(),
+		Type:        plan.Type.ValueString(),
+		Description: plan.Description.ValueString(),
+		Enabled:     plan.Enabled.ValueBool(),
+		Image:       plan.EgressImage.ValueString(),
+		Config:      plan.Config.ValueString(),
+	}
+}
@@ -0,0 +1,55 @@
+//go:build unit
+// +build unit
+
+package egress_provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-kasm/internal/testutil"
+)
+
+func TestEgressProviderResource_Metadata(t *testing.T) {
+	r := New()
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "kasm"}, resp)
+
+	assert.Equal(t, "kasm_egress_provider", resp.TypeName)
+}
+
+func TestEgressProviderResource_ValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+
+	config := func(providerType string, image interface{}) tfsdk.Config {
+		return testutil.Config(ctx, r, testutil.Attrs{
+			"name":         "egress",
+			"type":         providerType,
+			"egress_image": image,
+		})
+	}
+
+	testCases := []struct {
+		name        string
+		config      tfsdk.Config
+		expectError bool
+	}{
+		{name: "WireGuard without image", config: config("wireguard", nil)},
+		{name: "Custom with image", config: config("custom", "example/egress:1.0")},
+		{name: "Custom without image", config: config("custom", nil), expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &resource.ValidateConfigResponse{}
+			r.(resource.ResourceWithValidateConfig).ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tc.config}, resp)
+			assert.Equal(t, tc.expectError, resp.Diagnostics.HasError())
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-kasm/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &execResource{}
+	_ resource.ResourceWithConfigure = &execResource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func New() resource.Resource {
+	return &execResource{}
+}
+
+// execResource runs a command in a session when it is created. Changing any
+// input, including triggers, replaces the resource and runs the command again.
+type execResource struct {
+	client *client.Client
+}
+
+// execResourceModel maps the resource schema data.
+type execResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	KasmID      types.String `tfsdk:"kasm_id"`
+	UserID      types.String `tfsdk:"user_id"`
+	Command     types.String `tfsdk:"command"`
+	Env         types.Map    `tfsdk:"env"`
+	Workdir     types.String `tfsdk:"workdir"`
+	Privileged  types.Bool   `tfsdk:"privileged"`
+	User        types.String `tfsdk:"user"`
+	FailOnError types.Bool   `tfsdk:"fail_on_error"`
+	Triggers    types.Map    `tfsdk:"triggers"`
+	ExitCode    types.Int64  `tfsdk:"exit_code"`
+	Stdout      types.String `tfsdk:"stdout"`
+	Stderr      types.String `tfsdk:"stderr"`
+}
+
+// Metadata returns the resource type name.
+func (r *execResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_exec"
+}
+
+// Schema defines the schema for the resource.
+func (r *execResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs a command inside a running Kasm session. The command runs once when the resource is created; " +
+			"changing any argument, including triggers, runs it again. Destroying the resource does not undo the command.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the command execution.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"kasm_id": schema.StringAttribute{
+				Description: "The ID of the Kasm session to run the command in.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				Description: "The ID of the user who owns the session.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"command": schema.StringAttribute{
+				Description: "The command to run.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"env": schema.MapAttribute{
+				Description: "Environment variables for the command.",
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"workdir": schema.StringAttribute{
+				Description: "The working directory for the command.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"privileged": schema.BoolAttribute{
+				Description: "Whether to run the command with elevated privileges.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"user": schema.StringAttribute{
+				Description: "The user inside the session container to run the command as.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"fail_on_error": schema.BoolAttribute{
+				Description: "Whether a non-zero exit code fails the apply. Defaults to false, in which case the exit code is only recorded.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that run the command again when they change.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"exit_code": schema.Int64Attribute{
+				Description: "The exit code of the command.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"stdout": schema.StringAttribute{
+				Description: "The standard output of the command.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"stderr": schema.StringAttribute{
+				Description: "The standard error of the command.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *execResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create runs the command and records its result.
+func (r *execResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan execResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config := client.ExecConfig{
+		Cmd:        plan.Command.ValueString(),
+		Workdir:    plan.Workdir.ValueString(),
+		Privileged: plan.Privileged.ValueBool(),
+		User:       plan.User.ValueString(),
+	}
+	if !plan.Env.IsNull() {
+		config.Environment = make(map[string]string, len(plan.Env.Elements()))
+		resp.Diagnostics.Append(plan.Env.ElementsAs(ctx, &config.Environment, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Debug(ctx, "Running command in session", map[string]interface{}{
+		"kasm_id": plan.KasmID.ValueString(),
+	})
+
+	result, err := r.client.ExecCommandKasm(ctx, plan.KasmID.ValueString(), plan.UserID.ValueString(), config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Running Command",
+			fmt.Sprintf("Could not run command in session %s: %s", plan.KasmID.ValueString(), err),
+		)
+		return
+	}
+
+	if result.Kasm.ExitCode != 0 && plan.FailOnError.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Command Failed",
+			fmt.Sprintf("The command exited with code %d.\n\nstderr:\n%s", result.Kasm.ExitCode, result.Kasm.Stderr),
+		)
+		return
+	}
+
+	// Several commands may run in the same session, so the session ID alone
+	// does not identify an execution.
+	plan.ID = types.StringValue(fmt.Sprintf("%s-exec-%s", plan.KasmID.ValueString(), uuid.NewString()))
+	plan.ExitCode = types.Int64Value(int64(result.Kasm.ExitCode))
+	plan.Stdout = types.StringValue(result.Kasm.Stdout)
+	plan.Stderr = types.StringValue(result.Kasm.Stderr)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the recorded result; a command that has run cannot be refreshed.
+func (r *execResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state execResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only applies to fail_on_error, as every other argument replaces the
+// resource.
+func (r *execResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state execResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.FailOnError = plan.FailOnError
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the resource from state. The command's effects remain.
+func (r *execResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state execResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Exec resource delete complete", map[string]interface{}{
+		"kasm_id": state.KasmID.ValueString(),
+	})
+}
@@ -0,0 +1,80 @@
+//go:build unit
+// +build unit
+
+package exec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-kasm/internal/client"
+	"terraform-provider-kasm/internal/testutil"
+)
+
+func TestExecResource_Metadata(t *testing.T) {
+	r := New()
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "kasm"}, resp)
+
+	assert.Equal(t, "kasm_exec", resp.TypeName)
+}
+
+func TestExecResource_Schema(t *testing.T) {
+	r := New()
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	for _, name := range []string{"kasm_id", "user_id", "command"} {
+		assert.True(t, resp.Schema.Attributes[name].IsRequired(), "%s should be required", name)
+	}
+	for _, name := range []string{"exit_code", "stdout", "stderr"} {
+		assert.True(t, resp.Schema.Attributes[name].IsComputed(), "%s should be computed", name)
+	}
+	assert.True(t, resp.Schema.Attributes["env"].IsSensitive())
+}
+
+func TestExecResource_CreateUniqueIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"kasm": {"exit_code": 0, "stdout": "ok", "stderr": ""}}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	r := &execResource{client: client.NewClient(server.URL, "test-key", "test-secret", false, client.WithMaxRetries(0))}
+
+	create := func(command string) string {
+		plan := testutil.Plan(ctx, r, testutil.Attrs{
+			"id":            tftypes.UnknownValue,
+			"kasm_id":       "k1",
+			"user_id":       "u1",
+			"command":       command,
+			"privileged":    false,
+			"fail_on_error": true,
+		})
+
+		resp := &resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+		r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+		assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+		var id string
+		resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+		return id
+	}
+
+	first := create("echo one")
+	second := create("echo two")
+
+	assert.Contains(t, first, "k1-exec-")
+	assert.NotEqual(t, first, second)
+}
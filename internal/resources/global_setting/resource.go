@@ -0,0 +1,338 @@
+package global_setting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-kasm/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &globalSettingResource{}
+	_ resource.ResourceWithConfigure      = &globalSettingResource{}
+	_ resource.ResourceWithImportState    = &globalSettingResource{}
+	_ resource.ResourceWithValidateConfig = &globalSettingResource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func New() resource.Resource {
+	return &globalSettingResource{}
+}
+
+// globalSettingResource manages one server-wide Kasm setting.
+type globalSettingResource struct {
+	client *client.Client
+}
+
+// globalSettingResourceModel maps the resource schema data.
+type globalSettingResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Category      types.String `tfsdk:"category"`
+	Name          types.String `tfsdk:"name"`
+	Value         types.String `tfsdk:"value"`
+	SettingID     types.String `tfsdk:"setting_id"`
+	ValueType     types.String `tfsdk:"value_type"`
+	DefaultValue  types.String `tfsdk:"default_value"`
+	OriginalValue types.String `tfsdk:"original_value"`
+}
+
+// Metadata returns the resource type name.
+func (r *globalSettingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_global_setting"
+}
+
+// Schema defines the schema for the resource.
+func (r *globalSettingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages one global Kasm setting, such as login_session_lifetime, login_banner_text or api_rate_limit. " +
+			"Destroying the resource restores the Kasm default of the setting, or the value it had before Terraform " +
+			"managed it when Kasm does not report a default.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the resource, in the format category:name.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"category": schema.StringAttribute{
+				Description: "The category of the setting, such as auth, images, login, session or api.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the setting.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Description: "The value of the setting. Values are checked against the type of the setting: " +
+					"true or false for booleans, a number for numeric settings and a JSON document for structured settings.",
+				Required: true,
+			},
+			"setting_id": schema.StringAttribute{
+				Description: "The ID Kasm assigned to the setting.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"value_type": schema.StringAttribute{
+				Description: "The type of the value as reported by Kasm, such as bool, int, float, string or json.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"default_value": schema.StringAttribute{
+				Description: "The value Kasm ships with, restored when the resource is destroyed. " +
+					"Null when the server does not report it.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"original_value": schema.StringAttribute{
+				Description: "The value of the setting when it was created or imported, before Terraform changed it. " +
+					"Restored when the resource is destroyed if default_value is null.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *globalSettingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig checks the category and value of known settings.
+func (r *globalSettingResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config globalSettingResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Name.IsNull() || config.Name.IsUnknown() {
+		return
+	}
+	name := config.Name.ValueString()
+
+	category, known := client.GlobalSettingCategory(name)
+	if known && !config.Category.IsNull() && !config.Category.IsUnknown() && config.Category.ValueString() != category {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("category"),
+			"Invalid Global Setting Category",
+			fmt.Sprintf("Setting %s is in category %s, not %s.", name, category, config.Category.ValueString()),
+		)
+	}
+
+	if config.Value.IsNull() || config.Value.IsUnknown() {
+		return
+	}
+	if err := client.ValidateGlobalSettingValue(name, config.Value.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("value"),
+			"Invalid Global Setting Value",
+			err.Error(),
+		)
+	}
+}
+
+// Create sets the value of the setting.
+func (r *globalSettingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan globalSettingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setting, err := r.client.GetGlobalSetting(ctx, plan.Category.ValueString(), plan.Name.ValueString())
+	if err == nil {
+		plan.OriginalValue = types.StringValue(string(setting.Value))
+		setting, err = r.client.UpdateGlobalSetting(ctx, setting, plan.Value.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Global Setting",
+			fmt.Sprintf("Could not set global setting %s: %s", plan.Name.ValueString(), err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(plan.Category.ValueString() + ":" + plan.Name.ValueString())
+	flattenGlobalSetting(&plan, setting)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the setting from the server.
+func (r *globalSettingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state globalSettingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setting, err := r.client.GetGlobalSetting(ctx, state.Category.ValueString(), state.Name.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Debug(ctx, "Global setting not found, removing from state", map[string]interface{}{
+				"id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Global Setting",
+			fmt.Sprintf("Could not read global setting %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	// Kasm may spell a value differently, such as True for true; keep the
+	// configured spelling unless the value actually changed.
+	if state.Value.IsNull() || !setting.ValueEqual(state.Value.ValueString()) {
+		state.Value = types.StringValue(string(setting.Value))
+	}
+	state.ID = types.StringValue(state.Category.ValueString() + ":" + state.Name.ValueString())
+	flattenGlobalSetting(&state, setting)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update changes the value of the setting.
+func (r *globalSettingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan globalSettingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setting, err := r.client.GetGlobalSetting(ctx, plan.Category.ValueString(), plan.Name.ValueString())
+	if err == nil {
+		setting, err = r.client.UpdateGlobalSetting(ctx, setting, plan.Value.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Global Setting",
+			fmt.Sprintf("Could not update global setting %s: %s", plan.ID.ValueString(), err),
+		)
+		return
+	}
+
+	flattenGlobalSetting(&plan, setting)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete restores the default value of the setting. When Kasm does not
+// report a default, the value saved in original_value is restored instead.
+func (r *globalSettingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state globalSettingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setting, err := r.client.GetGlobalSetting(ctx, state.Category.ValueString(), state.Name.ValueString())
+	if err == nil {
+		if setting.DefaultValue == nil && !state.OriginalValue.IsNull() && !state.OriginalValue.IsUnknown() {
+			_, err = r.client.UpdateGlobalSetting(ctx, setting, state.OriginalValue.ValueString())
+		} else {
+			_, err = r.client.ResetGlobalSetting(ctx, setting)
+		}
+	}
+	switch {
+	case err == nil, client.IsNotFound(err):
+	case errors.Is(err, client.ErrNoSettingDefault):
+		resp.Diagnostics.AddWarning(
+			"Global Setting Not Reset",
+			fmt.Sprintf("Kasm does not report the default of global setting %s and its original value is not known, "+
+				"so it keeps its current value. Reset it in the Kasm admin UI if needed.", state.ID.ValueString()),
+		)
+	default:
+		resp.Diagnostics.AddError(
+			"Error Deleting Global Setting",
+			fmt.Sprintf("Could not restore the default of global setting %s: %s", state.ID.ValueString(), err),
+		)
+	}
+}
+
+// ImportState imports a global setting by category and name, and records
+// its current value as original_value.
+func (r *globalSettingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	category, name, ok := strings.Cut(req.ID, ":")
+	if !ok || category == "" || name == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in the format category:name",
+		)
+		return
+	}
+
+	setting, err := r.client.GetGlobalSetting(ctx, category, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Global Setting",
+			fmt.Sprintf("Could not read global setting %s: %s", req.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("category"), category)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("original_value"), string(setting.Value))...)
+}
+
+// flattenGlobalSetting copies the server-reported attributes of setting
+// into model.
+func flattenGlobalSetting(model *globalSettingResourceModel, setting *client.GlobalSetting) {
+	model.SettingID = types.StringValue(setting.SettingID)
+	model.ValueType = types.StringValue(setting.ValueType)
+	model.DefaultValue = types.StringNull()
+	if setting.DefaultValue != nil {
+		model.DefaultValue = types.StringValue(string(*setting.DefaultValue))
+	}
+}
@@ -0,0 +1,116 @@
+//go:build unit
+// +build unit
+
+package global_setting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-kasm/internal/client"
+	"terraform-provider-kasm/internal/testutil"
+)
+
+func TestGlobalSettingResource_Metadata(t *testing.T) {
+	r := New()
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "kasm"}, resp)
+
+	assert.Equal(t, "kasm_global_setting", resp.TypeName)
+}
+
+func TestGlobalSettingResource_ValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+
+	config := func(category, name string, value interface{}) tfsdk.Config {
+		return testutil.Config(ctx, r, testutil.Attrs{
+			"category": category,
+			"name":     name,
+			"value":    value,
+		})
+	}
+
+	testCases := []struct {
+		name        string
+		config      tfsdk.Config
+		expectError bool
+	}{
+		{name: "Valid integer", config: config("auth", "login_session_lifetime", "28800")},
+		{name: "Valid boolean", config: config("login", "login_banner_enabled", "true")},
+		{name: "Unknown setting", config: config("misc", "some_future_setting", "anything")},
+		{name: "Unknown value", config: config("api", "api_rate_limit", tftypes.UnknownValue)},
+		{name: "Wrong category", config: config("session", "login_session_lifetime", "28800"), expectError: true},
+		{name: "Wrong type", config: config("api", "api_rate_limit", "unlimited"), expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &resource.ValidateConfigResponse{}
+			r.(resource.ResourceWithValidateConfig).ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tc.config}, resp)
+			assert.Equal(t, tc.expectError, resp.Diagnostics.HasError())
+		})
+	}
+}
+
+func TestGlobalSettingResource_DeleteRestoresOriginalValue(t *testing.T) {
+	current := "Welcome"
+	var updates []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		switch r.URL.Path {
+		case "/api/public/get_settings":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"settings": []map[string]interface{}{
+					{"setting_id": "s1", "name": "login_banner_text", "category": "login", "value": current, "value_type": "string"},
+				},
+			})
+		case "/api/public/update_setting":
+			current = req["target_setting"].(map[string]interface{})["value"].(string)
+			updates = append(updates, current)
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	r := &globalSettingResource{client: client.NewClient(server.URL, "test-key", "test-secret", false, client.WithMaxRetries(0))}
+	plan := testutil.Plan(ctx, r, testutil.Attrs{
+		"id":             tftypes.UnknownValue,
+		"category":       "login",
+		"name":           "login_banner_text",
+		"value":          "Authorised use only",
+		"setting_id":     tftypes.UnknownValue,
+		"value_type":     tftypes.UnknownValue,
+		"default_value":  tftypes.UnknownValue,
+		"original_value": tftypes.UnknownValue,
+	})
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	assert.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	var originalValue types.String
+	createResp.State.GetAttribute(ctx, path.Root("original_value"), &originalValue)
+	assert.Equal(t, "Welcome", originalValue.ValueString())
+
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, deleteResp)
+	assert.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+	assert.Zero(t, deleteResp.Diagnostics.WarningsCount())
+	assert.Equal(t, []string{"Authorised use only", "Welcome"}, updates)
+}
@@ -107,7 +107,7 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 		Permissions: permissions,
 	}
 
-	createdGroup, err := r.client.CreateGroup(group)
+	createdGroup, err := r.client.CreateGroup(ctx, group)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating group",
@@ -134,7 +134,7 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		"id": state.ID.ValueString(),
 	})
 
-	group, err := r.client.GetGroup(state.ID.ValueString())
+	group, err := r.client.GetGroup(ctx, state.ID.ValueString())
 	if err != nil {
 		if client.IsGroupNotFoundError(err) {
 			tflog.Debug(ctx, "Group not found, removing from state", map[string]interface{}{
@@ -200,7 +200,7 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		Permissions: permissions,
 	}
 
-	updatedGroup, err := r.client.UpdateGroup(group)
+	updatedGroup, err := r.client.UpdateGroup(ctx, group)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating group",
@@ -223,7 +223,7 @@ func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.client.DeleteGroup(state.ID.ValueString())
+	err := r.client.DeleteGroup(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting group",
@@ -250,7 +250,7 @@ func (r *groupResource) ImportState(ctx context.Context, req resource.ImportStat
 			return
 		}
 		// Find group by name
-		groups, err := r.client.GetGroups()
+		groups, err := r.client.GetGroups(ctx)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error importing group",
@@ -4,6 +4,7 @@
 package tests
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -105,7 +106,7 @@ func testAccCheckGroupExists(n string) resource.TestCheckFunc {
 		}
 
 		client := testutils.GetTestClient(nil)
-		_, err := client.GetGroup(rs.Primary.ID)
+		_, err := client.GetGroup(context.Background(), rs.Primary.ID)
 		if err != nil {
 			return fmt.Errorf("Error fetching group with ID %s: %s", rs.Primary.ID, err)
 		}
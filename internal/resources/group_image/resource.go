@@ -111,12 +111,12 @@ func (r *groupImageResource) Configure(_ context.Context, req resource.Configure
 }
 
 // waitForImage waits for the image to be available in the group's authorized images
-func (r *groupImageResource) waitForImage(groupID string, imageID string) (*client.GroupImage, error) {
+func (r *groupImageResource) waitForImage(ctx context.Context, groupID string, imageID string) (*client.GroupImage, error) {
 	maxAttempts := 10
 	delay := 2 * time.Second
 
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
-		images, err := r.client.GetGroupImages(groupID)
+		images, err := r.client.GetGroupImages(client.NoCache(ctx), groupID)
 		if err != nil {
 			return nil, fmt.Errorf("error reading group images: %v", err)
 		}
@@ -128,7 +128,9 @@ func (r *groupImageResource) waitForImage(groupID string, imageID string) (*clie
 		}
 
 		if attempt < maxAttempts {
-			time.Sleep(delay)
+			if err := client.SleepContext(ctx, delay); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -145,7 +147,7 @@ func (r *groupImageResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	// Verify the image exists first by getting all images
-	images, err := r.client.GetImages()
+	images, err := r.client.GetImages(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error getting images",
@@ -170,7 +172,7 @@ func (r *groupImageResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	err = r.client.AddGroupImage(plan.GroupID.ValueString(), plan.ImageID.ValueString())
+	err = r.client.AddGroupImage(ctx, plan.GroupID.ValueString(), plan.ImageID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error authorizing image for group",
@@ -180,7 +182,7 @@ func (r *groupImageResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	// Wait for the image to be available
-	groupImage, err := r.waitForImage(plan.GroupID.ValueString(), plan.ImageID.ValueString())
+	groupImage, err := r.waitForImage(ctx, plan.GroupID.ValueString(), plan.ImageID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error finding authorized image",
@@ -211,8 +213,13 @@ func (r *groupImageResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	images, err := r.client.GetGroupImages(state.GroupID.ValueString())
+	images, err := r.client.GetGroupImages(ctx, state.GroupID.ValueString())
 	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading Group Images",
 			fmt.Sprintf("Could not read group images: %s", err),
@@ -266,7 +273,7 @@ func (r *groupImageResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	err := r.client.RemoveGroupImage(state.GroupID.ValueString(), state.ImageID.ValueString())
+	err := r.client.RemoveGroupImage(ctx, state.GroupID.ValueString(), state.ImageID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Removing Group Image Authorization",
@@ -4,6 +4,7 @@
 package tests
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -24,7 +25,7 @@ var createdImageID string // Track the created image ID for cleanup
 // cleanupTestImage deletes the test image if it was created
 func cleanupTestImage(t *testing.T, c *client.Client) {
 	if createdImageID != "" {
-		if err := c.DeleteImage(createdImageID); err != nil {
+		if err := c.DeleteImage(context.Background(), createdImageID); err != nil {
 			t.Logf("Warning: Failed to delete test image %s: %v", createdImageID, err)
 		} else {
 			if os.Getenv("KASM_DEBUG") != "" {
@@ -47,7 +48,7 @@ func ensureWorkspaceImage(t *testing.T, c *client.Client) string {
 	}
 
 	// Try to find an existing image first
-	images, err := c.GetImages()
+	images, err := c.GetImages(context.Background())
 	if err != nil {
 		if os.Getenv("KASM_DEBUG") != "" {
 			log.Printf("[DEBUG] Error getting images: %v", err)
@@ -129,7 +130,7 @@ func ensureWorkspaceImage(t *testing.T, c *client.Client) string {
 			log.Printf("[DEBUG] Attempt %d/%d to create image", i+1, maxRetries)
 		}
 
-		createdImage, lastErr = c.AddWorkspaceImage(image)
+		createdImage, lastErr = c.AddWorkspaceImage(context.Background(), image)
 
 		if lastErr != nil {
 			if os.Getenv("KASM_DEBUG") != "" {
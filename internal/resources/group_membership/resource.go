@@ -99,7 +99,7 @@ func (r *groupMembershipResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	err := r.client.AddUserToGroup(plan.UserID.ValueString(), plan.GroupID.ValueString())
+	err := r.client.AddUserToGroup(ctx, plan.UserID.ValueString(), plan.GroupID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error adding user to group",
@@ -127,8 +127,13 @@ func (r *groupMembershipResource) Read(ctx context.Context, req resource.ReadReq
 	}
 
 	// Get the user's groups
-	user, err := r.client.GetUser(state.UserID.ValueString())
+	user, err := r.client.GetUser(ctx, state.UserID.ValueString())
 	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading User",
 			fmt.Sprintf("Could not read user: %s", err),
@@ -177,7 +182,7 @@ func (r *groupMembershipResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	err := r.client.RemoveUserFromGroup(state.UserID.ValueString(), state.GroupID.ValueString())
+	err := r.client.RemoveUserFromGroup(ctx, state.UserID.ValueString(), state.GroupID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Removing User from Group",
@@ -0,0 +1,264 @@
+package group_setting
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-kasm/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &groupSettingResource{}
+	_ resource.ResourceWithConfigure      = &groupSettingResource{}
+	_ resource.ResourceWithImportState    = &groupSettingResource{}
+	_ resource.ResourceWithValidateConfig = &groupSettingResource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func New() resource.Resource {
+	return &groupSettingResource{}
+}
+
+// groupSettingResource manages one setting of a Kasm group.
+type groupSettingResource struct {
+	client *client.Client
+}
+
+// groupSettingResourceModel maps the resource schema data.
+type groupSettingResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	GroupID        types.String `tfsdk:"group_id"`
+	Name           types.String `tfsdk:"name"`
+	Value          types.String `tfsdk:"value"`
+	GroupSettingID types.String `tfsdk:"group_setting_id"`
+	ValueType      types.String `tfsdk:"value_type"`
+}
+
+// Metadata returns the resource type name.
+func (r *groupSettingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_setting"
+}
+
+// Schema defines the schema for the resource.
+func (r *groupSettingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages one setting of a Kasm group, such as idle_disconnect, max_kasms_per_user or allow_kasm_downloads. " +
+			"Destroying the resource removes the setting from the group, restoring the default.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the resource, in the format group_id:name.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				Description: "The ID of the group.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the setting.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Description: "The value of the setting. Values of known settings are checked against their type: " +
+					"true or false for booleans, a number for numeric settings and a JSON document for structured settings.",
+				Required: true,
+			},
+			"group_setting_id": schema.StringAttribute{
+				Description: "The ID Kasm assigned to the setting of this group.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"value_type": schema.StringAttribute{
+				Description: "The type of the value as reported by Kasm, such as bool, int, float, string or json.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *groupSettingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig checks the value against the type of the setting.
+func (r *groupSettingResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config groupSettingResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Name.IsNull() || config.Name.IsUnknown() || config.Value.IsNull() || config.Value.IsUnknown() {
+		return
+	}
+	if err := client.ValidateGroupSettingValue(config.Name.ValueString(), config.Value.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("value"),
+			"Invalid Group Setting Value",
+			err.Error(),
+		)
+	}
+}
+
+// Create applies the setting to the group.
+func (r *groupSettingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan groupSettingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setting, err := r.client.AddGroupSetting(ctx, plan.GroupID.ValueString(), plan.Name.ValueString(), plan.Value.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Group Setting",
+			fmt.Sprintf("Could not set %s on group %s: %s", plan.Name.ValueString(), plan.GroupID.ValueString(), err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(plan.GroupID.ValueString() + ":" + plan.Name.ValueString())
+	plan.GroupSettingID = types.StringValue(setting.GroupSettingID)
+	plan.ValueType = types.StringValue(setting.ValueType)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the setting from the group settings of the server.
+func (r *groupSettingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state groupSettingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setting, err := r.client.GetGroupSetting(ctx, state.GroupID.ValueString(), state.Name.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Debug(ctx, "Group setting not found, removing from state", map[string]interface{}{
+				"id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Group Setting",
+			fmt.Sprintf("Could not read group setting %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	// Kasm may spell a value differently, such as True for true; keep the
+	// configured spelling unless the value actually changed.
+	value := string(setting.Value)
+	if state.Value.IsNull() || !client.GroupSettingValuesEqual(setting.Name, state.Value.ValueString(), value) {
+		state.Value = types.StringValue(value)
+	}
+	state.ID = types.StringValue(state.GroupID.ValueString() + ":" + state.Name.ValueString())
+	state.GroupSettingID = types.StringValue(setting.GroupSettingID)
+	state.ValueType = types.StringValue(setting.ValueType)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update changes the value of the setting.
+func (r *groupSettingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan groupSettingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.UpdateGroupSetting(ctx, plan.GroupID.ValueString(), &client.GroupSetting{
+		GroupSettingID: plan.GroupSettingID.ValueString(),
+		Name:           plan.Name.ValueString(),
+		Value:          client.SettingValue(plan.Value.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Group Setting",
+			fmt.Sprintf("Could not update group setting %s: %s", plan.ID.ValueString(), err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the setting from the group.
+func (r *groupSettingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state groupSettingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.RemoveGroupSetting(ctx, state.GroupID.ValueString(), state.GroupSettingID.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Group Setting",
+			fmt.Sprintf("Could not remove group setting %s: %s", state.ID.ValueString(), err),
+		)
+	}
+}
+
+// ImportState imports a group setting by group ID and setting name.
+func (r *groupSettingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	groupID, name, ok := strings.Cut(req.ID, ":")
+	if !ok || groupID == "" || name == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in the format group_id:name",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), groupID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
@@ -0,0 +1,60 @@
+//go:build unit
+// +build unit
+
+package group_setting
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-kasm/internal/testutil"
+)
+
+func TestGroupSettingResource_Metadata(t *testing.T) {
+	r := New()
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "kasm"}, resp)
+
+	assert.Equal(t, "kasm_group_setting", resp.TypeName)
+}
+
+func TestGroupSettingResource_ValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+
+	config := func(name string, value interface{}) tfsdk.Config {
+		return testutil.Config(ctx, r, testutil.Attrs{
+			"group_id": "g1",
+			"name":     name,
+			"value":    value,
+		})
+	}
+
+	testCases := []struct {
+		name        string
+		config      tfsdk.Config
+		expectError bool
+	}{
+		{name: "Bool", config: config("allow_kasm_downloads", "false")},
+		{name: "Int", config: config("max_kasms_per_user", "2")},
+		{name: "Unknown value", config: config("max_kasms_per_user", tftypes.UnknownValue)},
+		{name: "Unknown setting", config: config("future_setting", "anything")},
+		{name: "Bad bool", config: config("allow_kasm_downloads", "no"), expectError: true},
+		{name: "Bad int", config: config("max_kasms_per_user", "two"), expectError: true},
+		{name: "Bad JSON", config: config("run_config", "{"), expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &resource.ValidateConfigResponse{}
+			r.(resource.ResourceWithValidateConfig).ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tc.config}, resp)
+			assert.Equal(t, tc.expectError, resp.Diagnostics.HasError())
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package group_sso_mapping
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-kasm/internal/client"
+	"terraform-provider-kasm/internal/schemautil"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &groupSSOMappingResource{}
+	_ resource.ResourceWithConfigure        = &groupSSOMappingResource{}
+	_ resource.ResourceWithImportState      = &groupSSOMappingResource{}
+	_ resource.ResourceWithConfigValidators = &groupSSOMappingResource{}
+	_ resource.ResourceWithValidateConfig   = &groupSSOMappingResource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func New() resource.Resource {
+	return &groupSSOMappingResource{}
+}
+
+// groupSSOMappingResource maps the groups of an LDAP, SAML or OIDC provider
+// to a Kasm group.
+type groupSSOMappingResource struct {
+	client *client.Client
+}
+
+// groupSSOMappingResourceModel maps the resource schema data.
+type groupSSOMappingResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	GroupID         types.String `tfsdk:"group_id"`
+	LDAPID          types.String `tfsdk:"ldap_id"`
+	SAMLID          types.String `tfsdk:"saml_id"`
+	OIDCID          types.String `tfsdk:"oidc_id"`
+	GroupAttribute  types.String `tfsdk:"group_attribute"`
+	ApplyToAllUsers types.Bool   `tfsdk:"apply_to_all_users"`
+}
+
+// Metadata returns the resource type name.
+func (r *groupSSOMappingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_sso_mapping"
+}
+
+// Schema defines the schema for the resource.
+func (r *groupSSOMappingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	replace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		Description: "Adds users who log in through an LDAP, SAML or OIDC provider to a Kasm group, based on their " +
+			"groups at the provider. Exactly one of ldap_id, saml_id and oidc_id must be set.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the SSO mapping.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				Description:   "The ID of the Kasm group users are added to. Changing this forces a new resource.",
+				Required:      true,
+				PlanModifiers: replace,
+			},
+			"ldap_id": schema.StringAttribute{
+				Description:   "The ID of the kasm_ldap_config the mapping is for. Changing this forces a new resource.",
+				Optional:      true,
+				PlanModifiers: replace,
+			},
+			"saml_id": schema.StringAttribute{
+				Description:   "The ID of the kasm_saml_config the mapping is for. Changing this forces a new resource.",
+				Optional:      true,
+				PlanModifiers: replace,
+			},
+			"oidc_id": schema.StringAttribute{
+				Description:   "The ID of the kasm_oidc_config the mapping is for. Changing this forces a new resource.",
+				Optional:      true,
+				PlanModifiers: replace,
+			},
+			"group_attribute": schema.StringAttribute{
+				Description: "The group users must belong to at the provider: the DN of the group for LDAP, or a value " +
+					"of the groups attribute or claim for SAML and OIDC. Required unless apply_to_all_users is true.",
+				Optional: true,
+			},
+			"apply_to_all_users": schema.BoolAttribute{
+				Description: "Whether every user of the provider is added to the group, regardless of their groups. " +
+					"Conflicts with group_attribute. Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// ConfigValidators requires exactly one SSO provider.
+func (r *groupSSOMappingResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("ldap_id"),
+			path.MatchRoot("saml_id"),
+			path.MatchRoot("oidc_id"),
+		),
+	}
+}
+
+// ValidateConfig requires a group attribute unless the mapping applies to
+// all users, and rejects one when it does.
+func (r *groupSSOMappingResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config groupSSOMappingResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.ApplyToAllUsers.IsUnknown() || config.GroupAttribute.IsUnknown() {
+		return
+	}
+
+	switch {
+	case config.ApplyToAllUsers.ValueBool() && !config.GroupAttribute.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("group_attribute"),
+			"Invalid SSO Mapping",
+			"group_attribute cannot be set when apply_to_all_users is true.",
+		)
+	case !config.ApplyToAllUsers.ValueBool() && config.GroupAttribute.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("group_attribute"),
+			"Invalid SSO Mapping",
+			"group_attribute is required unless apply_to_all_users is true.",
+		)
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *groupSSOMappingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates the SSO mapping.
+func (r *groupSSOMappingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan groupSSOMappingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mapping, err := r.client.AddGroupSSOMapping(ctx, expandGroupSSOMapping(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Group SSO Mapping",
+			fmt.Sprintf("Could not add SSO mapping to group %s: %s", plan.GroupID.ValueString(), err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(mapping.SSOGroupMappingID)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the SSO mapping from the server.
+func (r *groupSSOMappingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state groupSSOMappingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mapping, err := r.client.GetGroupSSOMapping(ctx, state.GroupID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Debug(ctx, "Group SSO mapping not found, removing from state", map[string]interface{}{
+				"id":       state.ID.ValueString(),
+				"group_id": state.GroupID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Group SSO Mapping",
+			fmt.Sprintf("Could not read SSO mapping %s of group %s: %s", state.ID.ValueString(), state.GroupID.ValueString(), err),
+		)
+		return
+	}
+
+	state.LDAPID = schemautil.OptionalString(state.LDAPID, mapping.LDAPID)
+	state.SAMLID = schemautil.OptionalString(state.SAMLID, mapping.SAMLID)
+	state.OIDCID = schemautil.OptionalString(state.OIDCID, mapping.OIDCID)
+	state.ApplyToAllUsers = types.BoolValue(mapping.ApplyToAllUsers)
+
+	// LDAP DNs are case-insensitive, so keep the configured spelling when the
+	// server reports the same DN in a different case.
+	sameDN := mapping.SSOType() == client.SSOTypeLDAP && strings.EqualFold(state.GroupAttribute.ValueString(), mapping.GroupAttribute)
+	if !sameDN {
+		state.GroupAttribute = schemautil.OptionalString(state.GroupAttribute, mapping.GroupAttribute)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the group attribute of the SSO mapping in place.
+func (r *groupSSOMappingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan groupSSOMappingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mapping := expandGroupSSOMapping(plan)
+	mapping.SSOGroupMappingID = plan.ID.ValueString()
+	if _, err := r.client.UpdateGroupSSOMapping(ctx, mapping); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Group SSO Mapping",
+			fmt.Sprintf("Could not update SSO mapping %s: %s", plan.ID.ValueString(), err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the SSO mapping.
+func (r *groupSSOMappingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state groupSSOMappingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteGroupSSOMapping(ctx, state.ID.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Group SSO Mapping",
+			fmt.Sprintf("Could not delete SSO mapping %s: %s", state.ID.ValueString(), err),
+		)
+	}
+}
+
+// ImportState imports an SSO mapping by group ID and mapping ID.
+func (r *groupSSOMappingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	groupID, id, ok := strings.Cut(req.ID, ":")
+	if !ok || groupID == "" || id == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in the format group_id:sso_group_mapping_id",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), groupID)...)
+}
+
+// expandGroupSSOMapping builds the API object from the plan.
+func expandGroupSSOMapping(plan groupSSOMappingResourceModel) *client.GroupSSOMapping {
+	return &client.GroupSSOMapping{
+		GroupID:         plan.GroupID.ValueString(),
+		LDAPID:          plan.LDAPID.ValueString(),
+		SAMLID:          plan.SAMLID.ValueString(),
+		OIDCID:          plan.OIDCID.ValueString(),
+		GroupAttribute:  plan.GroupAttribute.ValueString(),
+		ApplyToAllUsers: plan.ApplyToAllUsers.ValueBool(),
+	}
+}
@@ -0,0 +1,69 @@
+//go:build unit
+// +build unit
+
+package group_sso_mapping
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-kasm/internal/testutil"
+)
+
+func TestGroupSSOMappingResource_Metadata(t *testing.T) {
+	r := New()
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "kasm"}, resp)
+
+	assert.Equal(t, "kasm_group_sso_mapping", resp.TypeName)
+}
+
+func TestGroupSSOMappingResource_ValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+
+	config := func(ldapID, samlID, attribute, allUsers interface{}) tfsdk.Config {
+		return testutil.Config(ctx, r, testutil.Attrs{
+			"group_id":           "g1",
+			"ldap_id":            ldapID,
+			"saml_id":            samlID,
+			"group_attribute":    attribute,
+			"apply_to_all_users": allUsers,
+		})
+	}
+
+	testCases := []struct {
+		name        string
+		config      tfsdk.Config
+		expectError bool
+	}{
+		{name: "LDAP group DN", config: config("l1", nil, "CN=Developers,OU=Groups,DC=example,DC=com", nil)},
+		{name: "SAML all users", config: config(nil, "s1", nil, true)},
+		{name: "Unknown attribute", config: config("l1", nil, tftypes.UnknownValue, nil)},
+		{name: "No provider", config: config(nil, nil, "developers", nil), expectError: true},
+		{name: "Two providers", config: config("l1", "s1", "developers", nil), expectError: true},
+		{name: "No attribute", config: config(nil, "s1", nil, false), expectError: true},
+		{name: "Attribute and all users", config: config(nil, "s1", "developers", true), expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := resource.ValidateConfigRequest{Config: tc.config}
+			resp := &resource.ValidateConfigResponse{}
+			r.(resource.ResourceWithValidateConfig).ValidateConfig(ctx, req, resp)
+			diags := resp.Diagnostics
+			for _, v := range r.(resource.ResourceWithConfigValidators).ConfigValidators(ctx) {
+				resp := &resource.ValidateConfigResponse{}
+				v.ValidateResource(ctx, req, resp)
+				diags.Append(resp.Diagnostics...)
+			}
+			assert.Equal(t, tc.expectError, diags.HasError())
+		})
+	}
+}
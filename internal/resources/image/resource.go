@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"regexp"
-	"strings"
 
 	"terraform-provider-kasm/internal/client"
 	"terraform-provider-kasm/internal/validators"
@@ -294,7 +293,7 @@ func (r *imageResource) Create(ctx context.Context, req resource.CreateRequest,
 		ExecConfig:          stringMapToInterface(execConfig),
 	}
 
-	createdImage, err := r.client.CreateImage(image)
+	createdImage, err := r.client.CreateImage(ctx, image)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating image",
@@ -331,9 +330,9 @@ func (r *imageResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	image, err := r.client.GetImage(state.ID.ValueString())
+	image, err := r.client.GetImage(ctx, state.ID.ValueString())
 	if err != nil {
-		if isNotFoundError(err) {
+		if client.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
@@ -443,7 +442,7 @@ func (r *imageResource) Update(ctx context.Context, req resource.UpdateRequest,
 		ExecConfig:          stringMapToInterface(execConfig),
 	}
 
-	updatedImage, err := r.client.UpdateImage(image)
+	updatedImage, err := r.client.UpdateImage(ctx, image)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating image",
@@ -473,7 +472,7 @@ func (r *imageResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.client.DeleteImage(state.ID.ValueString())
+	err := r.client.DeleteImage(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddWarning(
 			"Image Deletion Not Supported",
@@ -487,10 +486,6 @@ func (r *imageResource) ImportState(ctx context.Context, req resource.ImportStat
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
-func isNotFoundError(err error) bool {
-	return strings.Contains(err.Error(), "not found")
-}
-
 // Docker image validator
 func validateDockerImage() validator.String {
 	return validators.StringValidator{
@@ -6,6 +6,7 @@
 package tests
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"regexp"
@@ -22,7 +23,7 @@ func TestAccKasmImage_getImages(t *testing.T) {
 			testutils.TestAccPreCheck(t)
 			// Add additional validation
 			c := testutils.GetTestClient(t)
-			images, err := c.GetImages()
+			images, err := c.GetImages(context.Background())
 			if err != nil {
 				t.Skipf("No images available: %v", err)
 			}
@@ -114,7 +114,7 @@ func (r *joinResource) Create(ctx context.Context, req resource.CreateRequest, r
 	// Try to join the session with retries
 	maxRetries := 5
 	for i := 0; i < maxRetries; i++ {
-		joinResp, err := r.client.JoinKasm(plan.ShareID.ValueString(), plan.UserID.ValueString())
+		joinResp, err := r.client.JoinKasm(ctx, plan.ShareID.ValueString(), plan.UserID.ValueString())
 		if err != nil {
 			if i == maxRetries-1 {
 				resp.Diagnostics.AddError(
@@ -124,7 +124,13 @@ func (r *joinResource) Create(ctx context.Context, req resource.CreateRequest, r
 				return
 			}
 			tflog.Info(ctx, fmt.Sprintf("Join attempt %d failed, retrying in 2 seconds...", i+1))
-			time.Sleep(2 * time.Second)
+			if err := client.SleepContext(ctx, 2*time.Second); err != nil {
+				resp.Diagnostics.AddError(
+					"Error joining Kasm session",
+					fmt.Sprintf("Join retry was cancelled: %v", err),
+				)
+				return
+			}
 			continue
 		}
 
@@ -137,7 +143,13 @@ func (r *joinResource) Create(ctx context.Context, req resource.CreateRequest, r
 				return
 			}
 			tflog.Info(ctx, fmt.Sprintf("Join attempt %d returned nil response, retrying in 2 seconds...", i+1))
-			time.Sleep(2 * time.Second)
+			if err := client.SleepContext(ctx, 2*time.Second); err != nil {
+				resp.Diagnostics.AddError(
+					"Error joining Kasm session",
+					fmt.Sprintf("Join retry was cancelled: %v", err),
+				)
+				return
+			}
 			continue
 		}
 
@@ -150,7 +162,13 @@ func (r *joinResource) Create(ctx context.Context, req resource.CreateRequest, r
 				return
 			}
 			tflog.Info(ctx, fmt.Sprintf("Join attempt %d failed with error: %s, retrying in 2 seconds...", i+1, joinResp.ErrorMessage))
-			time.Sleep(2 * time.Second)
+			if err := client.SleepContext(ctx, 2*time.Second); err != nil {
+				resp.Diagnostics.AddError(
+					"Error joining Kasm session",
+					fmt.Sprintf("Join retry was cancelled: %v", err),
+				)
+				return
+			}
 			continue
 		}
 
@@ -186,8 +204,13 @@ func (r *joinResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Try to join the session again to get fresh details
-	joinResp, err := r.client.JoinKasm(state.ShareID.ValueString(), state.UserID.ValueString())
+	joinResp, err := r.client.JoinKasm(ctx, state.ShareID.ValueString(), state.UserID.ValueString())
 	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading Kasm Session",
 			fmt.Sprintf("Could not read session ID %s: %s", state.ID.ValueString(), err),
@@ -218,7 +241,7 @@ func (r *joinResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	plan.ID = types.StringValue(fmt.Sprintf("%s:%s", plan.ShareID.ValueString(), plan.UserID.ValueString()))
 
 	// Re-join the session with the new parameters
-	joinResp, err := r.client.JoinKasm(plan.ShareID.ValueString(), plan.UserID.ValueString())
+	joinResp, err := r.client.JoinKasm(ctx, plan.ShareID.ValueString(), plan.UserID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error joining Kasm session",
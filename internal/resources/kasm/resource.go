@@ -22,6 +22,7 @@ var (
 	_ resource.Resource                = &kasmSessionResource{}
 	_ resource.ResourceWithConfigure   = &kasmSessionResource{}
 	_ resource.ResourceWithImportState = &kasmSessionResource{}
+	_ resource.ResourceWithModifyPlan  = &kasmSessionResource{}
 )
 
 type kasmSessionResource struct {
@@ -46,14 +47,13 @@ type kasmSessionResourceModel struct {
 }
 
 func NewKasmSessionResource() resource.Resource {
-	tflog.Info(context.Background(), "Creating new kasm session resource")
 	return &kasmSessionResource{
 		client: nil,
 	}
 }
 
-func (r *kasmSessionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	tflog.Info(context.Background(), "Configuring kasm session resource")
+func (r *kasmSessionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "Configuring kasm session resource")
 
 	if req.ProviderData == nil {
 		// During validation, provider data might be nil, which is okay
@@ -70,7 +70,7 @@ func (r *kasmSessionResource) Configure(_ context.Context, req resource.Configur
 	}
 
 	r.client = client
-	tflog.Info(context.Background(), "Successfully configured kasm session resource")
+	tflog.Info(ctx, "Successfully configured kasm session resource")
 }
 
 func (r *kasmSessionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -173,6 +173,40 @@ func (r *kasmSessionResource) Schema(_ context.Context, _ resource.SchemaRequest
 	}
 }
 
+// ModifyPlan checks the planned options against the Kasm server version so
+// that unsupported options fail at plan time with a clear diagnostic.
+func (r *kasmSessionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan kasmSessionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Persistent.ValueBool() {
+		if err := r.client.RequireFeature(client.FeaturePersistentSessions); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("persistent"),
+				"Unsupported Kasm Server Version",
+				fmt.Sprintf("Persistent sessions cannot be requested from this server: %v. Set persistent = false or upgrade Kasm.", err),
+			)
+		}
+	}
+
+	if plan.EnableSharing.ValueBool() && !plan.Share.ValueBool() {
+		if err := r.client.RequireFeature(client.FeatureEnableSharing); err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("enable_sharing"),
+				"Option Ignored By Kasm Server",
+				fmt.Sprintf("%v. On this server sharing is controlled by share alone, so enable_sharing has no effect.", err),
+			)
+		}
+	}
+}
+
 func (r *kasmSessionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Info(ctx, "Creating kasm session")
 
@@ -201,12 +235,13 @@ func (r *kasmSessionResource) Create(ctx context.Context, req resource.CreateReq
 		plan.ImageID.ValueString()))
 
 	// Create the session
-	status, err := r.client.CreateKasm(
+	status, err := r.client.CreateKasm(ctx,
 		plan.UserID.ValueString(),
 		plan.ImageID.ValueString(),
 		"",
 		"",
 		plan.Share.ValueBool(),
+		plan.EnableSharing.ValueBool(),
 		plan.Persistent.ValueBool(),
 		plan.AllowResume.ValueBool(),
 		plan.SessionAuthentication.ValueBool(),
@@ -220,7 +255,13 @@ func (r *kasmSessionResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	// Debug log the response
-	tflog.Debug(ctx, fmt.Sprintf("CreateKasm response: %+v", status))
+	ctx = client.MaskLogs(ctx, status.SessionToken)
+	tflog.Debug(ctx, "CreateKasm response", map[string]interface{}{
+		"kasm_id":       status.KasmID,
+		"status":        status.Status,
+		"share_id":      status.ShareID,
+		"session_token": status.SessionToken,
+	})
 
 	// Set ID and ShareID from initial response
 	plan.ID = types.StringValue(status.KasmID)
@@ -238,7 +279,7 @@ func (r *kasmSessionResource) Create(ctx context.Context, req resource.CreateReq
 
 	// Get full session details
 	tflog.Debug(ctx, "Getting session details")
-	sessionInfo, err := r.client.GetKasmStatus(
+	sessionInfo, err := r.client.GetKasmStatus(ctx,
 		plan.UserID.ValueString(),
 		status.KasmID,
 		true,
@@ -246,7 +287,10 @@ func (r *kasmSessionResource) Create(ctx context.Context, req resource.CreateReq
 	if err != nil {
 		tflog.Warn(ctx, fmt.Sprintf("Error getting session details: %v", err))
 	} else {
-		tflog.Debug(ctx, fmt.Sprintf("Session details: %+v", sessionInfo))
+		tflog.Debug(ctx, "Session details", map[string]interface{}{
+			"operational_status": sessionInfo.OperationalStatus,
+			"has_kasm":           sessionInfo.Kasm != nil,
+		})
 
 		if sessionInfo.Kasm != nil {
 			// Update operational status from fresh data
@@ -265,9 +309,15 @@ func (r *kasmSessionResource) Create(ctx context.Context, req resource.CreateReq
 		tflog.Debug(ctx, "Waiting for share_id to be available")
 		maxRetries := 10 // Increase retries
 		for i := 0; i < maxRetries; i++ {
-			time.Sleep(2 * time.Second)
+			if err := client.SleepContext(ctx, 2*time.Second); err != nil {
+				resp.Diagnostics.AddError(
+					"Error waiting for share_id",
+					fmt.Sprintf("Wait for share_id was cancelled: %v", err),
+				)
+				return
+			}
 
-			sessionInfo, err := r.client.GetKasmStatus(
+			sessionInfo, err := r.client.GetKasmStatus(ctx,
 				plan.UserID.ValueString(),
 				status.KasmID,
 				true,
@@ -289,7 +339,7 @@ func (r *kasmSessionResource) Create(ctx context.Context, req resource.CreateReq
 	// Handle RDP if enabled
 	if plan.RDPEnabled.ValueBool() {
 		tflog.Debug(ctx, "Getting RDP connection info")
-		rdpResp, err := r.client.GetRDPConnectionInfo(
+		rdpResp, err := r.client.GetRDPConnectionInfo(ctx,
 			plan.UserID.ValueString(),
 			status.KasmID,
 			client.RDPConnectionTypeFile,
@@ -333,12 +383,17 @@ func (r *kasmSessionResource) Read(ctx context.Context, req resource.ReadRequest
 	}
 
 	// Get session status
-	status, err := r.client.GetKasmStatus(
+	status, err := r.client.GetKasmStatus(ctx,
 		state.UserID.ValueString(),
 		state.ID.ValueString(),
 		false,
 	)
 	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error reading Kasm session",
 			fmt.Sprintf("Unable to read session: %v", err),
@@ -387,7 +442,7 @@ func (r *kasmSessionResource) Update(ctx context.Context, req resource.UpdateReq
 
 	// Update RDP status if changed
 	if !plan.RDPEnabled.Equal(state.RDPEnabled) && plan.RDPEnabled.ValueBool() {
-		rdpResp, err := r.client.GetRDPConnectionInfo(
+		rdpResp, err := r.client.GetRDPConnectionInfo(ctx,
 			plan.UserID.ValueString(),
 			state.ID.ValueString(),
 			client.RDPConnectionTypeFile,
@@ -423,7 +478,7 @@ func (r *kasmSessionResource) Delete(ctx context.Context, req resource.DeleteReq
 	}
 
 	// Destroy the session
-	err := r.client.DestroyKasm(state.UserID.ValueString(), state.ID.ValueString())
+	err := r.client.DestroyKasm(ctx, state.UserID.ValueString(), state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error destroying Kasm session",
@@ -4,6 +4,7 @@
 package tests
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"os"
@@ -32,7 +33,7 @@ func cleanupExistingSessions(t *testing.T) {
 	}
 
 	// Get all sessions
-	kasms, err := c.GetKasms()
+	kasms, err := c.GetKasms(context.Background())
 	if err != nil {
 		t.Logf("Warning: Failed to get existing sessions: %v", err)
 		return
@@ -40,7 +41,7 @@ func cleanupExistingSessions(t *testing.T) {
 
 	// Destroy each session
 	for _, kasm := range kasms.Kasms {
-		err := c.DestroyKasm(kasm.UserID, kasm.KasmID)
+		err := c.DestroyKasm(context.Background(), kasm.UserID, kasm.KasmID)
 		if err != nil {
 			t.Logf("Warning: Failed to destroy session %s: %v", kasm.KasmID, err)
 		}
@@ -58,7 +59,7 @@ func ensureImageAvailable(t *testing.T) (string, bool) {
 			return "", false
 		}
 
-		images, err := c.GetImages()
+		images, err := c.GetImages(context.Background())
 		if err != nil {
 			t.Logf("Attempt %d: Error getting images: %v", i+1, err)
 			if i < maxRetries-1 {
@@ -103,7 +104,7 @@ func ensureImageAvailable(t *testing.T) (string, bool) {
 				}
 
 				// Update the image
-				_, err := c.UpdateImage(updatedImage)
+				_, err := c.UpdateImage(context.Background(), updatedImage)
 				if err != nil {
 					t.Logf("Failed to update image run_config: %v", err)
 					return "", false
@@ -134,7 +135,7 @@ func waitForGroupMembership(t *testing.T, userID string, groupID string) error {
 			return fmt.Errorf("failed to get test client")
 		}
 
-		user, err := c.GetUser(userID)
+		user, err := c.GetUser(context.Background(), userID)
 		if err != nil {
 			t.Logf("Attempt %d: Error getting user: %v", i+1, err)
 			if i < maxRetries-1 {
@@ -76,7 +76,7 @@ func (r *keepaliveResource) Create(ctx context.Context, req resource.CreateReque
 	plan.ID = types.StringValue(plan.KasmID.ValueString())
 
 	// Make the keepalive API call
-	_, err := r.client.Keepalive(plan.KasmID.ValueString())
+	_, err := r.client.Keepalive(ctx, plan.KasmID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error sending keepalive",
@@ -118,7 +118,7 @@ func (r *keepaliveResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	// Make the keepalive API call
-	_, err := r.client.Keepalive(plan.KasmID.ValueString())
+	_, err := r.client.Keepalive(ctx, plan.KasmID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error sending keepalive",
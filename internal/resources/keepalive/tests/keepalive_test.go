@@ -4,6 +4,7 @@
 package tests
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"log"
@@ -24,7 +25,7 @@ func TestAccKasmKeepalive_basic(t *testing.T) {
 	}
 
 	// Get a valid user ID
-	users, err := c.GetUsers()
+	users, err := c.GetUsers(context.Background())
 	if err != nil {
 		t.Fatalf("Failed to get users: %v", err)
 	}
@@ -35,7 +36,7 @@ func TestAccKasmKeepalive_basic(t *testing.T) {
 	log.Printf("[DEBUG] Using user ID: %s", userID)
 
 	// Get a valid image ID
-	images, err := c.GetImages()
+	images, err := c.GetImages(context.Background())
 	if err != nil {
 		t.Fatalf("Failed to get images: %v", err)
 	}
@@ -57,12 +58,13 @@ func TestAccKasmKeepalive_basic(t *testing.T) {
 	log.Printf("[DEBUG] Using image ID: %s", imageID)
 
 	// Create a test kasm
-	kasm, err := c.CreateKasm(
+	kasm, err := c.CreateKasm(context.Background(),
 		userID,
 		imageID,
 		"", // empty session token, will be created automatically
 		users[0].Username,
 		false, // share
+		false, // enableSharing
 		false, // persistent
 		false, // allowResume
 		false, // sessionAuthentication
@@ -74,7 +76,7 @@ func TestAccKasmKeepalive_basic(t *testing.T) {
 
 	// Ensure kasm cleanup
 	t.Cleanup(func() {
-		if err := c.DestroyKasm(userID, kasm.KasmID); err != nil {
+		if err := c.DestroyKasm(context.Background(), userID, kasm.KasmID); err != nil {
 			t.Logf("Warning: Failed to delete test kasm %s: %v", kasm.KasmID, err)
 		} else {
 			log.Printf("[DEBUG] Successfully deleted test kasm: %s", kasm.KasmID)
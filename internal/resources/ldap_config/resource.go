@@ -0,0 +1,368 @@
+package ldap_config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-kasm/internal/client"
+	"terraform-provider-kasm/internal/schemautil"
+	"terraform-provider-kasm/internal/validators"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &ldapConfigResource{}
+	_ resource.ResourceWithConfigure   = &ldapConfigResource{}
+	_ resource.ResourceWithImportState = &ldapConfigResource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func New() resource.Resource {
+	return &ldapConfigResource{}
+}
+
+// ldapConfigResource manages a Kasm LDAP configuration.
+type ldapConfigResource struct {
+	client *client.Client
+}
+
+// ldapConfigResourceModel maps the resource schema data.
+type ldapConfigResourceModel struct {
+	ID                     types.String `tfsdk:"id"`
+	Name                   types.String `tfsdk:"name"`
+	Enabled                types.Bool   `tfsdk:"enabled"`
+	URL                    types.String `tfsdk:"url"`
+	SearchBase             types.String `tfsdk:"search_base"`
+	SearchFilter           types.String `tfsdk:"search_filter"`
+	GroupMembershipFilter  types.String `tfsdk:"group_membership_filter"`
+	ServiceAccountDN       types.String `tfsdk:"service_account_dn"`
+	ServiceAccountPassword types.String `tfsdk:"service_account_password"`
+	UsernameDomainMatch    types.String `tfsdk:"username_domain_match"`
+	AutoCreateUsers        types.Bool   `tfsdk:"auto_create_users"`
+	EmailAttribute         types.String `tfsdk:"email_attribute"`
+	TestConnection         types.Bool   `tfsdk:"test_connection"`
+}
+
+// Metadata returns the resource type name.
+func (r *ldapConfigResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ldap_config"
+}
+
+// Schema defines the schema for the resource.
+func (r *ldapConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Attributes Kasm fills in with its own defaults when they are not set.
+	resp.Schema = schema.Schema{
+		Description: "Manages an LDAP or Active Directory directory Kasm authenticates users against. Requires a license with the ldap feature.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the LDAP configuration.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the LDAP configuration.",
+				Required:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether users can log in through the directory. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"url": schema.StringAttribute{
+				Description: "The address of the directory, such as ldaps://dc1.example.com:636.",
+				Required:    true,
+				Validators: []validator.String{
+					validators.LDAPURL(),
+				},
+			},
+			"search_base": schema.StringAttribute{
+				Description: "The DN users are searched under, such as dc=example,dc=com.",
+				Required:    true,
+			},
+			"search_filter":           schemautil.ServerDefaultString("The filter that finds the entry of a user. {0} is replaced by the username."),
+			"group_membership_filter": schemautil.ServerDefaultString("The filter that finds the groups of a user. {0} is replaced by the DN of the user."),
+			"service_account_dn": schema.StringAttribute{
+				Description: "The DN of the account Kasm binds with to search the directory.",
+				Optional:    true,
+			},
+			"service_account_password": schema.StringAttribute{
+				Description: "The password of the service account.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("service_account_dn")),
+				},
+			},
+			"username_domain_match": schema.StringAttribute{
+				Description: "Only usernames ending in this domain, such as @example.com, are authenticated against the directory.",
+				Optional:    true,
+			},
+			"auto_create_users": schema.BoolAttribute{
+				Description: "Whether a Kasm user is created the first time a directory user logs in. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"email_attribute": schema.StringAttribute{
+				Description: "The directory attribute copied to the email address of the Kasm user, such as mail.",
+				Optional:    true,
+			},
+			"test_connection": schema.BoolAttribute{
+				Description: "Whether to test the connection to the directory and the service account during apply. " +
+					"A failed test fails the apply. Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *ldapConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates the LDAP configuration.
+func (r *ldapConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ldapConfigResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.CreateLDAPConfig(ctx, expandLDAPConfig(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating LDAP Configuration",
+			fmt.Sprintf("Could not create LDAP configuration %s: %s", plan.Name.ValueString(), err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(config.LDAPID)
+	flattenServerDefaults(&plan, config, false)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.testConnection(ctx, plan, &resp.Diagnostics)
+}
+
+// Read refreshes the LDAP configuration from the server.
+func (r *ldapConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ldapConfigResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetLDAPConfig(ctx, state.ID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Debug(ctx, "LDAP configuration not found, removing from state", map[string]interface{}{
+				"id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading LDAP Configuration",
+			fmt.Sprintf("Could not read LDAP configuration %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	state.Name = types.StringValue(config.Name)
+	state.Enabled = types.BoolValue(config.Enabled)
+	state.URL = types.StringValue(config.URL)
+	state.SearchBase = types.StringValue(config.SearchBase)
+	state.ServiceAccountDN = schemautil.OptionalString(state.ServiceAccountDN, config.ServiceAccountDN)
+	// The server may not return the password; keep what was applied.
+	if config.ServiceAccountPassword != "" {
+		state.ServiceAccountPassword = types.StringValue(config.ServiceAccountPassword)
+	}
+	state.UsernameDomainMatch = schemautil.OptionalString(state.UsernameDomainMatch, config.UsernameDomainMatch)
+	state.AutoCreateUsers = types.BoolValue(config.AutoCreateAppUser)
+	state.EmailAttribute = schemautil.OptionalString(state.EmailAttribute, config.EmailAttribute)
+	if state.TestConnection.IsNull() {
+		state.TestConnection = types.BoolValue(false)
+	}
+	flattenServerDefaults(&state, config, true)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the LDAP configuration in place.
+func (r *ldapConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ldapConfigResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config := expandLDAPConfig(plan)
+	config.LDAPID = plan.ID.ValueString()
+	updated, err := r.client.UpdateLDAPConfig(ctx, config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating LDAP Configuration",
+			fmt.Sprintf("Could not update LDAP configuration %s: %s", plan.ID.ValueString(), err),
+		)
+		return
+	}
+
+	flattenServerDefaults(&plan, updated, false)
+
+	// Test before saving the plan: on failure the prior state is kept, so
+	// the next apply retries the update.
+	r.testConnection(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the LDAP configuration.
+func (r *ldapConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ldapConfigResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteLDAPConfig(ctx, state.ID.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting LDAP Configuration",
+			fmt.Sprintf("Could not delete LDAP configuration %s: %s", state.ID.ValueString(), err),
+		)
+	}
+}
+
+// ImportState imports an LDAP configuration by ID or by name.
+func (r *ldapConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if name, ok := strings.CutPrefix(req.ID, "name:"); ok {
+		configs, err := r.client.GetLDAPConfigs(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing LDAP Configuration",
+				fmt.Sprintf("Could not list LDAP configurations: %s", err),
+			)
+			return
+		}
+
+		id = ""
+		for _, config := range configs {
+			if config.Name == name {
+				id = config.LDAPID
+				break
+			}
+		}
+		if id == "" {
+			resp.Diagnostics.AddError(
+				"LDAP Configuration Not Found",
+				fmt.Sprintf("Could not find an LDAP configuration named %s", name),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// testConnection runs Kasm's connection test when test_connection is set.
+// The configuration has been saved by then. After a create the resource
+// stays in state for the next apply to correct; after an update the prior
+// state is kept.
+func (r *ldapConfigResource) testConnection(ctx context.Context, model ldapConfigResourceModel, diags *diag.Diagnostics) {
+	if !model.TestConnection.ValueBool() {
+		return
+	}
+
+	result, err := r.client.TestLDAPConfig(ctx, model.ID.ValueString())
+	if err != nil {
+		diags.AddError(
+			"LDAP Connection Test Failed",
+			fmt.Sprintf("Kasm could not connect to %s with LDAP configuration %s: %s", model.URL.ValueString(), model.Name.ValueString(), err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "LDAP connection test passed", map[string]interface{}{
+		"id":      model.ID.ValueString(),
+		"message": result.Message,
+	})
+}
+
+// expandLDAPConfig builds the API object from the plan. Unknown attributes
+// are left empty so that the server applies its defaults.
+func expandLDAPConfig(plan ldapConfigResourceModel) *client.LDAPConfig {
+	return &client.LDAPConfig{
+		Name:                   plan.Name.ValueString(),
+		Enabled:                plan.Enabled.ValueBool(),
+		URL:                    plan.URL.ValueString(),
+		SearchBase:             plan.SearchBase.ValueString(),
+		SearchFilter:           plan.SearchFilter.ValueString(),
+		GroupMembershipFilter:  plan.GroupMembershipFilter.ValueString(),
+		ServiceAccountDN:       plan.ServiceAccountDN.ValueString(),
+		ServiceAccountPassword: plan.ServiceAccountPassword.ValueString(),
+		UsernameDomainMatch:    plan.UsernameDomainMatch.ValueString(),
+		AutoCreateAppUser:      plan.AutoCreateUsers.ValueBool(),
+		EmailAttribute:         plan.EmailAttribute.ValueString(),
+	}
+}
+
+// flattenServerDefaults copies the filters the server may default into the
+// model. After a create or update only unknown attributes are filled in, so
+// that the applied configuration is kept; a refresh copies them all.
+func flattenServerDefaults(model *ldapConfigResourceModel, config *client.LDAPConfig, refresh bool) {
+	set := func(current *types.String, value string) {
+		if refresh || current.IsUnknown() {
+			*current = types.StringValue(value)
+		}
+	}
+	set(&model.SearchFilter, config.SearchFilter)
+	set(&model.GroupMembershipFilter, config.GroupMembershipFilter)
+}
@@ -0,0 +1,82 @@
+//go:build unit
+// +build unit
+
+package ldap_config
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-kasm/internal/client"
+	"terraform-provider-kasm/internal/testutil"
+)
+
+func TestLDAPConfigResource_Metadata(t *testing.T) {
+	r := New()
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "kasm"}, resp)
+
+	assert.Equal(t, "kasm_ldap_config", resp.TypeName)
+}
+
+func TestLDAPConfigResource_Schema(t *testing.T) {
+	r := New()
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	for _, name := range []string{"name", "url", "search_base"} {
+		assert.True(t, resp.Schema.Attributes[name].IsRequired(), name)
+	}
+	assert.True(t, resp.Schema.Attributes["service_account_password"].IsSensitive())
+	assert.False(t, resp.Schema.Attributes["service_account_dn"].IsSensitive())
+	for _, name := range []string{"search_filter", "group_membership_filter", "test_connection"} {
+		assert.True(t, resp.Schema.Attributes[name].IsOptional(), name)
+		assert.True(t, resp.Schema.Attributes[name].IsComputed(), name)
+	}
+}
+
+func TestLDAPConfigResource_UpdateKeepsPriorStateWhenTestFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/public/update_ldap_config":
+			w.Write([]byte(`{}`))
+		case "/api/public/test_ldap_config":
+			w.Write([]byte(`{"success": false, "message": "invalid credentials"}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	r := &ldapConfigResource{client: client.NewClient(server.URL, "test-key", "test-secret", false, client.WithMaxRetries(0))}
+
+	attrs := func(url string) testutil.Attrs {
+		return testutil.Attrs{
+			"id":              "l1",
+			"name":            "corp",
+			"url":             url,
+			"search_base":     "dc=example,dc=com",
+			"test_connection": true,
+		}
+	}
+	prior := testutil.State(ctx, r, attrs("ldaps://dc1.example.com"))
+
+	// The framework starts the response from the prior state.
+	resp := &resource.UpdateResponse{State: prior}
+	r.Update(ctx, resource.UpdateRequest{
+		Plan:  testutil.Plan(ctx, r, attrs("ldaps://dc2.example.com")),
+		State: prior,
+	}, resp)
+
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.True(t, resp.State.Raw.Equal(prior.Raw))
+}
@@ -198,7 +198,7 @@ func (r *licenseResource) Create(ctx context.Context, req resource.CreateRequest
 		activateReq.IssuedTo = plan.IssuedTo.ValueString()
 	}
 
-	license, err := r.client.Activate(activateReq)
+	license, err := r.client.Activate(ctx, activateReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error activating license",
@@ -263,7 +263,7 @@ func (r *licenseResource) Update(ctx context.Context, req resource.UpdateRequest
 		activateReq.IssuedTo = plan.IssuedTo.ValueString()
 	}
 
-	license, err := r.client.Activate(activateReq)
+	license, err := r.client.Activate(ctx, activateReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error activating license",
@@ -78,7 +78,7 @@ func (r *loginResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	loginResp, err := r.client.GetLoginURL(plan.UserID.ValueString())
+	loginResp, err := r.client.GetLoginURL(ctx, plan.UserID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error generating login URL",
@@ -102,8 +102,13 @@ func (r *loginResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	loginResp, err := r.client.GetLoginURL(state.UserID.ValueString())
+	loginResp, err := r.client.GetLoginURL(ctx, state.UserID.ValueString())
 	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error reading login URL",
 			fmt.Sprintf("Unable to read login URL: %v", err),
@@ -125,7 +130,7 @@ func (r *loginResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	loginResp, err := r.client.GetLoginURL(plan.UserID.ValueString())
+	loginResp, err := r.client.GetLoginURL(ctx, plan.UserID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating login URL",
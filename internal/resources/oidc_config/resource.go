@@ -0,0 +1,377 @@
+package oidc_config
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-kasm/internal/client"
+	"terraform-provider-kasm/internal/schemautil"
+	"terraform-provider-kasm/internal/validators"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &oidcConfigResource{}
+	_ resource.ResourceWithConfigure      = &oidcConfigResource{}
+	_ resource.ResourceWithImportState    = &oidcConfigResource{}
+	_ resource.ResourceWithValidateConfig = &oidcConfigResource{}
+)
+
+// defaultScopes are requested when no scopes are configured.
+var defaultScopes = []string{"openid", "email", "profile"}
+
+// New is a helper function to simplify the provider implementation.
+func New() resource.Resource {
+	return &oidcConfigResource{}
+}
+
+// oidcConfigResource manages a Kasm OpenID Connect provider configuration.
+type oidcConfigResource struct {
+	client *client.Client
+}
+
+// oidcConfigResourceModel maps the resource schema data.
+type oidcConfigResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	AutoLogin     types.Bool   `tfsdk:"auto_login"`
+	Issuer        types.String `tfsdk:"issuer"`
+	ClientID      types.String `tfsdk:"client_id"`
+	ClientSecret  types.String `tfsdk:"client_secret"`
+	Scopes        types.List   `tfsdk:"scopes"`
+	UsernameClaim types.String `tfsdk:"username_claim"`
+	GroupsClaim   types.String `tfsdk:"groups_claim"`
+	RedirectURL   types.String `tfsdk:"redirect_url"`
+}
+
+// Metadata returns the resource type name.
+func (r *oidcConfigResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oidc_config"
+}
+
+// Schema defines the schema for the resource.
+func (r *oidcConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	scopes := make([]attr.Value, len(defaultScopes))
+	for i, scope := range defaultScopes {
+		scopes[i] = types.StringValue(scope)
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages an OpenID Connect provider Kasm users can log in through.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the OIDC configuration.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name shown on the login button of the provider.",
+				Required:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether users can log in through the provider. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"auto_login": schema.BoolAttribute{
+				Description: "Whether users are sent straight to the provider instead of the Kasm login page. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"issuer": schema.StringAttribute{
+				Description: "The issuer URL of the provider, under which its discovery document is served.",
+				Required:    true,
+				Validators: []validator.String{
+					validators.ValidateURL(),
+				},
+			},
+			"client_id": schema.StringAttribute{
+				Description: "The client ID Kasm is registered with at the provider.",
+				Required:    true,
+			},
+			"client_secret": schema.StringAttribute{
+				Description: "The client secret Kasm is registered with at the provider.",
+				Required:    true,
+				Sensitive:   true,
+			},
+			"scopes": schema.ListAttribute{
+				Description: fmt.Sprintf("The scopes Kasm requests. Must include openid. Defaults to %s.", strings.Join(defaultScopes, ", ")),
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Default:     listdefault.StaticValue(types.ListValueMust(types.StringType, scopes)),
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+				},
+			},
+			"username_claim": schema.StringAttribute{
+				Description: "The ID token claim used as the Kasm username. Defaults to email.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("email"),
+			},
+			"groups_claim": schema.StringAttribute{
+				Description: "The ID token claim listing the groups of the user, for use with kasm_group_sso_mapping.",
+				Optional:    true,
+			},
+			"redirect_url": schema.StringAttribute{
+				Description: "The callback URL to register with the provider. Kasm derives it from its own address when not set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					validators.ValidateURL(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *oidcConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig checks that the openid scope is requested.
+func (r *oidcConfigResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config oidcConfigResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Scopes.IsNull() || config.Scopes.IsUnknown() {
+		return
+	}
+	for _, scope := range config.Scopes.Elements() {
+		if scope.IsUnknown() {
+			return
+		}
+	}
+
+	var scopes []string
+	resp.Diagnostics.Append(config.Scopes.ElementsAs(ctx, &scopes, false)...)
+	if !resp.Diagnostics.HasError() && !slices.Contains(scopes, "openid") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("scopes"),
+			"Missing openid Scope",
+			"scopes must include openid for the provider to issue an ID token.",
+		)
+	}
+}
+
+// Create creates the OIDC configuration.
+func (r *oidcConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan oidcConfigResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, diags := expandOIDCConfig(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateOIDCConfig(ctx, config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating OIDC Configuration",
+			fmt.Sprintf("Could not create OIDC configuration %s: %s", plan.Name.ValueString(), err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(created.OIDCID)
+	if plan.RedirectURL.IsUnknown() {
+		plan.RedirectURL = types.StringValue(created.RedirectURL)
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the OIDC configuration from the server.
+func (r *oidcConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state oidcConfigResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetOIDCConfig(ctx, state.ID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Debug(ctx, "OIDC configuration not found, removing from state", map[string]interface{}{
+				"id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading OIDC Configuration",
+			fmt.Sprintf("Could not read OIDC configuration %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	state.Name = types.StringValue(config.Name)
+	state.Enabled = types.BoolValue(config.Enabled)
+	state.AutoLogin = types.BoolValue(config.AutoLogin)
+	state.Issuer = types.StringValue(config.Issuer)
+	state.ClientID = types.StringValue(config.ClientID)
+	// The server may not return the secret; keep what was applied.
+	if config.ClientSecret != "" {
+		state.ClientSecret = types.StringValue(config.ClientSecret)
+	}
+	scopes, diags := types.ListValueFrom(ctx, types.StringType, config.Scopes)
+	resp.Diagnostics.Append(diags...)
+	state.Scopes = scopes
+	state.UsernameClaim = types.StringValue(config.UsernameClaim)
+	state.GroupsClaim = schemautil.OptionalString(state.GroupsClaim, config.GroupsClaim)
+	state.RedirectURL = types.StringValue(config.RedirectURL)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the OIDC configuration in place.
+func (r *oidcConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan oidcConfigResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, diags := expandOIDCConfig(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.OIDCID = plan.ID.ValueString()
+
+	updated, err := r.client.UpdateOIDCConfig(ctx, config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating OIDC Configuration",
+			fmt.Sprintf("Could not update OIDC configuration %s: %s", plan.ID.ValueString(), err),
+		)
+		return
+	}
+
+	if plan.RedirectURL.IsUnknown() {
+		plan.RedirectURL = types.StringValue(updated.RedirectURL)
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the OIDC configuration.
+func (r *oidcConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state oidcConfigResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteOIDCConfig(ctx, state.ID.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting OIDC Configuration",
+			fmt.Sprintf("Could not delete OIDC configuration %s: %s", state.ID.ValueString(), err),
+		)
+	}
+}
+
+// ImportState imports an OIDC configuration by ID or by name.
+func (r *oidcConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if name, ok := strings.CutPrefix(req.ID, "name:"); ok {
+		configs, err := r.client.GetOIDCConfigs(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing OIDC Configuration",
+				fmt.Sprintf("Could not list OIDC configurations: %s", err),
+			)
+			return
+		}
+
+		id = ""
+		for _, config := range configs {
+			if config.Name == name {
+				id = config.OIDCID
+				break
+			}
+		}
+		if id == "" {
+			resp.Diagnostics.AddError(
+				"OIDC Configuration Not Found",
+				fmt.Sprintf("Could not find an OIDC configuration named %s", name),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// expandOIDCConfig builds the API object from the plan.
+func expandOIDCConfig(ctx context.Context, plan oidcConfigResourceModel) (*client.OIDCConfig, diag.Diagnostics) {
+	config := &client.OIDCConfig{
+		Name:          plan.Name.ValueString(),
+		Enabled:       plan.Enabled.ValueBool(),
+		AutoLogin:     plan.AutoLogin.ValueBool(),
+		Issuer:        plan.Issuer.ValueString(),
+		ClientID:      plan.ClientID.ValueString(),
+		ClientSecret:  plan.ClientSecret.ValueString(),
+		UsernameClaim: plan.UsernameClaim.ValueString(),
+		GroupsClaim:   plan.GroupsClaim.ValueString(),
+		RedirectURL:   plan.RedirectURL.ValueString(),
+	}
+	diags := plan.Scopes.ElementsAs(ctx, &config.Scopes, false)
+	return config, diags
+}
@@ -0,0 +1,80 @@
+//go:build unit
+// +build unit
+
+package oidc_config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-kasm/internal/testutil"
+)
+
+func TestOIDCConfigResource_Metadata(t *testing.T) {
+	r := New()
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "kasm"}, resp)
+
+	assert.Equal(t, "kasm_oidc_config", resp.TypeName)
+}
+
+func TestOIDCConfigResource_Schema(t *testing.T) {
+	r := New()
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	for _, name := range []string{"name", "issuer", "client_id", "client_secret"} {
+		assert.True(t, resp.Schema.Attributes[name].IsRequired(), name)
+	}
+	assert.True(t, resp.Schema.Attributes["client_secret"].IsSensitive())
+	assert.True(t, resp.Schema.Attributes["scopes"].IsComputed())
+}
+
+func TestOIDCConfigResource_ValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+
+	config := func(scopes tftypes.Value) tfsdk.Config {
+		return testutil.Config(ctx, r, testutil.Attrs{
+			"name":          "Keycloak",
+			"issuer":        "https://sso.example.com/realms/corp",
+			"client_id":     "kasm",
+			"client_secret": "s3cret",
+			"scopes":        scopes,
+		})
+	}
+	scopes := func(values ...string) tftypes.Value {
+		elems := make([]tftypes.Value, len(values))
+		for i, v := range values {
+			elems[i] = tftypes.NewValue(tftypes.String, v)
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elems)
+	}
+
+	testCases := []struct {
+		name        string
+		config      tfsdk.Config
+		expectError bool
+	}{
+		{name: "Default scopes", config: config(tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil))},
+		{name: "With openid", config: config(scopes("openid", "groups"))},
+		{name: "Unknown scope", config: config(tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, tftypes.UnknownValue)}))},
+		{name: "Without openid", config: config(scopes("email", "profile")), expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &resource.ValidateConfigResponse{}
+			r.(resource.ResourceWithValidateConfig).ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tc.config}, resp)
+			assert.Equal(t, tc.expectError, resp.Diagnostics.HasError())
+		})
+	}
+}
@@ -105,7 +105,7 @@ func (r *registryResource) Create(ctx context.Context, req resource.CreateReques
 		Channel:        plan.Channel.ValueString(),
 	}
 
-	err := r.client.CreateRegistry(createReq)
+	err := r.client.CreateRegistry(ctx, createReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating registry",
@@ -120,7 +120,7 @@ func (r *registryResource) Create(ctx context.Context, req resource.CreateReques
 	var createdRegistry *client.Registry
 	maxRetries := 2
 	for i := 0; i < maxRetries; i++ {
-		registries, err := r.client.GetRegistries()
+		registries, err := r.client.GetRegistries(client.NoCache(ctx))
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error retrieving registries",
@@ -139,7 +139,13 @@ func (r *registryResource) Create(ctx context.Context, req resource.CreateReques
 		}
 		if i < maxRetries-1 {
 			tflog.Info(ctx, fmt.Sprintf("Registry not found, retrying in 5 seconds (attempt %d/%d)", i+1, maxRetries))
-			time.Sleep(5 * time.Second)
+			if err := client.SleepContext(ctx, 5*time.Second); err != nil {
+				resp.Diagnostics.AddError(
+					"Error creating registry",
+					fmt.Sprintf("Wait for registry was cancelled: %v", err),
+				)
+				return
+			}
 		}
 	}
 
@@ -179,7 +185,7 @@ func (r *registryResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	registries, err := r.client.GetRegistries()
+	registries, err := r.client.GetRegistries(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Kasm Registry",
@@ -248,7 +254,7 @@ func (r *registryResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	err := r.client.DeleteRegistry(state.ID.ValueString())
+	err := r.client.DeleteRegistry(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Kasm Registry",
@@ -272,8 +278,8 @@ func validateRegistryURL(url string) error {
 }
 
 // Helper function to find a registry by ID
-func (r *registryResource) findRegistryByID(registryID string) (*client.Registry, error) {
-	registries, err := r.client.GetRegistries()
+func (r *registryResource) findRegistryByID(ctx context.Context, registryID string) (*client.Registry, error) {
+	registries, err := r.client.GetRegistries(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -288,8 +294,8 @@ func (r *registryResource) findRegistryByID(registryID string) (*client.Registry
 }
 
 // Helper function to find a registry by URL
-func (r *registryResource) findRegistryByURL(url string) (*client.Registry, error) {
-	registries, err := r.client.GetRegistries()
+func (r *registryResource) findRegistryByURL(ctx context.Context, url string) (*client.Registry, error) {
+	registries, err := r.client.GetRegistries(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -368,7 +374,7 @@ func (d *registriesDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 func (d *registriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state registriesDataSourceModel
 
-	registries, err := d.client.GetRegistries()
+	registries, err := d.client.GetRegistries(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Kasm Registries",
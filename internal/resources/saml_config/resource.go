@@ -0,0 +1,456 @@
+package saml_config
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-kasm/internal/client"
+	"terraform-provider-kasm/internal/schemautil"
+	"terraform-provider-kasm/internal/validators"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &samlConfigResource{}
+	_ resource.ResourceWithConfigure      = &samlConfigResource{}
+	_ resource.ResourceWithImportState    = &samlConfigResource{}
+	_ resource.ResourceWithValidateConfig = &samlConfigResource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func New() resource.Resource {
+	return &samlConfigResource{}
+}
+
+// samlConfigResource manages a Kasm SAML identity provider configuration.
+type samlConfigResource struct {
+	client *client.Client
+}
+
+// samlConfigResourceModel maps the resource schema data.
+type samlConfigResourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	Enabled              types.Bool   `tfsdk:"enabled"`
+	AutoLogin            types.Bool   `tfsdk:"auto_login"`
+	IdPEntityID          types.String `tfsdk:"idp_entity_id"`
+	IdPSSOURL            types.String `tfsdk:"idp_sso_url"`
+	IdPSLOURL            types.String `tfsdk:"idp_slo_url"`
+	IdPX509Cert          types.String `tfsdk:"idp_x509_cert"`
+	SPEntityID           types.String `tfsdk:"sp_entity_id"`
+	SPACSURL             types.String `tfsdk:"sp_acs_url"`
+	SPSLOURL             types.String `tfsdk:"sp_slo_url"`
+	SPX509Cert           types.String `tfsdk:"sp_x509_cert"`
+	SPPrivateKey         types.String `tfsdk:"sp_private_key"`
+	WantAssertionsSigned types.Bool   `tfsdk:"want_assertions_signed"`
+	AuthnRequestsSigned  types.Bool   `tfsdk:"authn_requests_signed"`
+	UsernameAttribute    types.String `tfsdk:"username_attribute"`
+	GroupsAttribute      types.String `tfsdk:"groups_attribute"`
+	AttributeMappings    types.Map    `tfsdk:"attribute_mappings"`
+}
+
+// Metadata returns the resource type name.
+func (r *samlConfigResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_saml_config"
+}
+
+// Schema defines the schema for the resource.
+func (r *samlConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Service provider endpoints Kasm derives from its own address when they
+	// are not set.
+	resp.Schema = schema.Schema{
+		Description: "Manages a SAML 2.0 identity provider Kasm users can log in through. Requires a license with the saml feature.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the SAML configuration.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name shown on the login button of the identity provider.",
+				Required:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether users can log in through the identity provider. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"auto_login": schema.BoolAttribute{
+				Description: "Whether users are sent straight to the identity provider instead of the Kasm login page. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"idp_entity_id": schema.StringAttribute{
+				Description: "The entity ID of the identity provider.",
+				Required:    true,
+			},
+			"idp_sso_url": schema.StringAttribute{
+				Description: "The single sign-on URL of the identity provider.",
+				Required:    true,
+				Validators: []validator.String{
+					validators.ValidateURL(),
+				},
+			},
+			"idp_slo_url": schema.StringAttribute{
+				Description: "The single logout URL of the identity provider.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.ValidateURL(),
+				},
+			},
+			"idp_x509_cert": schema.StringAttribute{
+				Description: "The certificate the identity provider signs assertions with, PEM encoded or as the base64 body shown in its metadata.",
+				Required:    true,
+				Validators: []validator.String{
+					validators.X509Certificate(),
+				},
+			},
+			"sp_entity_id": schemautil.ServerDefaultString("The entity ID of Kasm as a service provider."),
+			"sp_acs_url":   schemautil.ServerDefaultString("The assertion consumer service URL the identity provider posts assertions to."),
+			"sp_slo_url":   schemautil.ServerDefaultString("The single logout URL of Kasm as a service provider."),
+			"sp_x509_cert": schema.StringAttribute{
+				Description: "The certificate Kasm signs requests with, PEM encoded.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.X509Certificate(),
+				},
+			},
+			"sp_private_key": schema.StringAttribute{
+				Description: "The PEM encoded private key of sp_x509_cert.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					validators.PEMPrivateKey(),
+					stringvalidator.AlsoRequires(path.MatchRoot("sp_x509_cert")),
+				},
+			},
+			"want_assertions_signed": schema.BoolAttribute{
+				Description: "Whether assertions must be signed by the identity provider. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"authn_requests_signed": schema.BoolAttribute{
+				Description: "Whether Kasm signs authentication requests. Requires sp_x509_cert and sp_private_key. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"username_attribute": schema.StringAttribute{
+				Description: "The assertion attribute used as the Kasm username. The NameID is used when not set.",
+				Optional:    true,
+			},
+			"groups_attribute": schema.StringAttribute{
+				Description: "The assertion attribute listing the groups of the user, for use with kasm_group_sso_mapping.",
+				Optional:    true,
+			},
+			"attribute_mappings": schema.MapAttribute{
+				Description: fmt.Sprintf("Assertion attributes copied to the Kasm user, keyed by user field: %s.",
+					strings.Join(client.SAMLAttributeMappingFields, ", ")),
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.OneOf(client.SAMLAttributeMappingFields...)),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *samlConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig checks that signed requests have a signing key and that the
+// service provider certificate matches its key.
+func (r *samlConfigResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config samlConfigResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.AuthnRequestsSigned.ValueBool() && config.SPPrivateKey.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("authn_requests_signed"),
+			"Missing Signing Key",
+			"authn_requests_signed requires sp_x509_cert and sp_private_key.",
+		)
+	}
+
+	if config.SPX509Cert.IsNull() || config.SPX509Cert.IsUnknown() || config.SPPrivateKey.IsNull() || config.SPPrivateKey.IsUnknown() {
+		return
+	}
+	// Malformed values are reported by the attribute validators.
+	if validators.ParseX509Certificates(config.SPX509Cert.ValueString()) != nil || validators.ParsePrivateKey(config.SPPrivateKey.ValueString()) != nil {
+		return
+	}
+	if err := validators.MatchKeyPair(config.SPX509Cert.ValueString(), config.SPPrivateKey.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("sp_private_key"),
+			"Invalid Service Provider Key",
+			err.Error(),
+		)
+	}
+}
+
+// Create creates the SAML configuration.
+func (r *samlConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan samlConfigResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, diags := expandSAMLConfig(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateSAMLConfig(ctx, config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating SAML Configuration",
+			fmt.Sprintf("Could not create SAML configuration %s: %s", plan.Name.ValueString(), err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(created.SAMLID)
+	flattenServerDefaults(&plan, created, false)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the SAML configuration from the server.
+func (r *samlConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state samlConfigResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetSAMLConfig(ctx, state.ID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Debug(ctx, "SAML configuration not found, removing from state", map[string]interface{}{
+				"id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading SAML Configuration",
+			fmt.Sprintf("Could not read SAML configuration %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	state.Name = types.StringValue(config.Name)
+	state.Enabled = types.BoolValue(config.Enabled)
+	state.AutoLogin = types.BoolValue(config.AutoLogin)
+	state.IdPEntityID = types.StringValue(config.IdPEntityID)
+	state.IdPSSOURL = types.StringValue(config.IdPSSOURL)
+	state.IdPSLOURL = schemautil.OptionalString(state.IdPSLOURL, config.IdPSLOURL)
+	// Kasm may store certificates without PEM armour or with different line
+	// breaks; only a different certificate counts as a change.
+	if !sameCertificate(state.IdPX509Cert.ValueString(), config.IdPX509Cert) {
+		state.IdPX509Cert = types.StringValue(config.IdPX509Cert)
+	}
+	if !sameCertificate(state.SPX509Cert.ValueString(), config.SPX509Cert) {
+		state.SPX509Cert = schemautil.OptionalString(state.SPX509Cert, config.SPX509Cert)
+	}
+	// The server may not return the private key; keep what was applied.
+	if config.SPPrivateKey != "" {
+		state.SPPrivateKey = types.StringValue(config.SPPrivateKey)
+	}
+	state.WantAssertionsSigned = types.BoolValue(config.WantAssertionsSigned)
+	state.AuthnRequestsSigned = types.BoolValue(config.AuthnRequestsSigned)
+	state.UsernameAttribute = schemautil.OptionalString(state.UsernameAttribute, config.UsernameAttribute)
+	state.GroupsAttribute = schemautil.OptionalString(state.GroupsAttribute, config.GroupsAttribute)
+	if len(config.AttributeMappings) > 0 || !state.AttributeMappings.IsNull() {
+		mappings, diags := types.MapValueFrom(ctx, types.StringType, config.AttributeMappings)
+		resp.Diagnostics.Append(diags...)
+		state.AttributeMappings = mappings
+	}
+	flattenServerDefaults(&state, config, true)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the SAML configuration in place.
+func (r *samlConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan samlConfigResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, diags := expandSAMLConfig(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.SAMLID = plan.ID.ValueString()
+
+	updated, err := r.client.UpdateSAMLConfig(ctx, config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating SAML Configuration",
+			fmt.Sprintf("Could not update SAML configuration %s: %s", plan.ID.ValueString(), err),
+		)
+		return
+	}
+
+	flattenServerDefaults(&plan, updated, false)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the SAML configuration.
+func (r *samlConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state samlConfigResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteSAMLConfig(ctx, state.ID.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting SAML Configuration",
+			fmt.Sprintf("Could not delete SAML configuration %s: %s", state.ID.ValueString(), err),
+		)
+	}
+}
+
+// ImportState imports a SAML configuration by ID or by name.
+func (r *samlConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if name, ok := strings.CutPrefix(req.ID, "name:"); ok {
+		configs, err := r.client.GetSAMLConfigs(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing SAML Configuration",
+				fmt.Sprintf("Could not list SAML configurations: %s", err),
+			)
+			return
+		}
+
+		id = ""
+		for _, config := range configs {
+			if config.Name == name {
+				id = config.SAMLID
+				break
+			}
+		}
+		if id == "" {
+			resp.Diagnostics.AddError(
+				"SAML Configuration Not Found",
+				fmt.Sprintf("Could not find a SAML configuration named %s", name),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// expandSAMLConfig builds the API object from the plan. Unknown attributes
+// are left empty so that the server applies its defaults.
+func expandSAMLConfig(ctx context.Context, plan samlConfigResourceModel) (*client.SAMLConfig, diag.Diagnostics) {
+	config := &client.SAMLConfig{
+		Name:                 plan.Name.ValueString(),
+		Enabled:              plan.Enabled.ValueBool(),
+		AutoLogin:            plan.AutoLogin.ValueBool(),
+		IdPEntityID:          plan.IdPEntityID.ValueString(),
+		IdPSSOURL:            plan.IdPSSOURL.ValueString(),
+		IdPSLOURL:            plan.IdPSLOURL.ValueString(),
+		IdPX509Cert:          plan.IdPX509Cert.ValueString(),
+		SPEntityID:           plan.SPEntityID.ValueString(),
+		SPACSURL:             plan.SPACSURL.ValueString(),
+		SPSLOURL:             plan.SPSLOURL.ValueString(),
+		SPX509Cert:           plan.SPX509Cert.ValueString(),
+		SPPrivateKey:         plan.SPPrivateKey.ValueString(),
+		WantAssertionsSigned: plan.WantAssertionsSigned.ValueBool(),
+		AuthnRequestsSigned:  plan.AuthnRequestsSigned.ValueBool(),
+		UsernameAttribute:    plan.UsernameAttribute.ValueString(),
+		GroupsAttribute:      plan.GroupsAttribute.ValueString(),
+	}
+
+	var diags diag.Diagnostics
+	if !plan.AttributeMappings.IsNull() && !plan.AttributeMappings.IsUnknown() {
+		diags = plan.AttributeMappings.ElementsAs(ctx, &config.AttributeMappings, false)
+	}
+	return config, diags
+}
+
+// flattenServerDefaults copies the service provider endpoints the server may
+// default into the model. After a create or update only unknown attributes
+// are filled in, so that the applied configuration is kept; a refresh copies
+// them all.
+func flattenServerDefaults(model *samlConfigResourceModel, config *client.SAMLConfig, refresh bool) {
+	set := func(current *types.String, value string) {
+		if refresh || current.IsUnknown() {
+			*current = types.StringValue(value)
+		}
+	}
+	set(&model.SPEntityID, config.SPEntityID)
+	set(&model.SPACSURL, config.SPACSURL)
+	set(&model.SPSLOURL, config.SPSLOURL)
+}
+
+// sameCertificate reports whether two certificates differ only in PEM
+// armour and whitespace.
+func sameCertificate(a, b string) bool {
+	return certificateBody(a) == certificateBody(b)
+}
+
+func certificateBody(cert string) string {
+	return strings.Join(strings.Fields(pemArmour.ReplaceAllString(cert, "")), "")
+}
+
+// pemArmour matches PEM BEGIN and END lines.
+var pemArmour = regexp.MustCompile(`-----(BEGIN|END) [A-Z0-9 ]+-----`)
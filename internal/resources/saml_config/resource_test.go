@@ -0,0 +1,124 @@
+//go:build unit
+// +build unit
+
+package saml_config
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-kasm/internal/testutil"
+)
+
+// testKeyPair returns a self-signed certificate and its private key, both
+// PEM encoded.
+func testKeyPair(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kasm.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestSAMLConfigResource_Metadata(t *testing.T) {
+	r := New()
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "kasm"}, resp)
+
+	assert.Equal(t, "kasm_saml_config", resp.TypeName)
+}
+
+func TestSAMLConfigResource_Schema(t *testing.T) {
+	r := New()
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	for _, name := range []string{"name", "idp_entity_id", "idp_sso_url", "idp_x509_cert"} {
+		assert.True(t, resp.Schema.Attributes[name].IsRequired(), name)
+	}
+	assert.True(t, resp.Schema.Attributes["sp_private_key"].IsSensitive())
+	assert.False(t, resp.Schema.Attributes["idp_x509_cert"].IsSensitive())
+}
+
+func TestSAMLConfigResource_ValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+
+	cert, key := testKeyPair(t)
+	_, otherKey := testKeyPair(t)
+
+	config := func(spCert, spKey interface{}, signed bool) tfsdk.Config {
+		return testutil.Config(ctx, r, testutil.Attrs{
+			"name":                  "Okta",
+			"idp_entity_id":         "http://www.okta.com/exk1",
+			"idp_sso_url":           "https://example.okta.com/app/sso/saml",
+			"idp_x509_cert":         cert,
+			"sp_x509_cert":          spCert,
+			"sp_private_key":        spKey,
+			"authn_requests_signed": signed,
+		})
+	}
+
+	testCases := []struct {
+		name        string
+		config      tfsdk.Config
+		expectError bool
+	}{
+		{name: "IdP only", config: config(nil, nil, false)},
+		{name: "Signed requests", config: config(cert, key, true)},
+		{name: "Signed without key", config: config(nil, nil, true), expectError: true},
+		{name: "Mismatched key", config: config(cert, otherKey, false), expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &resource.ValidateConfigResponse{}
+			r.(resource.ResourceWithValidateConfig).ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tc.config}, resp)
+			assert.Equal(t, tc.expectError, resp.Diagnostics.HasError())
+		})
+	}
+}
+
+func TestSameCertificate(t *testing.T) {
+	cert, _ := testKeyPair(t)
+	block, _ := pem.Decode([]byte(cert))
+	body := string(pem.EncodeToMemory(block))
+
+	assert.True(t, sameCertificate(cert, body))
+	assert.True(t, sameCertificate(cert, cert[len("-----BEGIN CERTIFICATE-----\n"):len(cert)-len("-----END CERTIFICATE-----\n")]))
+	other, _ := testKeyPair(t)
+	assert.False(t, sameCertificate(cert, other))
+}
@@ -0,0 +1,369 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-kasm/internal/client"
+	"terraform-provider-kasm/internal/schemautil"
+	"terraform-provider-kasm/internal/validators"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &serverResource{}
+	_ resource.ResourceWithConfigure      = &serverResource{}
+	_ resource.ResourceWithImportState    = &serverResource{}
+	_ resource.ResourceWithModifyPlan     = &serverResource{}
+	_ resource.ResourceWithValidateConfig = &serverResource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func New() resource.Resource {
+	return &serverResource{}
+}
+
+// serverResource manages a fixed Kasm server.
+type serverResource struct {
+	client *client.Client
+}
+
+// serverResourceModel maps the resource schema data.
+type serverResourceModel struct {
+	ID                      types.String `tfsdk:"id"`
+	Name                    types.String `tfsdk:"name"`
+	Hostname                types.String `tfsdk:"hostname"`
+	ConnectionType          types.String `tfsdk:"connection_type"`
+	Port                    types.Int64  `tfsdk:"port"`
+	Username                types.String `tfsdk:"username"`
+	Password                types.String `tfsdk:"password"`
+	PrivateKey              types.String `tfsdk:"private_key"`
+	MaxSimultaneousSessions types.Int64  `tfsdk:"max_simultaneous_sessions"`
+	ZoneID                  types.String `tfsdk:"zone_id"`
+	ServerPoolID            types.String `tfsdk:"server_pool_id"`
+	Enabled                 types.Bool   `tfsdk:"enabled"`
+}
+
+// Metadata returns the resource type name.
+func (r *serverResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server"
+}
+
+// Schema defines the schema for the resource.
+func (r *serverResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a fixed Kasm server, an existing RDP, VNC, SSH or KasmVNC host that server workspaces connect to. " +
+			"Set the server_id of a kasm_image to the ID of this resource to launch the workspace on it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the server.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The friendly name of the server.",
+				Required:    true,
+			},
+			"hostname": schema.StringAttribute{
+				Description: "The hostname or IP address Kasm connects to.",
+				Required:    true,
+			},
+			"connection_type": schema.StringAttribute{
+				Description: fmt.Sprintf("The protocol Kasm connects with: one of %s.", strings.Join(client.ServerConnectionTypes, ", ")),
+				Required:    true,
+				Validators: []validator.String{
+					validators.StringOneOf(client.ServerConnectionTypes...),
+				},
+			},
+			"port": schema.Int64Attribute{
+				Description: "The port Kasm connects to. Defaults to the well-known port of the connection type: " +
+					"3389 for rdp, 5900 for vnc, 22 for ssh and 6901 for kasmvnc.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "The username Kasm authenticates with. When unset, users are prompted for their credentials.",
+				Optional:    true,
+			},
+			"password": schema.StringAttribute{
+				Description: "The password Kasm authenticates with.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"private_key": schema.StringAttribute{
+				Description: "The PEM encoded private key Kasm authenticates with. Only valid for ssh connections.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"max_simultaneous_sessions": schema.Int64Attribute{
+				Description: "The maximum number of sessions on the server at once. 0 means no limit. Defaults to 1.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Description: "The ID of the zone the server belongs to.",
+				Optional:    true,
+			},
+			"server_pool_id": schema.StringAttribute{
+				Description: "The ID of the kasm_server_pool the server belongs to.",
+				Optional:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether sessions can be launched on the server. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *serverResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig rejects a private key on connections other than ssh.
+func (r *serverResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config serverResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.PrivateKey.IsNull() || config.ConnectionType.IsNull() || config.ConnectionType.IsUnknown() {
+		return
+	}
+	if config.ConnectionType.ValueString() != client.ServerConnectionSSH {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("private_key"),
+			"Invalid Server Credentials",
+			fmt.Sprintf("private_key can only be used with ssh connections, not %s.", config.ConnectionType.ValueString()),
+		)
+	}
+}
+
+// ModifyPlan fills in the default port of the connection type when no port
+// is configured, so that changing the connection type also changes the port.
+func (r *serverResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config, plan serverResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Port.IsNull() || plan.ConnectionType.IsUnknown() {
+		return
+	}
+	if port := client.DefaultServerPort(plan.ConnectionType.ValueString()); port != 0 {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("port"), int64(port))...)
+	}
+}
+
+// Create registers the server.
+func (r *serverResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan serverResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	server, err := r.client.CreateServer(ctx, expandServer(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Server",
+			fmt.Sprintf("Could not create server %s: %s", plan.Name.ValueString(), err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(server.ServerID)
+	if plan.Port.IsUnknown() {
+		plan.Port = types.Int64Value(int64(server.Port))
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the server from the server.
+func (r *serverResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state serverResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	server, err := r.client.GetServer(ctx, state.ID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Debug(ctx, "Server not found, removing from state", map[string]interface{}{
+				"id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Server",
+			fmt.Sprintf("Could not read server %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	state.Name = types.StringValue(server.Name)
+	state.Hostname = types.StringValue(server.Hostname)
+	state.ConnectionType = types.StringValue(server.ConnectionType)
+	state.Port = types.Int64Value(int64(server.Port))
+	state.Username = schemautil.OptionalString(state.Username, server.Username)
+	// The server may not return credentials; keep what was applied.
+	if server.Password != "" {
+		state.Password = types.StringValue(server.Password)
+	}
+	if server.PrivateKey != "" {
+		state.PrivateKey = types.StringValue(server.PrivateKey)
+	}
+	state.MaxSimultaneousSessions = types.Int64Value(int64(server.MaxSimultaneousSessions))
+	state.ZoneID = schemautil.OptionalString(state.ZoneID, server.ZoneID)
+	state.ServerPoolID = schemautil.OptionalString(state.ServerPoolID, server.ServerPoolID)
+	state.Enabled = types.BoolValue(server.Enabled)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the server in place.
+func (r *serverResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan serverResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	server := expandServer(plan)
+	server.ServerID = plan.ID.ValueString()
+	if _, err := r.client.UpdateServer(ctx, server); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Server",
+			fmt.Sprintf("Could not update server %s: %s", plan.ID.ValueString(), err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the server.
+func (r *serverResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state serverResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteServer(ctx, state.ID.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Server",
+			fmt.Sprintf("Could not delete server %s: %s", state.ID.ValueString(), err),
+		)
+	}
+}
+
+// ImportState imports a server by ID or by name.
+func (r *serverResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if name, ok := strings.CutPrefix(req.ID, "name:"); ok {
+		servers, err := r.client.GetServers(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Server",
+				fmt.Sprintf("Could not list servers: %s", err),
+			)
+			return
+		}
+
+		id = ""
+		for _, server := range servers {
+			if server.Name == name {
+				id = server.ServerID
+				break
+			}
+		}
+		if id == "" {
+			resp.Diagnostics.AddError(
+				"Server Not Found",
+				fmt.Sprintf("Could not find a server named %s", name),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// expandServer builds the API object from the plan.
+func expandServer(plan serverResourceModel) *client.Server {
+	server := &client.Server{
+		Name:                    plan.Name.ValueString(),
+		Hostname:                plan.Hostname.ValueString(),
+		ConnectionType:          plan.ConnectionType.ValueString(),
+		Port:                    int(plan.Port.ValueInt64()),
+		Username:                plan.Username.ValueString(),
+		Password:                plan.Password.ValueString(),
+		PrivateKey:              plan.PrivateKey.ValueString(),
+		MaxSimultaneousSessions: int(plan.MaxSimultaneousSessions.ValueInt64()),
+		ZoneID:                  plan.ZoneID.ValueString(),
+		ServerPoolID:            plan.ServerPoolID.ValueString(),
+		Enabled:                 plan.Enabled.ValueBool(),
+	}
+	if server.Port == 0 {
+		server.Port = client.DefaultServerPort(server.ConnectionType)
+	}
+	return server
+}
@@ -0,0 +1,107 @@
+//go:build unit
+// +build unit
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-kasm/internal/testutil"
+)
+
+func TestServerResource_Metadata(t *testing.T) {
+	r := New()
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "kasm"}, resp)
+
+	assert.Equal(t, "kasm_server", resp.TypeName)
+}
+
+func TestServerResource_Schema(t *testing.T) {
+	r := New()
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	for _, name := range []string{"name", "hostname", "connection_type"} {
+		assert.True(t, resp.Schema.Attributes[name].IsRequired(), name)
+	}
+	assert.True(t, resp.Schema.Attributes["password"].IsSensitive())
+	assert.True(t, resp.Schema.Attributes["private_key"].IsSensitive())
+	assert.True(t, resp.Schema.Attributes["port"].IsComputed())
+}
+
+// serverValue builds a configuration or plan of the resource.
+func serverValue(ctx context.Context, r resource.Resource, connectionType string, port, privateKey interface{}) (tfsdk.Config, tfsdk.Plan) {
+	attrs := testutil.Attrs{
+		"name":            "jump",
+		"hostname":        "10.0.0.5",
+		"connection_type": connectionType,
+		"port":            port,
+		"private_key":     privateKey,
+	}
+	return testutil.Config(ctx, r, attrs), testutil.Plan(ctx, r, attrs)
+}
+
+func TestServerResource_ValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+
+	testCases := []struct {
+		name           string
+		connectionType string
+		privateKey     interface{}
+		expectError    bool
+	}{
+		{name: "RDP password", connectionType: "rdp"},
+		{name: "SSH key", connectionType: "ssh", privateKey: "key"},
+		{name: "VNC key", connectionType: "vnc", privateKey: "key", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config, _ := serverValue(ctx, r, tc.connectionType, nil, tc.privateKey)
+			resp := &resource.ValidateConfigResponse{}
+			r.(resource.ResourceWithValidateConfig).ValidateConfig(ctx, resource.ValidateConfigRequest{Config: config}, resp)
+			assert.Equal(t, tc.expectError, resp.Diagnostics.HasError())
+		})
+	}
+}
+
+func TestServerResource_ModifyPlan(t *testing.T) {
+	ctx := context.Background()
+	r := New()
+
+	testCases := []struct {
+		name           string
+		connectionType string
+		port           interface{}
+		expected       int64
+	}{
+		{name: "RDP default", connectionType: "rdp", expected: 3389},
+		{name: "KasmVNC default", connectionType: "kasmvnc", expected: 6901},
+		{name: "Configured port", connectionType: "ssh", port: 2222, expected: 2222},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config, plan := serverValue(ctx, r, tc.connectionType, tc.port, nil)
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			r.(resource.ResourceWithModifyPlan).ModifyPlan(ctx, resource.ModifyPlanRequest{Config: config, Plan: plan}, resp)
+			assert.False(t, resp.Diagnostics.HasError())
+
+			var port types.Int64
+			resp.Plan.GetAttribute(ctx, path.Root("port"), &port)
+			assert.Equal(t, tc.expected, port.ValueInt64())
+		})
+	}
+}
@@ -0,0 +1,32 @@
+//go:build unit
+// +build unit
+
+package server_pool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerPoolResource_Metadata(t *testing.T) {
+	r := New()
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "kasm"}, resp)
+
+	assert.Equal(t, "kasm_server_pool", resp.TypeName)
+}
+
+func TestServerPoolResource_Schema(t *testing.T) {
+	r := New()
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.True(t, resp.Schema.Attributes["name"].IsRequired())
+	assert.True(t, resp.Schema.Attributes["id"].IsComputed())
+}
@@ -130,7 +130,7 @@ func (r *sessionTokenResource) Create(ctx context.Context, req resource.CreateRe
 	createReq := &client.CreateSessionTokenRequest{}
 	createReq.TargetUser.UserID = plan.UserID.ValueString()
 
-	sessionToken, err := r.client.CreateSessionToken(createReq)
+	sessionToken, err := r.client.CreateSessionToken(ctx, createReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating session token",
@@ -163,8 +163,13 @@ func (r *sessionTokenResource) Read(ctx context.Context, req resource.ReadReques
 	getReq := &client.GetSessionTokenRequest{}
 	getReq.TargetSessionToken.SessionToken = state.SessionToken.ValueString()
 
-	sessionToken, err := r.client.GetSessionToken(getReq)
+	sessionToken, err := r.client.GetSessionToken(ctx, getReq)
 	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading Kasm Session Token",
 			fmt.Sprintf("Could not read session token ID %s: %s", state.ID.ValueString(), err),
@@ -192,7 +197,7 @@ func (r *sessionTokenResource) Update(ctx context.Context, req resource.UpdateRe
 	updateReq := &client.UpdateSessionTokenRequest{}
 	updateReq.TargetSessionToken.SessionToken = plan.SessionToken.ValueString()
 
-	sessionToken, err := r.client.UpdateSessionToken(updateReq)
+	sessionToken, err := r.client.UpdateSessionToken(ctx, updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Kasm Session Token",
@@ -221,7 +226,7 @@ func (r *sessionTokenResource) Delete(ctx context.Context, req resource.DeleteRe
 	deleteReq := &client.DeleteSessionTokenRequest{}
 	deleteReq.TargetSessionToken.SessionToken = state.SessionToken.ValueString()
 
-	err := r.client.DeleteSessionToken(deleteReq)
+	err := r.client.DeleteSessionToken(ctx, deleteReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Kasm Session Token",
@@ -151,7 +151,7 @@ func (r *sessionPermissionResource) Create(ctx context.Context, req resource.Cre
 	}
 
 	// Create the session permission
-	perms, err := r.client.SetSessionPermissions(request)
+	perms, err := r.client.SetSessionPermissions(ctx, request)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating session permission",
@@ -210,8 +210,13 @@ func (r *sessionPermissionResource) Read(ctx context.Context, req resource.ReadR
 		},
 	}
 
-	perms, err := r.client.GetSessionPermissions(request)
+	perms, err := r.client.GetSessionPermissions(ctx, request)
 	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error reading session permission",
 			fmt.Sprintf("Could not read session permission: %v", err),
@@ -293,7 +298,7 @@ func (r *sessionPermissionResource) Update(ctx context.Context, req resource.Upd
 	}
 
 	// Update the session permission
-	perms, err := r.client.SetSessionPermissions(request)
+	perms, err := r.client.SetSessionPermissions(ctx, request)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating session permission",
@@ -346,7 +351,7 @@ func (r *sessionPermissionResource) Delete(ctx context.Context, req resource.Del
 		},
 	}
 
-	err := r.client.DeleteAllSessionPermissions(request)
+	err := r.client.DeleteAllSessionPermissions(ctx, request)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting session permission",
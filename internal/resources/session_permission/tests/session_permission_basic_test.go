@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"testing"
@@ -194,7 +195,7 @@ func ensureImageAvailable(t *testing.T) (string, bool) {
 			return "", false
 		}
 
-		images, err := c.GetImages()
+		images, err := c.GetImages(context.Background())
 		if err != nil {
 			t.Logf("Attempt %d: Error getting images: %v", i+1, err)
 			if i < maxRetries-1 {
@@ -241,7 +242,7 @@ func ensureImageAvailable(t *testing.T) (string, bool) {
 				}
 
 				// Update the image
-				_, err := c.UpdateImage(updatedImage)
+				_, err := c.UpdateImage(context.Background(), updatedImage)
 				if err != nil {
 					t.Logf("Failed to update image run_config: %v", err)
 					return "", false
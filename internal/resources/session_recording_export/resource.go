@@ -0,0 +1,395 @@
+package session_recording_export
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-kasm/internal/client"
+	"terraform-provider-kasm/internal/validators"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &recordingExportResource{}
+	_ resource.ResourceWithConfigure  = &recordingExportResource{}
+	_ resource.ResourceWithModifyPlan = &recordingExportResource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func New() resource.Resource {
+	return &recordingExportResource{}
+}
+
+// recordingExportResource copies the recordings of a set of sessions to a
+// local directory and keeps them in step with the server.
+type recordingExportResource struct {
+	client *client.Client
+}
+
+// recordingExportResourceModel maps the resource schema data.
+type recordingExportResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	KasmIDs      types.Set    `tfsdk:"kasm_ids"`
+	Directory    types.String `tfsdk:"directory"`
+	Concurrency  types.Int64  `tfsdk:"concurrency"`
+	RecordingIDs types.Set    `tfsdk:"recording_ids"`
+	Files        types.Map    `tfsdk:"files"`
+}
+
+// exportedFileModel is an element of the files map.
+type exportedFileModel struct {
+	KasmID types.String `tfsdk:"kasm_id"`
+	Path   types.String `tfsdk:"path"`
+	SHA256 types.String `tfsdk:"sha256"`
+	Size   types.Int64  `tfsdk:"size"`
+}
+
+var exportedFileType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"kasm_id": types.StringType,
+	"path":    types.StringType,
+	"sha256":  types.StringType,
+	"size":    types.Int64Type,
+}}
+
+// Metadata returns the resource type name.
+func (r *recordingExportResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_session_recording_export"
+}
+
+// Schema defines the schema for the resource.
+func (r *recordingExportResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Downloads the recordings of a set of Kasm sessions to a local directory, as " +
+			"<directory>/<kasm_id>/<recording_id><ext>. Refreshing checks each file against its recorded SHA-256 " +
+			"checksum and the server for new recordings; missing, modified or new files are downloaded on the next apply. " +
+			"Destroying the resource leaves the files in place.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The export directory.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"kasm_ids": schema.SetAttribute{
+				Description: "The IDs of the sessions whose recordings are exported.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"directory": schema.StringAttribute{
+				Description: "The local directory to write the recordings to. It is created if it does not exist.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"concurrency": schema.Int64Attribute{
+				Description: fmt.Sprintf("The maximum number of recordings downloaded at once. Defaults to %d.", client.DefaultRecordingExportConcurrency),
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(client.DefaultRecordingExportConcurrency),
+				Validators: []validator.Int64{
+					validators.Int64AtLeast(1),
+				},
+			},
+			"recording_ids": schema.SetAttribute{
+				Description: "The IDs of the recordings the server reports for the sessions.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"files": schema.MapNestedAttribute{
+				Description: "The exported files, keyed by recording ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"kasm_id": schema.StringAttribute{
+							Description: "The ID of the session the recording belongs to.",
+							Computed:    true,
+						},
+						"path": schema.StringAttribute{
+							Description: "The path of the file.",
+							Computed:    true,
+						},
+						"sha256": schema.StringAttribute{
+							Description: "Hex encoded SHA-256 checksum of the file.",
+							Computed:    true,
+						},
+						"size": schema.Int64Attribute{
+							Description: "Size of the file in bytes.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *recordingExportResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan keeps the recorded files when the sessions are unchanged and
+// every recording on the server is exported intact. Otherwise files and
+// recording_ids are left unknown so that the apply downloads what is missing.
+func (r *recordingExportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state recordingExportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.KasmIDs.Equal(state.KasmIDs) && inSync(state) {
+		plan.RecordingIDs = state.RecordingIDs
+		plan.Files = state.Files
+	} else {
+		plan.RecordingIDs = types.SetUnknown(types.StringType)
+		plan.Files = types.MapUnknown(exportedFileType)
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// inSync reports whether the files in state cover exactly the recordings the
+// server reported.
+func inSync(state recordingExportResourceModel) bool {
+	if state.RecordingIDs.IsNull() || state.RecordingIDs.IsUnknown() || state.Files.IsNull() || state.Files.IsUnknown() {
+		return false
+	}
+
+	files := state.Files.Elements()
+	ids := state.RecordingIDs.Elements()
+	if len(files) != len(ids) {
+		return false
+	}
+	for _, id := range ids {
+		s, ok := id.(types.String)
+		if !ok {
+			return false
+		}
+		if _, ok := files[s.ValueString()]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Create downloads every recording of the sessions.
+func (r *recordingExportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan recordingExportResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.export(ctx, &plan, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.Directory
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read drops files that are missing or no longer match their checksum, and
+// refreshes the recordings the server reports.
+func (r *recordingExportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state recordingExportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	files := make(map[string]exportedFileModel)
+	resp.Diagnostics.Append(state.Files.ElementsAs(ctx, &files, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for recordingID, file := range files {
+		current, err := client.HashRecordingFile(file.Path.ValueString())
+		switch {
+		case err != nil:
+			tflog.Warn(ctx, "Exported recording is missing", map[string]interface{}{
+				"recording_id": recordingID,
+				"path":         file.Path.ValueString(),
+				"error":        err.Error(),
+			})
+			delete(files, recordingID)
+		case current.SHA256 != file.SHA256.ValueString():
+			tflog.Warn(ctx, "Exported recording was modified", map[string]interface{}{
+				"recording_id": recordingID,
+				"path":         file.Path.ValueString(),
+			})
+			delete(files, recordingID)
+		}
+	}
+	state.Files, diags = types.MapValueFrom(ctx, exportedFileType, files)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var kasmIDs []string
+	resp.Diagnostics.Append(state.KasmIDs.ElementsAs(ctx, &kasmIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sessions, err := r.client.GetSessionsRecordings(ctx, kasmIDs, client.SessionRecordingsOptions{})
+	switch {
+	case client.IsNotFound(err):
+		// Keep the recordings reported last time.
+	case err != nil:
+		resp.Diagnostics.AddError(
+			"Error Reading Session Recordings",
+			fmt.Sprintf("Could not read recordings for sessions: %s", err),
+		)
+		return
+	default:
+		// An empty rather than nil slice, so that sessions without
+		// recordings give an empty set as Create does, not a null one.
+		recordingIDs := []string{}
+		for _, recordings := range sessions {
+			for _, rec := range recordings {
+				recordingIDs = append(recordingIDs, rec.RecordingID)
+			}
+		}
+		state.RecordingIDs, diags = types.SetValueFrom(ctx, types.StringType, recordingIDs)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update downloads recordings that are new, missing or modified. Files that
+// still match their recorded checksum are kept.
+func (r *recordingExportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state recordingExportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Files.IsUnknown() {
+		// Only concurrency changed.
+		diags := resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	files := make(map[string]exportedFileModel)
+	resp.Diagnostics.Append(state.Files.ElementsAs(ctx, &files, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	known := make(map[string]string, len(files))
+	for recordingID, file := range files {
+		known[recordingID] = file.SHA256.ValueString()
+	}
+
+	resp.Diagnostics.Append(r.export(ctx, &plan, known)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the resource from state. The exported files are kept.
+func (r *recordingExportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state recordingExportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Session recording export removed; files are kept", map[string]interface{}{
+		"directory": state.Directory.ValueString(),
+	})
+}
+
+// export downloads the recordings of model's sessions and sets its
+// recording_ids and files.
+func (r *recordingExportResource) export(ctx context.Context, model *recordingExportResourceModel, known map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var kasmIDs []string
+	diags.Append(model.KasmIDs.ElementsAs(ctx, &kasmIDs, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	tflog.Debug(ctx, "Exporting session recordings", map[string]interface{}{
+		"kasm_ids":  kasmIDs,
+		"directory": model.Directory.ValueString(),
+	})
+
+	recordings, err := r.client.ExportSessionRecordings(ctx, kasmIDs, model.Directory.ValueString(), client.RecordingExportOptions{
+		Concurrency: int(model.Concurrency.ValueInt64()),
+		KnownSHA256: known,
+	})
+	if err != nil {
+		diags.AddError(
+			"Error Exporting Session Recordings",
+			fmt.Sprintf("Could not export recordings to %s: %s", model.Directory.ValueString(), err),
+		)
+		return diags
+	}
+
+	recordingIDs := make([]string, 0, len(recordings))
+	files := make(map[string]exportedFileModel, len(recordings))
+	for _, rec := range recordings {
+		recordingIDs = append(recordingIDs, rec.RecordingID)
+		files[rec.RecordingID] = exportedFileModel{
+			KasmID: types.StringValue(rec.KasmID),
+			Path:   types.StringValue(rec.Path),
+			SHA256: types.StringValue(rec.SHA256),
+			Size:   types.Int64Value(rec.Size),
+		}
+	}
+
+	var d diag.Diagnostics
+	model.RecordingIDs, d = types.SetValueFrom(ctx, types.StringType, recordingIDs)
+	diags.Append(d...)
+	model.Files, d = types.MapValueFrom(ctx, exportedFileType, files)
+	diags.Append(d...)
+	return diags
+}
@@ -0,0 +1,137 @@
+//go:build unit
+// +build unit
+
+package session_recording_export
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-kasm/internal/client"
+)
+
+func TestRecordingExportResource_Metadata(t *testing.T) {
+	r := New()
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "kasm"}, resp)
+
+	assert.Equal(t, "kasm_session_recording_export", resp.TypeName)
+}
+
+func TestRecordingExportResource_Schema(t *testing.T) {
+	r := New()
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	for _, name := range []string{"kasm_ids", "directory"} {
+		assert.True(t, resp.Schema.Attributes[name].IsRequired(), "%s should be required", name)
+	}
+	for _, name := range []string{"recording_ids", "files"} {
+		assert.True(t, resp.Schema.Attributes[name].IsComputed(), "%s should be computed", name)
+	}
+}
+
+func TestInSync(t *testing.T) {
+	file := types.ObjectValueMust(exportedFileType.AttrTypes, map[string]attr.Value{
+		"kasm_id": types.StringValue("kasm-1"),
+		"path":    types.StringValue("/tmp/kasm-1/r1.webm"),
+		"sha256":  types.StringValue("abc"),
+		"size":    types.Int64Value(3),
+	})
+	ids := func(values ...string) types.Set {
+		elements := make([]attr.Value, 0, len(values))
+		for _, v := range values {
+			elements = append(elements, types.StringValue(v))
+		}
+		return types.SetValueMust(types.StringType, elements)
+	}
+	files := types.MapValueMust(exportedFileType, map[string]attr.Value{"r1": file})
+
+	testCases := []struct {
+		name     string
+		state    recordingExportResourceModel
+		expected bool
+	}{
+		{
+			name:     "All exported",
+			state:    recordingExportResourceModel{RecordingIDs: ids("r1"), Files: files},
+			expected: true,
+		},
+		{
+			name:  "New recording",
+			state: recordingExportResourceModel{RecordingIDs: ids("r1", "r2"), Files: files},
+		},
+		{
+			name:  "Recording removed from server",
+			state: recordingExportResourceModel{RecordingIDs: ids("r2"), Files: files},
+		},
+		{
+			name:  "File dropped on refresh",
+			state: recordingExportResourceModel{RecordingIDs: ids("r1"), Files: types.MapValueMust(exportedFileType, map[string]attr.Value{})},
+		},
+		{
+			name:  "Unknown",
+			state: recordingExportResourceModel{RecordingIDs: types.SetUnknown(types.StringType), Files: files},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, inSync(tc.state))
+		})
+	}
+}
+
+func TestRecordingExportResource_NoRecordingsPlansNoChanges(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"kasm_sessions": {"kasm-1": {"session_recordings": []}}}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	r := &recordingExportResource{client: client.NewClient(server.URL, "test-key", "test-secret", false, client.WithMaxRetries(0))}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	// The state Create leaves behind when the session has no recordings.
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	diags := state.Set(ctx, recordingExportResourceModel{
+		ID:           types.StringValue(t.TempDir()),
+		KasmIDs:      types.SetValueMust(types.StringType, []attr.Value{types.StringValue("kasm-1")}),
+		Directory:    types.StringValue(t.TempDir()),
+		Concurrency:  types.Int64Value(4),
+		RecordingIDs: types.SetValueMust(types.StringType, []attr.Value{}),
+		Files:        types.MapValueMust(exportedFileType, map[string]attr.Value{}),
+	})
+	assert.False(t, diags.HasError(), "%v", diags)
+
+	readResp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, readResp)
+	assert.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+
+	var refreshed recordingExportResourceModel
+	readResp.State.Get(ctx, &refreshed)
+	assert.False(t, refreshed.RecordingIDs.IsNull(), "recording_ids must be an empty set, not null")
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: readResp.State.Raw}
+	planResp := &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{State: readResp.State, Plan: plan}, planResp)
+	assert.False(t, planResp.Diagnostics.HasError(), "%v", planResp.Diagnostics)
+
+	var planned recordingExportResourceModel
+	planResp.Plan.Get(ctx, &planned)
+	assert.False(t, planned.RecordingIDs.IsUnknown(), "recording_ids should not change")
+	assert.False(t, planned.Files.IsUnknown(), "files should not change")
+	assert.True(t, planResp.Plan.Raw.Equal(readResp.State.Raw), "plan should match the refreshed state")
+}
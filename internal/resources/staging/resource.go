@@ -145,7 +145,7 @@ func (r *stagingResource) Create(ctx context.Context, req resource.CreateRequest
 		AllowKasmMicrophone:    plan.AllowKasmMicrophone.ValueBool(),
 	}
 
-	stagingConfig, err := r.client.CreateStagingConfig(createReq)
+	stagingConfig, err := r.client.CreateStagingConfig(ctx, createReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating staging config",
@@ -171,8 +171,13 @@ func (r *stagingResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	stagingConfig, err := r.client.GetStagingConfig(state.ID.ValueString())
+	stagingConfig, err := r.client.GetStagingConfig(ctx, state.ID.ValueString())
 	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading Kasm Staging Config",
 			fmt.Sprintf("Could not read staging config ID %s: %s", state.ID.ValueString(), err),
@@ -227,7 +232,7 @@ func (r *stagingResource) Update(ctx context.Context, req resource.UpdateRequest
 	*updateReq.AllowKasmClipboardUp = plan.AllowKasmClipboardUp.ValueBool()
 	*updateReq.AllowKasmMicrophone = plan.AllowKasmMicrophone.ValueBool()
 
-	stagingConfig, err := r.client.UpdateStagingConfig(updateReq)
+	stagingConfig, err := r.client.UpdateStagingConfig(ctx, updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Kasm Staging Config",
@@ -251,7 +256,7 @@ func (r *stagingResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	err := r.client.DeleteStagingConfig(state.ID.ValueString())
+	err := r.client.DeleteStagingConfig(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Kasm Staging Config",
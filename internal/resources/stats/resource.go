@@ -2,8 +2,8 @@ package stats
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -153,7 +153,7 @@ func (r *statsResource) Create(ctx context.Context, req resource.CreateRequest,
 	plan.ID = types.StringValue(fmt.Sprintf("%s-stats", plan.KasmID.ValueString()))
 
 	// Get initial stats
-	frameStats, err := r.client.GetFrameStats(plan.KasmID.ValueString(), plan.UserID.ValueString())
+	frameStats, err := r.client.GetFrameStats(ctx, plan.KasmID.ValueString(), plan.UserID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Getting Frame Stats",
@@ -192,10 +192,10 @@ func (r *statsResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Get refreshed stats
-	frameStats, err := r.client.GetFrameStats(state.KasmID.ValueString(), state.UserID.ValueString())
+	frameStats, err := r.client.GetFrameStats(ctx, state.KasmID.ValueString(), state.UserID.ValueString())
 	if err != nil {
 		// If the error is about needing an active user connection, we'll handle it gracefully
-		if strings.Contains(err.Error(), "a user must be actively connected to the session") {
+		if errors.Is(err, client.ErrSessionNotConnected) {
 			resp.Diagnostics.AddWarning(
 				"Frame Stats Unavailable",
 				"Frame stats are only available when a user is actively connected to the session. Connect to the session in a browser and try again.",
@@ -206,6 +206,11 @@ func (r *statsResource) Read(ctx context.Context, req resource.ReadRequest, resp
 			return
 		}
 
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading Frame Stats",
 			fmt.Sprintf("Could not read frame stats: %s", err),
@@ -256,7 +261,7 @@ func (r *statsResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Get refreshed stats
-	frameStats, err := r.client.GetFrameStats(plan.KasmID.ValueString(), plan.UserID.ValueString())
+	frameStats, err := r.client.GetFrameStats(ctx, plan.KasmID.ValueString(), plan.UserID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Frame Stats",
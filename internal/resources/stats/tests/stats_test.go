@@ -4,6 +4,7 @@
 package tests
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -22,7 +23,7 @@ var createdImageID string // Track the created image ID for cleanup
 // cleanupTestImage deletes the test image if it was created
 func cleanupTestImage(t *testing.T, c *client.Client) {
 	if createdImageID != "" {
-		if err := c.DeleteImage(createdImageID); err != nil {
+		if err := c.DeleteImage(context.Background(), createdImageID); err != nil {
 			t.Logf("Warning: Failed to delete test image %s: %v", createdImageID, err)
 		} else {
 			log.Printf("[DEBUG] Successfully deleted test image: %s", createdImageID)
@@ -41,7 +42,7 @@ func ensureWorkspaceImage(t *testing.T, c *client.Client) string {
 	}
 
 	// Try to find an existing image first
-	images, err := c.GetImages()
+	images, err := c.GetImages(context.Background())
 	if err != nil {
 		log.Printf("[DEBUG] Error getting images: %v", err)
 		log.Printf("[DEBUG] Will attempt to create new image")
@@ -105,7 +106,7 @@ func ensureWorkspaceImage(t *testing.T, c *client.Client) string {
 	for i := 0; i < maxRetries; i++ {
 		log.Printf("[DEBUG] Attempt %d/%d to create image", i+1, maxRetries)
 
-		createdImage, lastErr = c.AddWorkspaceImage(image)
+		createdImage, lastErr = c.AddWorkspaceImage(context.Background(), image)
 
 		if lastErr != nil {
 			log.Printf("[DEBUG] Attempt %d failed with error: %v", i+1, lastErr)
@@ -161,7 +162,7 @@ func waitForImageAvailable(t *testing.T, c *client.Client, imageID string) {
 	for i := 0; i < maxRetries; i++ {
 		log.Printf("[DEBUG] Checking if image %s is available (attempt %d/%d)", imageID, i+1, maxRetries)
 
-		images, err := c.GetImages()
+		images, err := c.GetImages(context.Background())
 		if err != nil {
 			log.Printf("[DEBUG] Error checking image status: %v", err)
 			time.Sleep(retryInterval)
@@ -229,7 +230,7 @@ func TestAccKasmStats_FrameStats(t *testing.T) {
 	// Create a new Kasm session
 	log.Printf("[DEBUG] Creating Kasm session for user %s with image %s", userID, imageID)
 	sessionToken := uuid.New().String()
-	kasm, err := c.CreateKasm(userID, imageID, sessionToken, "test", true, false, false, false)
+	kasm, err := c.CreateKasm(context.Background(), userID, imageID, sessionToken, "test", true, true, false, false, false)
 	if err != nil {
 		t.Fatalf("Failed to create Kasm session: %v", err)
 	}
@@ -237,7 +238,7 @@ func TestAccKasmStats_FrameStats(t *testing.T) {
 	// Ensure the Kasm session is deleted when the test is done
 	defer func() {
 		log.Printf("[DEBUG] Cleaning up Kasm session %s", kasm.KasmID)
-		err := c.DestroyKasm(userID, kasm.KasmID)
+		err := c.DestroyKasm(context.Background(), userID, kasm.KasmID)
 		if err != nil {
 			log.Printf("[WARN] Failed to delete Kasm session: %v", err)
 		}
@@ -252,7 +253,7 @@ func TestAccKasmStats_FrameStats(t *testing.T) {
 
 	// Get the join URL for the session using the share_id
 	log.Printf("[DEBUG] Getting join URL for the session...")
-	joinResp, err := c.JoinKasm(shareID, userID)
+	joinResp, err := c.JoinKasm(context.Background(), shareID, userID)
 	if err != nil {
 		t.Fatalf("Failed to get join URL: %v", err)
 	} else {
@@ -290,7 +291,7 @@ func TestAccKasmStats_FrameStats(t *testing.T) {
 	maxStatsRetries := 10
 	statsRetryInterval := 5 * time.Second
 	for i := 0; i < maxStatsRetries; i++ {
-		stats, err := c.GetFrameStats(kasm.KasmID, userID)
+		stats, err := c.GetFrameStats(context.Background(), kasm.KasmID, userID)
 		if err == nil && stats != nil {
 			frameStats = stats
 			log.Printf("[DEBUG] Successfully retrieved frame stats on attempt %d", i+1)
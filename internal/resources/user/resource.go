@@ -157,7 +157,9 @@ func (r *userResource) retryOperation(ctx context.Context, operation func() erro
 
 		if i < maxRetries-1 {
 			tflog.Warn(ctx, fmt.Sprintf("Operation failed, retrying (%d/%d)", i+1, maxRetries))
-			time.Sleep(time.Second * time.Duration(i+1))
+			if err := client.SleepContext(ctx, time.Second*time.Duration(i+1)); err != nil {
+				return err
+			}
 			continue
 		}
 		return err
@@ -220,7 +222,7 @@ func resourceUserToState(ctx context.Context, user *client.User, state *UserReso
 func (r *userResource) handleGroupUpdates(ctx context.Context, userID string, groups types.List) error {
 	if groups.IsNull() || groups.IsUnknown() {
 		// Handle null or unknown values by setting empty list
-		return r.client.UpdateUserGroupsByName(userID, []string{})
+		return r.client.UpdateUserGroupsByName(ctx, userID, []string{})
 	}
 
 	var groupStrings []types.String
@@ -236,7 +238,7 @@ func (r *userResource) handleGroupUpdates(ctx context.Context, userID string, gr
 		}
 	}
 
-	return r.client.UpdateUserGroupsByName(userID, groupNames)
+	return r.client.UpdateUserGroupsByName(ctx, userID, groupNames)
 }
 
 // Create implementation
@@ -297,7 +299,7 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 	var createdUser *client.User
 	err := r.retryOperation(ctx, func() error {
 		var err error
-		createdUser, err = r.client.CreateUser(user)
+		createdUser, err = r.client.CreateUser(ctx, user)
 		return err
 	})
 
@@ -328,7 +330,7 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 			attributesInterface[k] = v
 		}
 
-		err = r.client.UpdateUserAttributes(createdUser.UserID, attributesInterface)
+		err = r.client.UpdateUserAttributes(ctx, createdUser.UserID, attributesInterface)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error setting user attributes",
@@ -351,7 +353,7 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	// Read the user back to get the final state
-	user, err = r.client.GetUser(createdUser.UserID)
+	user, err = r.client.GetUser(ctx, createdUser.UserID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading created user",
@@ -405,7 +407,7 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	var user *client.User
 	err := r.retryOperation(ctx, func() error {
 		var err error
-		user, err = r.client.GetUser(state.ID.ValueString())
+		user, err = r.client.GetUser(ctx, state.ID.ValueString())
 		return err
 	})
 
@@ -469,7 +471,7 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	var updatedUser *client.User
 	err := r.retryOperation(ctx, func() error {
 		var err error
-		updatedUser, err = r.client.UpdateUser(user)
+		updatedUser, err = r.client.UpdateUser(ctx, user)
 		return err
 	})
 
@@ -490,7 +492,7 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 			return
 		}
 
-		err = r.client.UpdateUserGroupsByName(updatedUser.UserID, groupNames)
+		err = r.client.UpdateUserGroupsByName(ctx, updatedUser.UserID, groupNames)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error updating user groups",
@@ -517,7 +519,7 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	// Read back the final state
-	readUser, err := r.client.GetUser(updatedUser.UserID)
+	readUser, err := r.client.GetUser(ctx, updatedUser.UserID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading updated user",
@@ -549,7 +551,7 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	// Remove from groups first
 	if !state.Groups.IsNull() && !state.Groups.IsUnknown() {
 		err := r.retryOperation(ctx, func() error {
-			return r.client.UpdateUserGroupsByName(state.ID.ValueString(), []string{})
+			return r.client.UpdateUserGroupsByName(ctx, state.ID.ValueString(), []string{})
 		})
 		if err != nil {
 			resp.Diagnostics.AddWarning(
@@ -561,7 +563,7 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 	// Delete the user
 	err := r.retryOperation(ctx, func() error {
-		return r.client.DeleteUser(state.ID.ValueString())
+		return r.client.DeleteUser(ctx, state.ID.ValueString())
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -594,7 +596,7 @@ func (r *userResource) ImportState(ctx context.Context, req resource.ImportState
 			return
 		}
 		// Find user by username
-		users, err := r.client.GetUsers()
+		users, err := r.client.GetUsers(ctx)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error importing user",
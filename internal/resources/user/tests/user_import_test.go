@@ -4,6 +4,7 @@
 package tests
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -143,7 +144,7 @@ func testAccCheckUserExists(n string) resource.TestCheckFunc {
 		}
 
 		client := testutils.GetTestClient(nil)
-		_, err := client.GetUser(rs.Primary.ID)
+		_, err := client.GetUser(context.Background(), rs.Primary.ID)
 		if err != nil {
 			return fmt.Errorf("Error fetching user with ID %s: %s", rs.Primary.ID, err)
 		}
@@ -161,7 +162,7 @@ func testAccCheckUserDestroy(s *terraform.State) error {
 			continue
 		}
 
-		_, err := client.GetUser(rs.Primary.ID)
+		_, err := client.GetUser(context.Background(), rs.Primary.ID)
 		if err == nil {
 			return fmt.Errorf("User still exists: %s", rs.Primary.ID)
 		}
@@ -0,0 +1,137 @@
+package vm_provider_config
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"terraform-provider-kasm/internal/client"
+)
+
+// NewAWS returns the kasm_aws_vm_provider_config resource.
+func NewAWS() resource.Resource {
+	return &vmProviderConfigResource{cloud: awsCloud}
+}
+
+// NewAzure returns the kasm_azure_vm_provider_config resource.
+func NewAzure() resource.Resource {
+	return &vmProviderConfigResource{cloud: azureCloud}
+}
+
+// NewGCP returns the kasm_gcp_vm_provider_config resource.
+func NewGCP() resource.Resource {
+	return &vmProviderConfigResource{cloud: gcpCloud}
+}
+
+// NewDigitalOcean returns the kasm_digitalocean_vm_provider_config resource.
+func NewDigitalOcean() resource.Resource {
+	return &vmProviderConfigResource{cloud: digitalOceanCloud}
+}
+
+// NewOCI returns the kasm_oci_vm_provider_config resource.
+func NewOCI() resource.Resource {
+	return &vmProviderConfigResource{cloud: ociCloud}
+}
+
+// startupScript is accepted by every cloud.
+var startupScript = setting{
+	name:        "startup_script",
+	description: "A script run when an agent VM boots, typically to install and register the Kasm agent.",
+	kind:        kindString,
+}
+
+var awsCloud = cloud{
+	typeName: "aws_vm_provider_config",
+	provider: client.VMProviderAWS,
+	title:    "AWS",
+	settings: []setting{
+		{name: "region", description: "The AWS region agents are launched in.", kind: kindString, required: true},
+		{name: "access_key_id", description: "The access key ID of the AWS account.", kind: kindString, required: true, sensitive: true},
+		{name: "secret_access_key", description: "The secret access key of the AWS account.", kind: kindString, required: true, sensitive: true},
+		{name: "ec2_ami_id", description: "The AMI agents are launched from.", kind: kindString, required: true},
+		{name: "ec2_instance_type", description: "The EC2 instance type of agents, such as t3.xlarge.", kind: kindString, required: true},
+		{name: "ec2_subnet_id", description: "The subnet agents are launched in.", kind: kindString, required: true},
+		{name: "ec2_security_group_ids", description: "The security groups attached to agents.", kind: kindList, required: true},
+		{name: "ec2_ebs_volume_size_gb", description: "The size of the root volume, in GB.", kind: kindInt},
+		{name: "ec2_ebs_volume_type", description: "The type of the root volume, such as gp3.", kind: kindString},
+		{name: "ec2_iam_instance_profile", description: "The IAM instance profile attached to agents.", kind: kindString},
+		startupScript,
+	},
+}
+
+var azureCloud = cloud{
+	typeName: "azure_vm_provider_config",
+	provider: client.VMProviderAzure,
+	title:    "Azure",
+	settings: []setting{
+		{name: "subscription_id", description: "The Azure subscription agents are launched in.", kind: kindString, required: true},
+		{name: "tenant_id", description: "The Azure AD tenant of the service principal.", kind: kindString, required: true},
+		{name: "client_id", description: "The application ID of the service principal.", kind: kindString, required: true},
+		{name: "client_secret", description: "The secret of the service principal.", kind: kindString, required: true, sensitive: true},
+		{name: "resource_group", description: "The resource group agents are created in.", kind: kindString, required: true},
+		{name: "region", description: "The Azure region agents are launched in.", kind: kindString, required: true},
+		{name: "vm_size", description: "The VM size of agents, such as Standard_D4s_v3.", kind: kindString, required: true},
+		{name: "image_reference", description: "The image agents are launched from, as an image ID or publisher:offer:sku:version.", kind: kindString, required: true},
+		{name: "subnet", description: "The ID of the subnet agents are attached to.", kind: kindString, required: true},
+		{name: "network_security_group", description: "The ID of the network security group of agents.", kind: kindString},
+		{name: "os_disk_size_gb", description: "The size of the OS disk, in GB.", kind: kindInt},
+		{name: "os_disk_type", description: "The storage type of the OS disk, such as Premium_LRS.", kind: kindString},
+		startupScript,
+	},
+}
+
+var gcpCloud = cloud{
+	typeName: "gcp_vm_provider_config",
+	provider: client.VMProviderGCP,
+	title:    "Google Cloud",
+	settings: []setting{
+		{name: "project_id", description: "The project agents are launched in.", kind: kindString, required: true},
+		{name: "credentials", description: "The JSON key of the service account Kasm uses.", kind: kindString, required: true, sensitive: true},
+		{name: "region", description: "The region agents are launched in.", kind: kindString, required: true},
+		{name: "zone", description: "The zone agents are launched in, such as europe-west2-a.", kind: kindString, required: true},
+		{name: "machine_type", description: "The machine type of agents, such as e2-standard-4.", kind: kindString, required: true},
+		{name: "image", description: "The image agents are launched from.", kind: kindString, required: true},
+		{name: "network", description: "The VPC network agents are attached to.", kind: kindString, required: true},
+		{name: "subnetwork", description: "The subnetwork agents are attached to.", kind: kindString},
+		{name: "network_tags", description: "Network tags applied to agents.", kind: kindList},
+		{name: "boot_disk_size_gb", description: "The size of the boot disk, in GB.", kind: kindInt},
+		{name: "boot_disk_type", description: "The type of the boot disk, such as pd-ssd.", kind: kindString},
+		startupScript,
+	},
+}
+
+var digitalOceanCloud = cloud{
+	typeName: "digitalocean_vm_provider_config",
+	provider: client.VMProviderDigitalOcean,
+	title:    "DigitalOcean",
+	settings: []setting{
+		{name: "token", description: "The DigitalOcean API token.", kind: kindString, required: true, sensitive: true},
+		{name: "region", description: "The region agents are launched in, such as lon1.", kind: kindString, required: true},
+		{name: "droplet_size", description: "The size slug of agents, such as s-4vcpu-8gb.", kind: kindString, required: true},
+		{name: "droplet_image", description: "The image agents are launched from.", kind: kindString, required: true},
+		{name: "ssh_key_name", description: "The name of the SSH key added to agents.", kind: kindString},
+		{name: "firewall_name", description: "The name of the firewall agents are added to.", kind: kindString},
+		{name: "tags", description: "Tags applied to agents.", kind: kindList},
+		startupScript,
+	},
+}
+
+var ociCloud = cloud{
+	typeName: "oci_vm_provider_config",
+	provider: client.VMProviderOCI,
+	title:    "Oracle Cloud Infrastructure",
+	settings: []setting{
+		{name: "tenancy_ocid", description: "The OCID of the tenancy.", kind: kindString, required: true},
+		{name: "user_ocid", description: "The OCID of the API user.", kind: kindString, required: true},
+		{name: "fingerprint", description: "The fingerprint of the API signing key.", kind: kindString, required: true},
+		{name: "private_key", description: "The PEM encoded API signing key.", kind: kindString, required: true, sensitive: true},
+		{name: "region", description: "The region agents are launched in.", kind: kindString, required: true},
+		{name: "compartment_ocid", description: "The OCID of the compartment agents are launched in.", kind: kindString, required: true},
+		{name: "availability_domains", description: "The availability domains agents are spread across.", kind: kindList, required: true},
+		{name: "image_ocid", description: "The OCID of the image agents are launched from.", kind: kindString, required: true},
+		{name: "shape", description: "The shape of agents, such as VM.Standard.E4.Flex.", kind: kindString, required: true},
+		{name: "subnet_ocid", description: "The OCID of the subnet agents are attached to.", kind: kindString, required: true},
+		{name: "shape_ocpus", description: "The OCPUs of flexible shapes.", kind: kindInt},
+		{name: "shape_memory_gb", description: "The memory of flexible shapes, in GB.", kind: kindInt},
+		{name: "boot_volume_size_gb", description: "The size of the boot volume, in GB.", kind: kindInt},
+		startupScript,
+	},
+}
@@ -0,0 +1,371 @@
+package vm_provider_config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-kasm/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &vmProviderConfigResource{}
+	_ resource.ResourceWithConfigure   = &vmProviderConfigResource{}
+	_ resource.ResourceWithImportState = &vmProviderConfigResource{}
+)
+
+// settingKind is the Terraform type of a cloud setting.
+type settingKind int
+
+const (
+	kindString settingKind = iota
+	kindInt
+	kindList
+)
+
+// setting is a cloud-specific attribute. It is sent to Kasm under the key
+// "<provider>_<name>".
+type setting struct {
+	name        string
+	description string
+	kind        settingKind
+	required    bool
+	sensitive   bool
+}
+
+// cloud describes the VM provider config resource of one cloud.
+type cloud struct {
+	typeName string
+	provider string
+	title    string
+	settings []setting
+}
+
+func (c cloud) key(s setting) string {
+	return c.provider + "_" + s.name
+}
+
+// vmProviderConfigResource manages the VM provider config of one cloud.
+type vmProviderConfigResource struct {
+	client *client.Client
+	cloud  cloud
+}
+
+// attributeGetter is implemented by tfsdk.Plan and tfsdk.State.
+type attributeGetter interface {
+	GetAttribute(ctx context.Context, path path.Path, target interface{}) diag.Diagnostics
+}
+
+// Metadata returns the resource type name.
+func (r *vmProviderConfigResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.cloud.typeName
+}
+
+// Schema defines the schema for the resource.
+func (r *vmProviderConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "The ID of the VM provider config.",
+			Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"name": schema.StringAttribute{
+			Description: "The name of the VM provider config.",
+			Required:    true,
+		},
+	}
+	for _, s := range r.cloud.settings {
+		switch s.kind {
+		case kindInt:
+			attributes[s.name] = schema.Int64Attribute{
+				Description: s.description,
+				Required:    s.required,
+				Optional:    !s.required,
+				Sensitive:   s.sensitive,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			}
+		case kindList:
+			attributes[s.name] = schema.ListAttribute{
+				Description: s.description,
+				ElementType: types.StringType,
+				Required:    s.required,
+				Optional:    !s.required,
+				Sensitive:   s.sensitive,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			}
+		default:
+			attributes[s.name] = schema.StringAttribute{
+				Description: s.description,
+				Required:    s.required,
+				Optional:    !s.required,
+				Sensitive:   s.sensitive,
+			}
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: fmt.Sprintf("Manages a Kasm VM provider config for %s: the account and VM template autoscaling "+
+			"uses to launch agents. Reference it from kasm_autoscale_config.", r.cloud.title),
+		Attributes: attributes,
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *vmProviderConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates the VM provider config.
+func (r *vmProviderConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	config, diags := r.expand(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateVMProviderConfig(ctx, config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating VM Provider Config",
+			fmt.Sprintf("Could not create %s VM provider config %s: %s", r.cloud.title, config.Name, err),
+		)
+		return
+	}
+
+	resp.State.Raw = req.Plan.Raw
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), created.VMProviderConfigID)...)
+}
+
+// Read refreshes the VM provider config from the server. Secrets the server
+// does not return are kept as applied.
+func (r *vmProviderConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var id types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetVMProviderConfig(ctx, id.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Debug(ctx, "VM provider config not found, removing from state", map[string]interface{}{
+				"id": id.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading VM Provider Config",
+			fmt.Sprintf("Could not read VM provider config %s: %s", id.ValueString(), err),
+		)
+		return
+	}
+	if config.Provider != r.cloud.provider {
+		resp.Diagnostics.AddError(
+			"Wrong VM Provider",
+			fmt.Sprintf("VM provider config %s is a %s config, not %s. Use the matching kasm_*_vm_provider_config resource.",
+				id.ValueString(), config.Provider, r.cloud.provider),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), config.Name)...)
+	for _, s := range r.cloud.settings {
+		value, ok := config.Settings[r.cloud.key(s)]
+		if !ok || value == nil || value == "" {
+			// Keep what was applied: the server omits secrets and unset
+			// optional settings.
+			continue
+		}
+		flattened, diags := flattenSetting(s, value)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(s.name), flattened)...)
+	}
+}
+
+// Update updates the VM provider config in place.
+func (r *vmProviderConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	config, diags := r.expand(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var id types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.VMProviderConfigID = id.ValueString()
+
+	if _, err := r.client.UpdateVMProviderConfig(ctx, config); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating VM Provider Config",
+			fmt.Sprintf("Could not update VM provider config %s: %s", id.ValueString(), err),
+		)
+		return
+	}
+
+	resp.State.Raw = req.Plan.Raw
+}
+
+// Delete deletes the VM provider config.
+func (r *vmProviderConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var id types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteVMProviderConfig(ctx, id.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting VM Provider Config",
+			fmt.Sprintf("Could not delete VM provider config %s: %s", id.ValueString(), err),
+		)
+	}
+}
+
+// ImportState imports a VM provider config by ID or by name. Secrets are not
+// imported and must be set in configuration.
+func (r *vmProviderConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if name, ok := strings.CutPrefix(req.ID, "name:"); ok {
+		configs, err := r.client.GetVMProviderConfigs(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing VM Provider Config",
+				fmt.Sprintf("Could not list VM provider configs: %s", err),
+			)
+			return
+		}
+
+		id = ""
+		for _, config := range configs {
+			if config.Provider == r.cloud.provider && config.Name == name {
+				id = config.VMProviderConfigID
+				break
+			}
+		}
+		if id == "" {
+			resp.Diagnostics.AddError(
+				"VM Provider Config Not Found",
+				fmt.Sprintf("Could not find a %s VM provider config named %s", r.cloud.title, name),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// expand builds the API object from a plan. Unset optional settings are not
+// sent.
+func (r *vmProviderConfigResource) expand(ctx context.Context, plan attributeGetter) (*client.VMProviderConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var name types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("name"), &name)...)
+
+	config := &client.VMProviderConfig{
+		Name:     name.ValueString(),
+		Provider: r.cloud.provider,
+		Settings: make(map[string]interface{}),
+	}
+	for _, s := range r.cloud.settings {
+		switch s.kind {
+		case kindInt:
+			var value types.Int64
+			diags.Append(plan.GetAttribute(ctx, path.Root(s.name), &value)...)
+			if !value.IsNull() && !value.IsUnknown() {
+				config.Settings[r.cloud.key(s)] = value.ValueInt64()
+			}
+		case kindList:
+			var value types.List
+			diags.Append(plan.GetAttribute(ctx, path.Root(s.name), &value)...)
+			if !value.IsNull() && !value.IsUnknown() {
+				var items []string
+				diags.Append(value.ElementsAs(ctx, &items, false)...)
+				config.Settings[r.cloud.key(s)] = items
+			}
+		default:
+			var value types.String
+			diags.Append(plan.GetAttribute(ctx, path.Root(s.name), &value)...)
+			if !value.IsNull() && !value.IsUnknown() {
+				config.Settings[r.cloud.key(s)] = value.ValueString()
+			}
+		}
+	}
+
+	return config, diags
+}
+
+// flattenSetting converts a decoded JSON value into the setting's Terraform
+// value.
+func flattenSetting(s setting, value interface{}) (attr.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch s.kind {
+	case kindInt:
+		number, ok := value.(float64)
+		if !ok {
+			diags.AddAttributeError(path.Root(s.name), "Unexpected VM Provider Config Value",
+				fmt.Sprintf("Expected a number, got %T", value))
+			return nil, diags
+		}
+		return types.Int64Value(int64(number)), diags
+	case kindList:
+		raw, ok := value.([]interface{})
+		if !ok {
+			diags.AddAttributeError(path.Root(s.name), "Unexpected VM Provider Config Value",
+				fmt.Sprintf("Expected a list, got %T", value))
+			return nil, diags
+		}
+		items := make([]attr.Value, 0, len(raw))
+		for _, item := range raw {
+			items = append(items, types.StringValue(fmt.Sprint(item)))
+		}
+		list, d := types.ListValue(types.StringType, items)
+		diags.Append(d...)
+		return list, diags
+	default:
+		return types.StringValue(fmt.Sprint(value)), diags
+	}
+}
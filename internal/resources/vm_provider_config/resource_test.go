@@ -0,0 +1,54 @@
+//go:build unit
+// +build unit
+
+package vm_provider_config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVMProviderConfigResources_Metadata(t *testing.T) {
+	testCases := map[string]func() resource.Resource{
+		"kasm_aws_vm_provider_config":          NewAWS,
+		"kasm_azure_vm_provider_config":        NewAzure,
+		"kasm_gcp_vm_provider_config":          NewGCP,
+		"kasm_digitalocean_vm_provider_config": NewDigitalOcean,
+		"kasm_oci_vm_provider_config":          NewOCI,
+	}
+
+	for typeName, newResource := range testCases {
+		t.Run(typeName, func(t *testing.T) {
+			resp := &resource.MetadataResponse{}
+			newResource().Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "kasm"}, resp)
+			assert.Equal(t, typeName, resp.TypeName)
+		})
+	}
+}
+
+func TestVMProviderConfigResources_Schema(t *testing.T) {
+	for _, newResource := range []func() resource.Resource{NewAWS, NewAzure, NewGCP, NewDigitalOcean, NewOCI} {
+		r := newResource().(*vmProviderConfigResource)
+		resp := &resource.SchemaResponse{}
+		r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+		assert.False(t, resp.Diagnostics.HasError(), r.cloud.typeName)
+		assert.True(t, resp.Schema.Attributes["name"].IsRequired(), r.cloud.typeName)
+
+		secrets := 0
+		for _, s := range r.cloud.settings {
+			attribute := resp.Schema.Attributes[s.name]
+			if assert.NotNil(t, attribute, s.name) {
+				assert.Equal(t, s.required, attribute.IsRequired(), s.name)
+				assert.Equal(t, s.sensitive, attribute.IsSensitive(), s.name)
+			}
+			if s.sensitive {
+				secrets++
+			}
+		}
+		assert.NotZero(t, secrets, "%s has no sensitive settings", r.cloud.typeName)
+	}
+}
@@ -0,0 +1,370 @@
+package zone
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-kasm/internal/client"
+	"terraform-provider-kasm/internal/schemautil"
+	"terraform-provider-kasm/internal/validators"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &zoneResource{}
+	_ resource.ResourceWithConfigure   = &zoneResource{}
+	_ resource.ResourceWithImportState = &zoneResource{}
+)
+
+// New is a helper function to simplify the provider implementation.
+func New() resource.Resource {
+	return &zoneResource{}
+}
+
+// zoneResource manages a Kasm deployment zone.
+type zoneResource struct {
+	client *client.Client
+}
+
+// zoneResourceModel maps the resource schema data.
+type zoneResourceModel struct {
+	ID                     types.String `tfsdk:"id"`
+	Name                   types.String `tfsdk:"name"`
+	LoadBalancingStrategy  types.String `tfsdk:"load_balancing_strategy"`
+	SearchAlternateZones   types.Bool   `tfsdk:"search_alternate_zones"`
+	PrioritizeStaticAgents types.Bool   `tfsdk:"prioritize_static_agents"`
+	UpstreamAuthAddress    types.String `tfsdk:"upstream_auth_address"`
+	AllowOriginDomain      types.String `tfsdk:"allow_origin_domain"`
+	ProxyHostname          types.String `tfsdk:"proxy_hostname"`
+	ProxyPath              types.String `tfsdk:"proxy_path"`
+	ProxyPort              types.Int64  `tfsdk:"proxy_port"`
+	AutoScalingEnabled     types.Bool   `tfsdk:"auto_scaling_enabled"`
+	AWSEnabled             types.Bool   `tfsdk:"aws_enabled"`
+	AWSRegion              types.String `tfsdk:"aws_region"`
+	AWSAccessKeyID         types.String `tfsdk:"aws_access_key_id"`
+	AWSSecretAccessKey     types.String `tfsdk:"aws_secret_access_key"`
+	EC2AgentAMIID          types.String `tfsdk:"ec2_agent_ami_id"`
+}
+
+// Metadata returns the resource type name.
+func (r *zoneResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone"
+}
+
+// Schema defines the schema for the resource.
+func (r *zoneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Attributes Kasm fills in with its own defaults when they are not set.
+	resp.Schema = schema.Schema{
+		Description: "Manages a Kasm deployment zone, a group of agents and the settings sessions use to reach them.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the zone.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the zone.",
+				Required:    true,
+			},
+			"load_balancing_strategy": schema.StringAttribute{
+				Description: fmt.Sprintf("How new sessions are placed on the zone's agents: one of %s. Defaults to %s.",
+					strings.Join(client.ZoneLoadBalancingStrategies, ", "), client.ZoneLoadBalancingLeastLoad),
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(client.ZoneLoadBalancingLeastLoad),
+				Validators: []validator.String{
+					validators.StringOneOf(client.ZoneLoadBalancingStrategies...),
+				},
+			},
+			"search_alternate_zones": schema.BoolAttribute{
+				Description: "Whether sessions can be placed in another zone when this zone has no capacity. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"prioritize_static_agents": schema.BoolAttribute{
+				Description: "Whether static agents are used before auto-scaled agents. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"upstream_auth_address": schemautil.ServerDefaultString("The address agents use to authenticate sessions against the Kasm web servers."),
+			"allow_origin_domain":   schemautil.ServerDefaultString("The domain allowed as the origin of session connections."),
+			"proxy_hostname":        schemautil.ServerDefaultString("The hostname clients use to connect to sessions in this zone."),
+			"proxy_path":            schemautil.ServerDefaultString("The path clients use to connect to sessions in this zone."),
+			"proxy_port": schema.Int64Attribute{
+				Description: "The port clients use to connect to sessions in this zone. 0 uses the port the client reached Kasm on.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 65535),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"auto_scaling_enabled": schema.BoolAttribute{
+				Description: "Whether agents are auto-scaled in this zone. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"aws_enabled": schema.BoolAttribute{
+				Description: "Whether the legacy AWS integration is enabled. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"aws_region": schema.StringAttribute{
+				Description: "The AWS region of the legacy AWS integration.",
+				Optional:    true,
+			},
+			"aws_access_key_id": schema.StringAttribute{
+				Description: "The AWS access key ID of the legacy AWS integration.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"aws_secret_access_key": schema.StringAttribute{
+				Description: "The AWS secret access key of the legacy AWS integration.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"ec2_agent_ami_id": schema.StringAttribute{
+				Description: "The AMI of auto-scaled EC2 agents of the legacy AWS integration.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *zoneResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates the zone.
+func (r *zoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan zoneResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := r.client.CreateZone(ctx, expandZone(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Zone",
+			fmt.Sprintf("Could not create zone %s: %s", plan.Name.ValueString(), err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(zone.ZoneID)
+	flattenServerDefaults(&plan, zone, false)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the zone from the server.
+func (r *zoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state zoneResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := r.client.GetZone(ctx, state.ID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Debug(ctx, "Zone not found, removing from state", map[string]interface{}{
+				"id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Zone",
+			fmt.Sprintf("Could not read zone %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	state.Name = types.StringValue(zone.ZoneName)
+	if zone.LoadBalancingStrategy != "" {
+		state.LoadBalancingStrategy = types.StringValue(zone.LoadBalancingStrategy)
+	}
+	state.SearchAlternateZones = types.BoolValue(zone.SearchAlternateZones)
+	state.PrioritizeStaticAgents = types.BoolValue(zone.PrioritizeStaticAgents)
+	state.AutoScalingEnabled = types.BoolValue(zone.AutoScalingEnabled)
+	state.AWSEnabled = types.BoolValue(zone.AWSEnabled)
+	state.AWSRegion = schemautil.OptionalString(state.AWSRegion, zone.AWSRegion)
+	state.AWSAccessKeyID = schemautil.OptionalString(state.AWSAccessKeyID, zone.AWSAccessKeyID)
+	state.EC2AgentAMIID = schemautil.OptionalString(state.EC2AgentAMIID, zone.EC2AgentAMIID)
+	// The server may not return the secret key; keep what was applied.
+	if zone.AWSSecretAccessKey != "" {
+		state.AWSSecretAccessKey = types.StringValue(zone.AWSSecretAccessKey)
+	}
+	flattenServerDefaults(&state, zone, true)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the zone in place.
+func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan zoneResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone := expandZone(plan)
+	zone.ZoneID = plan.ID.ValueString()
+	updated, err := r.client.UpdateZone(ctx, zone)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Zone",
+			fmt.Sprintf("Could not update zone %s: %s", plan.ID.ValueString(), err),
+		)
+		return
+	}
+
+	flattenServerDefaults(&plan, updated, false)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the zone.
+func (r *zoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state zoneResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteZone(ctx, state.ID.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Zone",
+			fmt.Sprintf("Could not delete zone %s: %s", state.ID.ValueString(), err),
+		)
+	}
+}
+
+// ImportState imports a zone by ID or by name.
+func (r *zoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if name, ok := strings.CutPrefix(req.ID, "name:"); ok {
+		zones, err := r.client.GetZones(ctx, true)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Zone",
+				fmt.Sprintf("Could not list zones: %s", err),
+			)
+			return
+		}
+
+		id = ""
+		for _, zone := range zones {
+			if zone.ZoneName == name {
+				id = zone.ZoneID
+				break
+			}
+		}
+		if id == "" {
+			resp.Diagnostics.AddError(
+				"Zone Not Found",
+				fmt.Sprintf("Could not find a zone named %s", name),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// expandZone builds the API object from the plan. Unknown attributes are
+// left empty so that the server applies its defaults.
+func expandZone(plan zoneResourceModel) *client.Zone {
+	zone := &client.Zone{
+		ZoneName:               plan.Name.ValueString(),
+		LoadBalancingStrategy:  plan.LoadBalancingStrategy.ValueString(),
+		SearchAlternateZones:   plan.SearchAlternateZones.ValueBool(),
+		PrioritizeStaticAgents: plan.PrioritizeStaticAgents.ValueBool(),
+		UpstreamAuthAddress:    plan.UpstreamAuthAddress.ValueString(),
+		AllowOriginDomain:      plan.AllowOriginDomain.ValueString(),
+		ProxyHostname:          plan.ProxyHostname.ValueString(),
+		ProxyPath:              plan.ProxyPath.ValueString(),
+		AutoScalingEnabled:     plan.AutoScalingEnabled.ValueBool(),
+		AWSEnabled:             plan.AWSEnabled.ValueBool(),
+		AWSRegion:              plan.AWSRegion.ValueString(),
+		AWSAccessKeyID:         plan.AWSAccessKeyID.ValueString(),
+		AWSSecretAccessKey:     plan.AWSSecretAccessKey.ValueString(),
+		EC2AgentAMIID:          plan.EC2AgentAMIID.ValueString(),
+	}
+	if !plan.ProxyPort.IsNull() && !plan.ProxyPort.IsUnknown() {
+		port := int(plan.ProxyPort.ValueInt64())
+		zone.ProxyPort = &port
+	}
+	return zone
+}
+
+// flattenServerDefaults copies the attributes the server may default into
+// the model. After a create or update only unknown attributes are filled in,
+// so that the applied configuration is kept; a refresh copies them all.
+func flattenServerDefaults(model *zoneResourceModel, zone *client.Zone, refresh bool) {
+	set := func(current *types.String, value string) {
+		if refresh || current.IsUnknown() {
+			*current = types.StringValue(value)
+		}
+	}
+	set(&model.UpstreamAuthAddress, zone.UpstreamAuthAddress)
+	set(&model.AllowOriginDomain, zone.AllowOriginDomain)
+	set(&model.ProxyHostname, zone.ProxyHostname)
+	set(&model.ProxyPath, zone.ProxyPath)
+
+	if !refresh && !model.ProxyPort.IsUnknown() {
+		return
+	}
+	if zone.ProxyPort != nil {
+		model.ProxyPort = types.Int64Value(int64(*zone.ProxyPort))
+	} else if model.ProxyPort.IsUnknown() {
+		model.ProxyPort = types.Int64Null()
+	}
+}
@@ -0,0 +1,36 @@
+//go:build unit
+// +build unit
+
+package zone
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZoneResource_Metadata(t *testing.T) {
+	r := New()
+	resp := &resource.MetadataResponse{}
+
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "kasm"}, resp)
+
+	assert.Equal(t, "kasm_zone", resp.TypeName)
+}
+
+func TestZoneResource_Schema(t *testing.T) {
+	r := New()
+	resp := &resource.SchemaResponse{}
+
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.True(t, resp.Schema.Attributes["name"].IsRequired())
+	assert.True(t, resp.Schema.Attributes["aws_secret_access_key"].IsSensitive())
+	assert.True(t, resp.Schema.Attributes["aws_access_key_id"].IsSensitive())
+	for _, name := range []string{"load_balancing_strategy", "proxy_hostname", "proxy_port", "upstream_auth_address", "search_alternate_zones"} {
+		assert.True(t, resp.Schema.Attributes[name].IsOptional(), name)
+	}
+}
@@ -0,0 +1,33 @@
+// Package schemautil holds schema attributes and state helpers shared by the
+// provider's resources.
+package schemautil
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ServerDefaultString returns an optional string attribute whose value the
+// server chooses when it is not configured. The chosen value is kept across
+// plans.
+func ServerDefaultString(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: description,
+		Optional:    true,
+		Computed:    true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+// OptionalString keeps an unset optional attribute null when the server
+// reports it as empty.
+func OptionalString(current types.String, value string) types.String {
+	if value == "" && current.IsNull() {
+		return current
+	}
+	return types.StringValue(value)
+}
@@ -0,0 +1,26 @@
+//go:build unit
+// +build unit
+
+package schemautil
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionalString(t *testing.T) {
+	assert.True(t, OptionalString(types.StringNull(), "").IsNull())
+	assert.Equal(t, types.StringValue(""), OptionalString(types.StringValue("old"), ""))
+	assert.Equal(t, types.StringValue("new"), OptionalString(types.StringNull(), "new"))
+}
+
+func TestServerDefaultString(t *testing.T) {
+	attr := ServerDefaultString("The search filter.")
+
+	assert.True(t, attr.IsOptional())
+	assert.True(t, attr.IsComputed())
+	assert.Equal(t, "The search filter.", attr.GetDescription())
+	assert.Len(t, attr.StringPlanModifiers(), 1)
+}
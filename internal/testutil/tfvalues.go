@@ -0,0 +1,70 @@
+// Package testutil builds Terraform configuration, plan and state values for
+// unit tests of the provider's resources.
+package testutil
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Attrs sets attributes of an object value by name. A value is either a
+// tftypes.Value or a Go value accepted by tftypes.NewValue for the type of
+// the attribute, such as a string, a bool, nil or tftypes.UnknownValue.
+// Attributes that are not listed are null.
+type Attrs map[string]interface{}
+
+// ResourceSchema returns the schema of r.
+func ResourceSchema(ctx context.Context, r resource.Resource) schema.Schema {
+	resp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, resp)
+	if resp.Diagnostics.HasError() {
+		panic(fmt.Sprintf("invalid resource schema: %v", resp.Diagnostics))
+	}
+	return resp.Schema
+}
+
+// ObjectValue returns a value of the object type of s with the attributes
+// in attrs set and every other attribute null.
+func ObjectValue(ctx context.Context, s schema.Schema, attrs Attrs) tftypes.Value {
+	objectType := s.Type().TerraformType(ctx).(tftypes.Object)
+
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range attrs {
+		attrType, ok := objectType.AttributeTypes[name]
+		if !ok {
+			panic(fmt.Sprintf("schema has no attribute %q", name))
+		}
+		if v, ok := value.(tftypes.Value); ok {
+			values[name] = v
+			continue
+		}
+		values[name] = tftypes.NewValue(attrType, value)
+	}
+	return tftypes.NewValue(objectType, values)
+}
+
+// Config returns the configuration of r with attrs set.
+func Config(ctx context.Context, r resource.Resource, attrs Attrs) tfsdk.Config {
+	s := ResourceSchema(ctx, r)
+	return tfsdk.Config{Schema: s, Raw: ObjectValue(ctx, s, attrs)}
+}
+
+// Plan returns a plan for r with attrs set.
+func Plan(ctx context.Context, r resource.Resource, attrs Attrs) tfsdk.Plan {
+	s := ResourceSchema(ctx, r)
+	return tfsdk.Plan{Schema: s, Raw: ObjectValue(ctx, s, attrs)}
+}
+
+// State returns a state for r with attrs set.
+func State(ctx context.Context, r resource.Resource, attrs Attrs) tfsdk.State {
+	s := ResourceSchema(ctx, r)
+	return tfsdk.State{Schema: s, Raw: ObjectValue(ctx, s, attrs)}
+}
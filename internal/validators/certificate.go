@@ -0,0 +1,158 @@
+package validators
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// X509Certificate returns a validator which ensures that any configured
+// string value holds one or more X.509 certificates, and warns when one of
+// them has expired.
+func X509Certificate() validator.String {
+	return ConfigSyntaxValidator{
+		Desc:  "must be a PEM encoded X.509 certificate, or the base64 body of one",
+		Parse: ParseX509Certificates,
+		Warn:  CheckX509CertificatesExpiry,
+	}
+}
+
+// PEMPrivateKey returns a validator which ensures that any configured string
+// value is a PEM encoded RSA, ECDSA or Ed25519 private key.
+func PEMPrivateKey() validator.String {
+	return ConfigSyntaxValidator{
+		Desc:  "must be a PEM encoded private key",
+		Parse: ParsePrivateKey,
+	}
+}
+
+// ParseX509Certificates checks s, which is either a series of PEM
+// CERTIFICATE blocks or the base64 body of a single certificate as identity
+// providers often show it, and returns an error describing the first
+// certificate that cannot be parsed. Expiry is not checked.
+func ParseX509Certificates(s string) error {
+	_, err := parseX509Certificates(s)
+	return err
+}
+
+// CheckX509CertificatesExpiry returns an error describing the first expired
+// certificate in s. Values ParseX509Certificates rejects are not reported.
+func CheckX509CertificatesExpiry(s string) error {
+	certs, err := parseX509Certificates(s)
+	if err != nil {
+		return nil
+	}
+
+	now := time.Now()
+	for i, cert := range certs {
+		if now.After(cert.NotAfter) {
+			return fmt.Errorf("certificate %d (%s) expired on %s", i+1, cert.Subject.CommonName, cert.NotAfter.UTC().Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+func parseX509Certificates(s string) ([]*x509.Certificate, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, fmt.Errorf("no certificate found")
+	}
+
+	if !strings.HasPrefix(s, "-----BEGIN") {
+		der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+		if err != nil {
+			return nil, fmt.Errorf("certificate is neither PEM nor base64: %v", err)
+		}
+		cert, err := parseCertificate(1, der)
+		if err != nil {
+			return nil, err
+		}
+		return []*x509.Certificate{cert}, nil
+	}
+
+	var certs []*x509.Certificate
+	rest := []byte(s)
+	for n := 1; ; n++ {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			if n == 1 {
+				return nil, fmt.Errorf("no PEM block found")
+			}
+			if strings.TrimSpace(string(rest)) != "" {
+				return nil, fmt.Errorf("unexpected text after certificate %d", n-1)
+			}
+			return certs, nil
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("PEM block %d is a %s, not a CERTIFICATE", n, block.Type)
+		}
+		cert, err := parseCertificate(n, block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+}
+
+func parseCertificate(n int, der []byte) (*x509.Certificate, error) {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("certificate %d is not a valid X.509 certificate: %v", n, err)
+	}
+	return cert, nil
+}
+
+// ParsePrivateKey checks that s is a single PEM encoded private key in
+// PKCS #1, PKCS #8 or SEC 1 form.
+func ParsePrivateKey(s string) error {
+	block, rest := pem.Decode([]byte(strings.TrimSpace(s)))
+	if block == nil {
+		return fmt.Errorf("no PEM block found")
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return fmt.Errorf("unexpected text after the private key")
+	}
+
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		_, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		_, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		_, err = x509.ParseECPrivateKey(block.Bytes)
+	case "ENCRYPTED PRIVATE KEY":
+		return fmt.Errorf("the private key is encrypted; Kasm needs it unencrypted")
+	default:
+		return fmt.Errorf("PEM block is a %s, not a private key", block.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s: %v", strings.ToLower(block.Type), err)
+	}
+	return nil
+}
+
+// MatchKeyPair checks that the first certificate in cert, in either form
+// accepted by ParseX509Certificates, belongs to the PEM encoded private key.
+func MatchKeyPair(cert, key string) error {
+	cert = strings.TrimSpace(cert)
+	if !strings.HasPrefix(cert, "-----BEGIN") {
+		der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(cert), ""))
+		if err != nil {
+			return fmt.Errorf("certificate is neither PEM nor base64: %v", err)
+		}
+		cert = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	}
+
+	if _, err := tls.X509KeyPair([]byte(cert), []byte(strings.TrimSpace(key))); err != nil {
+		return fmt.Errorf("the certificate does not match the private key: %v", err)
+	}
+	return nil
+}
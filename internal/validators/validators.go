@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"regexp"
+	"time"
 )
 
 // StringValidator is a custom string validator
@@ -123,6 +124,45 @@ func ValidateURL() validator.String {
 	}
 }
 
+// LDAPURL returns a validator which ensures that any configured string value
+// is an ldap:// or ldaps:// address.
+func LDAPURL() validator.String {
+	return StringValidator{
+		Desc: "must be an ldap:// or ldaps:// URL",
+		ValidateFn: func(val string) bool {
+			matched, _ := regexp.MatchString(`^ldaps?://[^/\s]+`, val)
+			return matched
+		},
+		ErrMessage: "URL must start with ldap:// or ldaps:// followed by a host",
+	}
+}
+
+// Duration returns a validator which ensures that any configured string
+// value is a positive Go duration such as "500ms" or "2m".
+func Duration() validator.String {
+	return StringValidator{
+		Desc: "must be a positive duration such as \"500ms\" or \"2m\"",
+		ValidateFn: func(val string) bool {
+			d, err := time.ParseDuration(val)
+			return err == nil && d > 0
+		},
+		ErrMessage: "value must be a positive duration such as \"500ms\" or \"2m\"",
+	}
+}
+
+// RFC3339 returns a validator which ensures that any configured string value
+// is a timestamp such as "2024-01-02T15:04:05Z".
+func RFC3339() validator.String {
+	return StringValidator{
+		Desc: "must be an RFC 3339 timestamp such as \"2024-01-02T15:04:05Z\"",
+		ValidateFn: func(val string) bool {
+			_, err := time.Parse(time.RFC3339, val)
+			return err == nil
+		},
+		ErrMessage: "value must be an RFC 3339 timestamp such as \"2024-01-02T15:04:05Z\"",
+	}
+}
+
 func Int64AtLeast(min int64) validator.Int64 {
 	return Int64Validator{
 		Desc: fmt.Sprintf("must be at least %d", min),
@@ -132,3 +172,13 @@ func Int64AtLeast(min int64) validator.Int64 {
 		ErrMessage: fmt.Sprintf("value must be at least %d", min),
 	}
 }
+
+func Float64GreaterThan(min float64) validator.Float64 {
+	return Float64Validator{
+		Desc: fmt.Sprintf("must be greater than %g", min),
+		ValidateFn: func(val float64) bool {
+			return val > min
+		},
+		ErrMessage: fmt.Sprintf("value must be greater than %g", min),
+	}
+}
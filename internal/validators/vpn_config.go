@@ -0,0 +1,443 @@
+package validators
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// ConfigSyntaxValidator checks a configuration file held in a string
+// attribute and reports the first syntax error with its line number. Warn,
+// if set, is run on values that parse and its error is reported as a
+// warning.
+type ConfigSyntaxValidator struct {
+	Desc  string
+	Parse func(string) error
+	Warn  func(string) error
+}
+
+func (v ConfigSyntaxValidator) Description(ctx context.Context) string {
+	return v.Desc
+}
+
+func (v ConfigSyntaxValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ConfigSyntaxValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := v.Parse(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid configuration",
+			err.Error(),
+		)
+		return
+	}
+
+	if v.Warn == nil {
+		return
+	}
+	if err := v.Warn(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"Configuration warning",
+			err.Error(),
+		)
+	}
+}
+
+// WireGuardConfig returns a validator which ensures that any configured
+// string value is a WireGuard configuration in wg-quick format.
+func WireGuardConfig() validator.String {
+	return ConfigSyntaxValidator{
+		Desc:  "must be a WireGuard configuration with an [Interface] and at least one [Peer] section",
+		Parse: ParseWireGuardConfig,
+	}
+}
+
+// OpenVPNConfig returns a validator which ensures that any configured string
+// value is an OpenVPN client profile.
+func OpenVPNConfig() validator.String {
+	return ConfigSyntaxValidator{
+		Desc:  "must be an OpenVPN client profile with dev and remote directives",
+		Parse: ParseOpenVPNConfig,
+	}
+}
+
+// wireGuardKeys lists the keys accepted in each WireGuard section, in lower
+// case, including the wg-quick extensions. The value says whether the key
+// is required.
+var wireGuardKeys = map[string]map[string]bool{
+	"interface": {
+		"privatekey": true,
+		"listenport": false,
+		"fwmark":     false,
+		"address":    false,
+		"dns":        false,
+		"mtu":        false,
+		"table":      false,
+		"preup":      false,
+		"postup":     false,
+		"predown":    false,
+		"postdown":   false,
+		"saveconfig": false,
+	},
+	"peer": {
+		"publickey":           true,
+		"presharedkey":        false,
+		"allowedips":          false,
+		"endpoint":            false,
+		"persistentkeepalive": false,
+	},
+}
+
+// wireGuardRepeatableKeys lists the keys that may be given more than once in
+// a section; their values are combined.
+var wireGuardRepeatableKeys = map[string]bool{
+	"address":    true,
+	"dns":        true,
+	"allowedips": true,
+	"preup":      true,
+	"postup":     true,
+	"predown":    true,
+	"postdown":   true,
+}
+
+// ParseWireGuardConfig checks that s is a wg-quick configuration: exactly one
+// [Interface] section with a PrivateKey, at least one [Peer] section with a
+// PublicKey, and well-formed keys, addresses and ports.
+func ParseWireGuardConfig(s string) error {
+	var (
+		section    string
+		seen       map[string]bool
+		interfaces int
+		peers      int
+	)
+	closeSection := func(line int) error {
+		for key, required := range wireGuardKeys[section] {
+			if required && !seen[key] {
+				return fmt.Errorf("line %d: [%s] section is missing %s", line, sectionName(section), keyName(key))
+			}
+		}
+		return nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return fmt.Errorf("line %d: malformed section header", lineNo)
+			}
+			if section != "" {
+				if err := closeSection(lineNo); err != nil {
+					return err
+				}
+			}
+			section = strings.ToLower(strings.TrimSpace(line[1 : len(line)-1]))
+			seen = make(map[string]bool)
+			switch section {
+			case "interface":
+				interfaces++
+				if interfaces > 1 {
+					return fmt.Errorf("line %d: only one [Interface] section is allowed", lineNo)
+				}
+			case "peer":
+				if interfaces == 0 {
+					return fmt.Errorf("line %d: [Peer] section before [Interface]", lineNo)
+				}
+				peers++
+			default:
+				if isConfigName(section) {
+					return fmt.Errorf("line %d: unknown section [%s]", lineNo, section)
+				}
+				return fmt.Errorf("line %d: unknown section", lineNo)
+			}
+			continue
+		}
+
+		// Lines may hold key material, so errors name the line and, when it
+		// parses, the key, but never echo the line itself.
+		rawKey, value, ok := strings.Cut(line, "=")
+		rawKey = strings.TrimSpace(rawKey)
+		if section == "" {
+			if ok && isConfigName(rawKey) {
+				return fmt.Errorf("line %d: %s is outside of a section", lineNo, rawKey)
+			}
+			return fmt.Errorf("line %d: text outside of a section", lineNo)
+		}
+		if !ok {
+			return fmt.Errorf("line %d: expected \"Key = Value\"", lineNo)
+		}
+		key := strings.ToLower(rawKey)
+		value = strings.TrimSpace(value)
+		if _, known := wireGuardKeys[section][key]; !known {
+			if isConfigName(rawKey) {
+				return fmt.Errorf("line %d: unknown key %q in [%s] section", lineNo, rawKey, sectionName(section))
+			}
+			return fmt.Errorf("line %d: unknown key in [%s] section", lineNo, sectionName(section))
+		}
+		if seen[key] && !wireGuardRepeatableKeys[key] {
+			return fmt.Errorf("line %d: duplicate key %s", lineNo, keyName(key))
+		}
+		seen[key] = true
+		if err := checkWireGuardValue(key, value); err != nil {
+			return fmt.Errorf("line %d: %s: %w", lineNo, keyName(key), err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if interfaces == 0 {
+		return fmt.Errorf("missing [Interface] section")
+	}
+	if err := closeSection(lineNo); err != nil {
+		return err
+	}
+	if peers == 0 {
+		return fmt.Errorf("missing [Peer] section")
+	}
+	return nil
+}
+
+// checkWireGuardValue validates the value of a single WireGuard key.
+func checkWireGuardValue(key, value string) error {
+	switch key {
+	case "privatekey", "publickey", "presharedkey":
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil || len(decoded) != 32 {
+			return fmt.Errorf("must be a base64 encoded 32 byte key")
+		}
+	case "listenport":
+		return checkPort(value)
+	case "mtu", "persistentkeepalive":
+		if n, err := strconv.Atoi(value); err != nil || n < 0 {
+			return fmt.Errorf("must be a non-negative number, got %q", value)
+		}
+	case "address", "allowedips":
+		for _, prefix := range splitList(value) {
+			if _, err := netip.ParsePrefix(prefix); err != nil {
+				if _, err := netip.ParseAddr(prefix); err != nil {
+					return fmt.Errorf("%q is not an IP address or CIDR", prefix)
+				}
+			}
+		}
+	case "endpoint":
+		host, port, err := net.SplitHostPort(value)
+		if err != nil || host == "" {
+			return fmt.Errorf("must be host:port, got %q", value)
+		}
+		return checkPort(port)
+	}
+	return nil
+}
+
+// openVPNInlineBlocks lists the directives that can be given inline as
+// <name>...</name>.
+var openVPNInlineBlocks = map[string]bool{
+	"ca":                   true,
+	"cert":                 true,
+	"key":                  true,
+	"tls-auth":             true,
+	"tls-crypt":            true,
+	"tls-crypt-v2":         true,
+	"secret":               true,
+	"pkcs12":               true,
+	"extra-certs":          true,
+	"dh":                   true,
+	"auth-user-pass":       true,
+	"http-proxy-user-pass": true,
+	"peer-fingerprint":     true,
+	"connection":           true,
+}
+
+// ParseOpenVPNConfig checks that s is an OpenVPN client profile: a dev
+// directive, at least one remote, well-formed remote ports and balanced,
+// non-empty inline blocks.
+func ParseOpenVPNConfig(s string) error {
+	var (
+		block      string
+		blockStart int
+		blockLines int
+		hasDev     bool
+		hasRemote  bool
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+
+		if block != "" {
+			if strings.EqualFold(line, "</"+block+">") {
+				if blockLines == 0 {
+					return fmt.Errorf("line %d: <%s> block is empty", blockStart, block)
+				}
+				block = ""
+				continue
+			}
+			if strings.HasPrefix(line, "</") {
+				return fmt.Errorf("line %d: closing tag does not close <%s> opened on line %d", lineNo, block, blockStart)
+			}
+			if line != "" {
+				blockLines++
+			}
+			// A <connection> block holds directives; other blocks hold data.
+			if block != "connection" {
+				continue
+			}
+		}
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "<") {
+			if !strings.HasSuffix(line, ">") || strings.HasPrefix(line, "</") {
+				return fmt.Errorf("line %d: malformed inline block tag", lineNo)
+			}
+			if block != "" {
+				return fmt.Errorf("line %d: <%s> block cannot be nested", lineNo, block)
+			}
+			name := strings.ToLower(line[1 : len(line)-1])
+			if !openVPNInlineBlocks[name] {
+				if isConfigName(name) {
+					return fmt.Errorf("line %d: unknown inline block <%s>", lineNo, name)
+				}
+				return fmt.Errorf("line %d: unknown inline block", lineNo)
+			}
+			block, blockStart, blockLines = name, lineNo, 0
+			continue
+		}
+
+		fields := strings.Fields(line)
+		switch strings.ToLower(fields[0]) {
+		case "dev":
+			if len(fields) < 2 {
+				return fmt.Errorf("line %d: dev needs a device such as tun or tap", lineNo)
+			}
+			hasDev = true
+		case "remote":
+			if len(fields) < 2 {
+				return fmt.Errorf("line %d: remote needs a host", lineNo)
+			}
+			if len(fields) > 2 {
+				if err := checkPort(fields[2]); err != nil {
+					return fmt.Errorf("line %d: remote: %w", lineNo, err)
+				}
+			}
+			hasRemote = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if block != "" {
+		return fmt.Errorf("line %d: <%s> block is not closed", blockStart, block)
+	}
+	if !hasDev {
+		return fmt.Errorf("missing dev directive")
+	}
+	if !hasRemote {
+		return fmt.Errorf("missing remote directive")
+	}
+	return nil
+}
+
+func checkPort(value string) error {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("%q is not a port number", value)
+	}
+	return nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// isConfigName reports whether s looks like a section, key or block name
+// rather than data, so that error messages can quote it without risking
+// key material. Base64 keys are longer than any name.
+func isConfigName(s string) bool {
+	if s == "" || len(s) > 32 {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && (r >= '0' && r <= '9' || r == '-' || r == '_'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// sectionName and keyName restore the conventional capitalisation of
+// WireGuard section and key names for error messages.
+func sectionName(section string) string {
+	switch section {
+	case "interface":
+		return "Interface"
+	case "peer":
+		return "Peer"
+	}
+	return section
+}
+
+func keyName(key string) string {
+	names := map[string]string{
+		"privatekey":          "PrivateKey",
+		"publickey":           "PublicKey",
+		"presharedkey":        "PresharedKey",
+		"allowedips":          "AllowedIPs",
+		"persistentkeepalive": "PersistentKeepalive",
+		"listenport":          "ListenPort",
+		"fwmark":              "FwMark",
+		"saveconfig":          "SaveConfig",
+		"preup":               "PreUp",
+		"postup":              "PostUp",
+		"predown":             "PreDown",
+		"postdown":            "PostDown",
+		"address":             "Address",
+		"endpoint":            "Endpoint",
+		"table":               "Table",
+		"dns":                 "DNS",
+		"mtu":                 "MTU",
+	}
+	if name, ok := names[key]; ok {
+		return name
+	}
+	return key
+}
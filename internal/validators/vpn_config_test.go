@@ -0,0 +1,213 @@
+//go:build unit
+// +build unit
+
+package validators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testWireGuardKey = "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="
+
+func TestParseWireGuardConfig(t *testing.T) {
+	valid := `[Interface]
+PrivateKey = ` + testWireGuardKey + `
+Address = 10.0.0.2/32, fd00::2/128
+DNS = 1.1.1.1
+
+# Exit node
+[Peer]
+PublicKey = ` + testWireGuardKey + `
+AllowedIPs = 0.0.0.0/0
+Endpoint = vpn.example.com:51820
+PersistentKeepalive = 25
+`
+
+	testCases := []struct {
+		name          string
+		config        string
+		errorContains string
+	}{
+		{name: "Valid", config: valid},
+		{
+			name:          "Missing peer",
+			config:        "[Interface]\nPrivateKey = " + testWireGuardKey + "\n",
+			errorContains: "missing [Peer] section",
+		},
+		{
+			name:          "Missing private key",
+			config:        "[Interface]\nAddress = 10.0.0.2/32\n[Peer]\nPublicKey = " + testWireGuardKey + "\n",
+			errorContains: "line 3: [Interface] section is missing PrivateKey",
+		},
+		{
+			name:          "Bad key",
+			config:        "[Interface]\nPrivateKey = not-a-key\n",
+			errorContains: "line 2: PrivateKey: must be a base64 encoded 32 byte key",
+		},
+		{
+			name:          "Bad endpoint port",
+			config:        "[Interface]\nPrivateKey = " + testWireGuardKey + "\n[Peer]\nPublicKey = " + testWireGuardKey + "\nEndpoint = vpn.example.com:70000\n",
+			errorContains: "line 5: Endpoint",
+		},
+		{
+			name:          "Unknown key",
+			config:        "[Interface]\nPrivateKey = " + testWireGuardKey + "\nColour = blue\n",
+			errorContains: `unknown key "Colour"`,
+		},
+		{
+			name:          "Duplicate key",
+			config:        "[Interface]\nPrivateKey = " + testWireGuardKey + "\nprivatekey = " + testWireGuardKey + "\n",
+			errorContains: "duplicate key PrivateKey",
+		},
+		{
+			name:          "Not a config",
+			config:        "hello",
+			errorContains: "outside of a section",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ParseWireGuardConfig(tc.config)
+			if tc.errorContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.errorContains)
+			}
+		})
+	}
+}
+
+func TestParseOpenVPNConfig(t *testing.T) {
+	valid := `client
+dev tun
+proto udp
+; primary server
+remote vpn.example.com 1194
+<ca>
+-----BEGIN CERTIFICATE-----
+MIIB
+-----END CERTIFICATE-----
+</ca>
+`
+
+	testCases := []struct {
+		name          string
+		config        string
+		errorContains string
+	}{
+		{name: "Valid", config: valid},
+		{
+			name:   "Remote in connection block",
+			config: "dev tun\n<connection>\nremote vpn.example.com 443 tcp\n</connection>\n",
+		},
+		{
+			name:          "Missing remote",
+			config:        "client\ndev tun\n",
+			errorContains: "missing remote directive",
+		},
+		{
+			name:          "Bad remote port",
+			config:        "dev tun\nremote vpn.example.com 0\n",
+			errorContains: "line 2: remote",
+		},
+		{
+			name:          "Unclosed block",
+			config:        "dev tun\nremote vpn.example.com\n<ca>\nMIIB\n",
+			errorContains: "line 3: <ca> block is not closed",
+		},
+		{
+			name:          "Mismatched block",
+			config:        "dev tun\nremote vpn.example.com\n<ca>\nMIIB\n</cert>\n",
+			errorContains: "does not close <ca>",
+		},
+		{
+			name:          "Empty block",
+			config:        "dev tun\nremote vpn.example.com\n<key>\n</key>\n",
+			errorContains: "<key> block is empty",
+		},
+		{
+			name:          "Unknown block",
+			config:        "dev tun\nremote vpn.example.com\n<foo>\nbar\n</foo>\n",
+			errorContains: "unknown inline block <foo>",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ParseOpenVPNConfig(tc.config)
+			if tc.errorContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.errorContains)
+			}
+		})
+	}
+}
+
+func TestVPNConfigErrorsOmitKeyMaterial(t *testing.T) {
+	keyBody := testWireGuardKey[:len(testWireGuardKey)-1]
+
+	testCases := []struct {
+		name   string
+		parse  func(string) error
+		config string
+	}{
+		{
+			name:   "WireGuard key outside of a section",
+			parse:  ParseWireGuardConfig,
+			config: "PrivateKey = " + testWireGuardKey + "\n[Interface]\n",
+		},
+		{
+			name:   "WireGuard malformed section header",
+			parse:  ParseWireGuardConfig,
+			config: "[Interface " + testWireGuardKey + "\n",
+		},
+		{
+			name:   "WireGuard unknown section",
+			parse:  ParseWireGuardConfig,
+			config: "[" + testWireGuardKey + "]\n",
+		},
+		{
+			name:   "WireGuard line without a key",
+			parse:  ParseWireGuardConfig,
+			config: "[Interface]\nPrivateKey " + testWireGuardKey + "\n",
+		},
+		{
+			name:   "WireGuard bare key",
+			parse:  ParseWireGuardConfig,
+			config: "[Interface]\n" + testWireGuardKey + "\n",
+		},
+		{
+			name:   "OpenVPN malformed tag",
+			parse:  ParseOpenVPNConfig,
+			config: "dev tun\n<key " + testWireGuardKey + "\n",
+		},
+		{
+			name:   "OpenVPN mismatched closing tag",
+			parse:  ParseOpenVPNConfig,
+			config: "dev tun\n<key>\n" + testWireGuardKey + "\n</" + testWireGuardKey + ">\n",
+		},
+		{
+			name:   "OpenVPN unknown block",
+			parse:  ParseOpenVPNConfig,
+			config: "dev tun\n<" + testWireGuardKey + ">\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.parse(tc.config)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), "line ")
+				assert.NotContains(t, err.Error(), keyBody)
+			}
+		})
+	}
+}